@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"referral-service/domain"
+	"referral-service/referralcode"
+	"referral-service/repository"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Contract to manage the additional referral codes of a member, ex. one per channel
+type CodeController interface {
+	AddReferralCode(ctx context.Context,
+		member_id string,
+		code *string,
+		channel *string,
+		expires_at *int64,
+	) (*domain.ReferralCode, error)
+	GetReferralCodes(ctx context.Context, member_id string) ([]domain.ReferralCode, error)
+	RevokeReferralCode(ctx context.Context, member_id string, code string) (*domain.ReferralCode, error)
+}
+
+type codeCon struct {
+	log    *zap.Logger
+	db     repository.Repository
+	codes  referralcode.Generator
+	vanity referralcode.VanityRules
+}
+
+type CodeParams struct {
+	fx.In
+
+	Log    *zap.Logger
+	Db     repository.Repository
+	Codes  referralcode.Generator
+	Vanity referralcode.VanityRules
+}
+
+func CodeNew(p CodeParams) CodeController {
+	newController := &codeCon{
+		log:    p.Log,
+		db:     p.Db,
+		codes:  p.Codes,
+		vanity: p.Vanity,
+	}
+
+	return newController
+}
+
+// AddReferralCode gives the member another code, generated unless code is given.
+// A code with an expiry stops resolving at expires_at, in unix seconds.
+func (c *codeCon) AddReferralCode(ctx context.Context,
+	member_id string,
+	code *string,
+	channel *string,
+	expires_at *int64) (*domain.ReferralCode, error) {
+	referralCode := domain.ReferralCode{MemberId: member_id}
+	if channel != nil {
+		referralCode.Channel = *channel
+	}
+	if expires_at != nil {
+		if *expires_at <= time.Now().UTC().Unix() {
+			return nil, domain.NewError(domain.ErrInvalidArgument, "expires_at must be in the future")
+		}
+		referralCode.ExpiresAt = *expires_at
+	}
+
+	member, err := c.db.GetMember(ctx, member_id)
+	if err != nil {
+		return nil, err
+	}
+	if code != nil {
+		referralCode.Code = referralcode.Normalize(*code)
+		if err := c.vanity.Check(referralCode.Code); err != nil {
+			return nil, err
+		}
+		err = c.db.AddReferralCode(ctx, referralCode)
+	} else {
+		var program domain.Program
+		program, err = c.db.GetProgram(ctx, member.ProgramId)
+		if err != nil {
+			return nil, err
+		}
+		err = generateCode(c.log, c.codes, program, func(generated string) error {
+			referralCode.Code = generated
+			return c.db.AddReferralCode(ctx, referralCode)
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := c.db.GetReferralCode(ctx, referralCode.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &added, nil
+}
+
+// GetReferralCodes lists every code the member has held, including retired, revoked and expired ones.
+func (c *codeCon) GetReferralCodes(ctx context.Context, member_id string) ([]domain.ReferralCode, error) {
+	if _, err := c.db.GetMember(ctx, member_id); err != nil {
+		return nil, err
+	}
+	return c.db.GetReferralCodes(ctx, member_id)
+}
+
+// RevokeReferralCode stops one of the member's codes from accepting referrals. Existing
+// referrals made with it are kept. The member's current code can't be revoked, a
+// member always has one working code.
+func (c *codeCon) RevokeReferralCode(ctx context.Context, member_id string, code string) (*domain.ReferralCode, error) {
+	code = referralcode.Normalize(code)
+	referralCode, err := c.db.GetReferralCode(ctx, code)
+	if err == nil && referralCode.MemberId != member_id {
+		// don't tell other members' codes apart from unknown ones.
+		err = domain.NewError(domain.ErrNotFound, "referral code %s not found", code)
+	}
+	if err != nil {
+		return nil, err
+	}
+	member, err := c.db.GetMember(ctx, member_id)
+	if err != nil {
+		return nil, err
+	}
+	if member.ReferralCode == code {
+		return nil, domain.NewError(domain.ErrConflict, "the member's current referral code can't be revoked")
+	}
+
+	if err := c.db.RevokeReferralCode(ctx, code); err != nil {
+		return nil, err
+	}
+	revoked, err := c.db.GetReferralCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return &revoked, nil
+}
+
+// resolveCode returns the member owning code and the code, which must be active.
+// Retired codes still resolve, revoked and expired ones don't. The member may be
+// inactive, callers accepting referrals or clicks check it themselves.
+// An unknown code failing the check character is reported as mistyped; known codes
+// resolve without it, as vanity codes and codes made before checksums were on have none.
+func resolveCode(ctx context.Context, db repository.Repository, codes referralcode.Generator, code string) (domain.Member, domain.ReferralCode, error) {
+	code = referralcode.Normalize(code)
+	referralCode, err := db.GetReferralCode(ctx, code)
+	if errors.Is(err, domain.ErrNotFound) && codes.Mistyped(code) {
+		return domain.Member{}, referralCode, domain.NewError(domain.ErrInvalidArgument, "referral code %s is mistyped", code)
+	}
+	if err != nil {
+		return domain.Member{}, referralCode, err
+	}
+	if !referralCode.Active(time.Now().UTC().Unix()) {
+		return domain.Member{}, referralCode, domain.ErrReferralCodeInactive
+	}
+	member, err := db.GetMember(ctx, referralCode.MemberId)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.Member{}, referralCode, domain.NewError(domain.ErrNotFound, "referral code %s not found", referralCode.Code)
+	}
+	return member, referralCode, err
+}
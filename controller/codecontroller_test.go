@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"referral-service/domain"
+	"referral-service/repository"
+
+	"go.uber.org/zap"
+)
+
+func TestResolveCodeChecksum(t *testing.T) {
+	ctx := context.Background()
+	db := repository.NewMemory(zap.NewNop())
+	codes := newCodes(t, true)
+	programId, err := db.AddProgram(ctx, "friends", "Friends", true, "", "", domain.RewardPolicy{}, domain.QualificationRule{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := codes.Generate("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.AddMember(ctx, "Ada", nil, "ada@example.com", programId, generated, nil); err != nil {
+		t.Fatal(err)
+	}
+	// vanity codes carry no check character.
+	if _, err := db.AddMember(ctx, "Bob", nil, "bob@example.com", programId, "bobby", nil); err != nil {
+		t.Fatal(err)
+	}
+	unknown, err := codes.Generate("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := generated[len(generated)-1]
+	mistyped := generated[:len(generated)-1] + "a"
+	if last == 'a' {
+		mistyped = generated[:len(generated)-1] + "b"
+	}
+
+	tests := []struct {
+		name string
+		code string
+		kind error
+	}{
+		{"generated", generated, nil},
+		{"generated in upper case", " " + strings.ToUpper(generated), nil},
+		{"vanity", "BOBBY", nil},
+		{"mistyped", mistyped, domain.ErrInvalidArgument},
+		{"unknown", unknown, domain.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := resolveCode(ctx, db, codes, tt.code)
+			if tt.kind == nil && err != nil || tt.kind != nil && !errors.Is(err, tt.kind) {
+				t.Fatalf("resolveCode(%q) = %v, want %v", tt.code, err, tt.kind)
+			}
+		})
+	}
+
+	// without check characters a mistyped code is just unknown.
+	if _, _, err := resolveCode(ctx, db, newCodes(t, false), mistyped); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("resolveCode(%q) without checksums = %v, want not found", mistyped, err)
+	}
+}
@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"referral-service/domain"
+	"referral-service/referralcode"
+	"referral-service/repository"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Contract to qualify referrals from what their referees do, ex. sign up or purchase
+type ConversionController interface {
+	IngestConversionEvent(ctx context.Context, conversion Conversion) (ConversionResult, error)
+	IngestConversionEvents(ctx context.Context, conversions []Conversion) ([]ConversionResult, error)
+}
+
+// Conversion is a conversion event as sent. It's matched to referrals by the referee's
+// Email or Phone, and by AttributionToken, the referral code of the attribution cookie
+// set when the referee followed a referral link.
+type Conversion struct {
+	EventId          string
+	EventType        string
+	AttributionToken string
+	Email            string
+	Phone            string
+	// ProgramId narrows email and phone matches to one program.
+	ProgramId   *string
+	OrderAmount int64
+	// OccurredAt is when the referee converted in unix seconds, 0 for now.
+	OccurredAt int64
+}
+
+// ConversionResult is what ingesting a conversion did. Reason says why the event
+// didn't qualify a referral, Duplicate is set for events already ingested.
+type ConversionResult struct {
+	Event     domain.ConversionEvent
+	Reason    string
+	Duplicate bool
+}
+
+// conversionChangedBy is the status changed by of referrals qualified by conversion events.
+const conversionChangedBy = "conversion-events"
+
+// maxConversionMatches bounds the referrals a contact is matched against.
+const maxConversionMatches = 100
+
+type conversionCon struct {
+	log *zap.Logger
+	db  repository.Repository
+}
+
+type ConversionParams struct {
+	fx.In
+
+	Log *zap.Logger
+	Db  repository.Repository
+}
+
+func ConversionNew(p ConversionParams) ConversionController {
+	newController := &conversionCon{
+		log: p.Log,
+		db:  p.Db,
+	}
+
+	return newController
+}
+
+// IngestConversionEvents ingests conversions one by one. On an error the earlier
+// conversions stay ingested, sending them again with their event ids is safe.
+func (c *conversionCon) IngestConversionEvents(ctx context.Context, conversions []Conversion) ([]ConversionResult, error) {
+	results := make([]ConversionResult, 0, len(conversions))
+	for _, conversion := range conversions {
+		result, err := c.IngestConversionEvent(ctx, conversion)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// IngestConversionEvent stores a conversion and qualifies the earliest pending referral
+// it matches whose program's qualification rule it meets.
+func (c *conversionCon) IngestConversionEvent(ctx context.Context, conversion Conversion) (ConversionResult, error) {
+	event := domain.ConversionEvent{
+		ExternalId:   conversion.EventId,
+		EventType:    conversion.EventType,
+		ContactEmail: domain.NormalizeEmail(conversion.Email),
+		ContactPhone: domain.NormalizePhone(conversion.Phone),
+		OrderAmount:  conversion.OrderAmount,
+		OccurredAt:   conversion.OccurredAt,
+	}
+	if event.OccurredAt == 0 {
+		event.OccurredAt = time.Now().UTC().Unix()
+	}
+	if conversion.AttributionToken == "" && event.ContactEmail == "" && event.ContactPhone == "" {
+		return ConversionResult{}, domain.NewError(domain.ErrInvalidArgument, "attribution token, email or phone is required")
+	}
+
+	if event.ExternalId != "" {
+		existing, err := c.db.GetConversionEvent(ctx, event.ExternalId)
+		if err == nil {
+			return ConversionResult{Event: existing, Duplicate: true}, nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return ConversionResult{}, err
+		}
+	}
+
+	candidates, reason, err := c.candidates(ctx, conversion, event)
+	if err != nil {
+		return ConversionResult{}, err
+	}
+	var change *domain.ReferralStatusChange
+	for i, referral := range candidates {
+		program, err := c.db.GetProgram(ctx, referral.ProgramId)
+		if err != nil {
+			return ConversionResult{}, err
+		}
+		unmet := program.Unmet(event, referral)
+		if unmet == "" && program.Expired(referral, event.OccurredAt) {
+			unmet = "event happened after the referral's attribution window"
+		}
+		if unmet != "" {
+			// the earliest referral explains why nothing qualified.
+			if i == 0 {
+				event.ReferralId = referral.ID
+				reason = unmet
+			}
+			continue
+		}
+		event.ReferralId = referral.ID
+		event.Qualified = true
+		reason = ""
+		change = &domain.ReferralStatusChange{
+			ReferralId: referral.ID,
+			FromStatus: referral.Status,
+			ToStatus:   domain.ReferralStatusQualified,
+			ChangedBy:  conversionChangedBy,
+			Reason:     event.EventType,
+		}
+		break
+	}
+
+	event.ID, err = c.db.AddConversionEvent(ctx, event, change)
+	if err != nil {
+		return ConversionResult{}, err
+	}
+	if change != nil {
+		c.log.Info("referral qualified by conversion event",
+			zap.String("referral_id", change.ReferralId),
+			zap.String("event_id", event.ID),
+			zap.String("event_type", event.EventType),
+		)
+	}
+	return ConversionResult{Event: event, Reason: reason}, nil
+}
+
+// candidates returns the pending referrals a conversion may qualify, earliest first,
+// or the reason there are none. An attribution token narrows them to the referrals made
+// with its code; as a code is shared by everyone its member referred, a token matching
+// several referrals needs the referee's email or phone to tell them apart.
+func (c *conversionCon) candidates(ctx context.Context, conversion Conversion, event domain.ConversionEvent) ([]domain.Referral, string, error) {
+	var code *string
+	if conversion.AttributionToken != "" {
+		token := referralcode.Normalize(conversion.AttributionToken)
+		_, err := c.db.GetReferralCode(ctx, token)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, "attribution token matches no referral code", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		code = &token
+	}
+
+	pending := domain.ReferralStatusPending
+	var filters []domain.ReferralFilter
+	if event.ContactEmail != "" {
+		filters = append(filters, domain.ReferralFilter{ContactEmail: &event.ContactEmail})
+	}
+	if event.ContactPhone != "" {
+		filters = append(filters, domain.ReferralFilter{ContactPhone: &event.ContactPhone})
+	}
+	if len(filters) == 0 {
+		filters = append(filters, domain.ReferralFilter{})
+	}
+	seen := map[string]bool{}
+	var candidates []domain.Referral
+	for _, filter := range filters {
+		filter.Status = &pending
+		filter.ProgramId = conversion.ProgramId
+		filter.ReferralCode = code
+		referrals, err := c.db.GetReferrals(ctx, filter, domain.Page{Number: 1, Size: maxConversionMatches})
+		if err != nil {
+			return nil, "", err
+		}
+		for _, referral := range referrals {
+			if !seen[referral.ID] {
+				seen[referral.ID] = true
+				candidates = append(candidates, referral)
+			}
+		}
+	}
+	switch {
+	case len(candidates) == 0 && code != nil:
+		return nil, "no pending referral made with the attribution token matches the event", nil
+	case len(candidates) == 0:
+		return nil, "no pending referral matches the event", nil
+	case len(candidates) > 1 && code != nil && event.ContactEmail == "" && event.ContactPhone == "":
+		return nil, "attribution token matches several pending referrals, email or phone is required", nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].CreatedAt != candidates[j].CreatedAt {
+			return candidates[i].CreatedAt < candidates[j].CreatedAt
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+	return candidates, "", nil
+}
@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"referral-service/domain"
+	"referral-service/repository"
+
+	"go.uber.org/zap"
+)
+
+// conversionFixture is a member of a program qualifying referrals on purchases,
+// with the referral controller adding its referrals.
+type conversionFixture struct {
+	db     repository.Repository
+	refs   *referralCon
+	c      *conversionCon
+	member domain.Member
+}
+
+func newConversionFixture(t *testing.T) conversionFixture {
+	t.Helper()
+	db := repository.NewMemory(zap.NewNop())
+	programId, err := db.AddProgram(context.Background(), "friends", "Friends", true, "", "", domain.RewardPolicy{},
+		domain.QualificationRule{QualifyEvent: "purchase"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conversionFixture{
+		db:     db,
+		refs:   newReferralCon(t, db),
+		c:      &conversionCon{log: zap.NewNop(), db: db},
+		member: addCodedMember(t, db, programId, "ada@example.com", "ada-7q2k"),
+	}
+}
+
+// addCodedMember enrols a member with the given referral code in programId and returns it.
+func addCodedMember(t *testing.T, db repository.Repository, programId string, email string, code string) domain.Member {
+	t.Helper()
+	ctx := context.Background()
+	id, err := db.AddMember(ctx, "Member", nil, email, programId, code, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	member, err := db.GetMember(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return member
+}
+
+// ingest ingests a purchase and fails the test on an error.
+func (f conversionFixture) ingest(t *testing.T, conversion Conversion) ConversionResult {
+	t.Helper()
+	conversion.EventType = "purchase"
+	result, err := f.c.IngestConversionEvent(context.Background(), conversion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// status returns the status of the referral with id.
+func (f conversionFixture) status(t *testing.T, id string) string {
+	t.Helper()
+	referral, err := f.db.GetReferral(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return referral.Status
+}
+
+func TestConversionMatching(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("token", func(t *testing.T) {
+		f := newConversionFixture(t)
+		id := addReferral(t, f.refs, f.member.ReferralCode, "cy@example.com")
+		// the cookie's code as sent back by a checkout, in any case.
+		result := f.ingest(t, Conversion{EventId: "e1", AttributionToken: " " + strings.ToUpper(f.member.ReferralCode)})
+		if !result.Event.Qualified || result.Event.ReferralId != id || f.status(t, id) != domain.ReferralStatusQualified {
+			t.Fatalf("result = %+v", result)
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		f := newConversionFixture(t)
+		addReferral(t, f.refs, f.member.ReferralCode, "cy@example.com")
+		result := f.ingest(t, Conversion{EventId: "e1", AttributionToken: "NOSUCHCODE"})
+		if result.Event.Qualified || result.Reason != "attribution token matches no referral code" {
+			t.Fatalf("result = %+v", result)
+		}
+	})
+
+	t.Run("email", func(t *testing.T) {
+		f := newConversionFixture(t)
+		id := addReferral(t, f.refs, f.member.ReferralCode, "cy@example.com")
+		result := f.ingest(t, Conversion{EventId: "e1", Email: "Cy@Example.com"})
+		if !result.Event.Qualified || result.Event.ReferralId != id {
+			t.Fatalf("result = %+v", result)
+		}
+	})
+
+	t.Run("phone", func(t *testing.T) {
+		f := newConversionFixture(t)
+		phone := "+1 (555) 010-0000"
+		id, err := f.refs.AddReferral(ctx, nil, nil, nil, &phone, f.member.ReferralCode, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := f.ingest(t, Conversion{EventId: "e1", Phone: "15550100000"})
+		if !result.Event.Qualified || result.Event.ReferralId != id {
+			t.Fatalf("result = %+v", result)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		f := newConversionFixture(t)
+		addReferral(t, f.refs, f.member.ReferralCode, "cy@example.com")
+		result := f.ingest(t, Conversion{EventId: "e1", Email: "di@example.com"})
+		if result.Event.Qualified || result.Event.ReferralId != "" || result.Reason != "no pending referral matches the event" {
+			t.Fatalf("result = %+v", result)
+		}
+	})
+}
+
+func TestConversionSeveralCandidates(t *testing.T) {
+	t.Run("token shared by several referrals", func(t *testing.T) {
+		f := newConversionFixture(t)
+		cy := addReferral(t, f.refs, f.member.ReferralCode, "cy@example.com")
+		di := addReferral(t, f.refs, f.member.ReferralCode, "di@example.com")
+
+		result := f.ingest(t, Conversion{EventId: "e1", AttributionToken: f.member.ReferralCode})
+		if result.Event.Qualified || result.Reason != "attribution token matches several pending referrals, email or phone is required" {
+			t.Fatalf("result = %+v", result)
+		}
+		// the referee's email picks their referral among the code's.
+		result = f.ingest(t, Conversion{EventId: "e2", AttributionToken: f.member.ReferralCode, Email: "di@example.com"})
+		if !result.Event.Qualified || result.Event.ReferralId != di {
+			t.Fatalf("result = %+v", result)
+		}
+		if f.status(t, cy) != domain.ReferralStatusPending {
+			t.Fatalf("cy's referral is %s, want pending", f.status(t, cy))
+		}
+	})
+
+	t.Run("token and email of another code", func(t *testing.T) {
+		f := newConversionFixture(t)
+		other := addCodedMember(t, f.db, f.member.ProgramId, "bo@example.com", "bo-4m8x")
+		addReferral(t, f.refs, other.ReferralCode, "cy@example.com")
+
+		result := f.ingest(t, Conversion{EventId: "e1", AttributionToken: f.member.ReferralCode, Email: "cy@example.com"})
+		if result.Event.Qualified || result.Reason != "no pending referral made with the attribution token matches the event" {
+			t.Fatalf("result = %+v", result)
+		}
+	})
+
+	t.Run("one qualified at a time", func(t *testing.T) {
+		f := newConversionFixture(t)
+		other := addCodedMember(t, f.db, f.member.ProgramId, "bo@example.com", "bo-4m8x")
+		first := addReferral(t, f.refs, f.member.ReferralCode, "cy@example.com")
+		second := addReferral(t, f.refs, other.ReferralCode, "cy@example.com")
+
+		one := f.ingest(t, Conversion{EventId: "e1", Email: "cy@example.com"})
+		two := f.ingest(t, Conversion{EventId: "e2", Email: "cy@example.com"})
+		if !one.Event.Qualified || !two.Event.Qualified || one.Event.ReferralId == two.Event.ReferralId {
+			t.Fatalf("results = %+v, %+v", one, two)
+		}
+		for _, id := range []string{first, second} {
+			if f.status(t, id) != domain.ReferralStatusQualified {
+				t.Fatalf("referral %s is %s, want qualified", id, f.status(t, id))
+			}
+		}
+		three := f.ingest(t, Conversion{EventId: "e3", Email: "cy@example.com"})
+		if three.Event.Qualified || three.Reason != "no pending referral matches the event" {
+			t.Fatalf("result = %+v", three)
+		}
+	})
+}
+
+func TestConversionReplay(t *testing.T) {
+	f := newConversionFixture(t)
+	addReferral(t, f.refs, f.member.ReferralCode, "cy@example.com")
+	addReferral(t, f.refs, f.member.ReferralCode, "cy@example.com")
+
+	first := f.ingest(t, Conversion{EventId: "order-1", Email: "cy@example.com", OrderAmount: 500})
+	if !first.Event.Qualified || first.Duplicate {
+		t.Fatalf("result = %+v", first)
+	}
+	// a retried delivery returns the stored event and qualifies nothing else.
+	replay := f.ingest(t, Conversion{EventId: "order-1", Email: "cy@example.com", OrderAmount: 500})
+	if !replay.Duplicate || replay.Event.ReferralId != first.Event.ReferralId {
+		t.Fatalf("replay = %+v, want a duplicate of %+v", replay, first)
+	}
+	pending := domain.ReferralStatusPending
+	left, err := f.db.GetReferrals(context.Background(), domain.ReferralFilter{Status: &pending}, domain.Page{Number: 1, Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) != 1 {
+		t.Fatalf("%d pending referrals after the replay, want 1", len(left))
+	}
+}
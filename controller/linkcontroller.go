@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"referral-service/domain"
+	"referral-service/referralcode"
+	"referral-service/repository"
+
+	"go.uber.org/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Contract for shareable member referral links
+type LinkController interface {
+	GenerateReferralLink(ctx context.Context, email string, program_id *string) (string, error)
+	FollowReferralLink(ctx context.Context, code string, visit LinkVisit) (string, error)
+	GetClickStats(ctx context.Context, memberId *string, programId *string) (domain.ClickStats, error)
+}
+
+// LinkVisit describes the visitor following a referral link.
+type LinkVisit struct {
+	UserAgent string
+	Referer   string
+	IP        string
+}
+
+type linkCon struct {
+	log    *zap.Logger
+	db     repository.Repository
+	codes  referralcode.Generator
+	config linkConfig
+}
+
+type linkConfig struct {
+	UrlTemplate       string `yaml:"url_template"`
+	DefaultLandingUrl string `yaml:"default_landing_url"`
+	IpHashSalt        string `yaml:"ip_hash_salt"`
+}
+
+type LinkParams struct {
+	fx.In
+
+	Log   *zap.Logger
+	Db    repository.Repository
+	Cfg   config.Provider
+	Codes referralcode.Generator
+}
+
+func LinkNew(p LinkParams) (LinkController, error) {
+	var cfg linkConfig
+	if err := p.Cfg.Get("referral_link").Populate(&cfg); err != nil {
+		return nil, fmt.Errorf("referral_link config populate %w", err)
+	}
+	if cfg.UrlTemplate == "" {
+		return nil, fmt.Errorf("referral_link.url_template is not configured")
+	}
+
+	newController := &linkCon{
+		log:    p.Log,
+		db:     p.Db,
+		codes:  p.Codes,
+		config: cfg,
+	}
+
+	return newController, nil
+}
+
+// GenerateReferralLink builds the shareable url for the member registered with the given email,
+// in program_id when the email is enrolled in several programs.
+func (c *linkCon) GenerateReferralLink(ctx context.Context, email string, program_id *string) (string, error) {
+	member, err := c.db.GetMemberByEmail(ctx, email, program_id)
+	if err != nil {
+		return "", err
+	}
+	if !member.IsActive {
+		return "", fmt.Errorf("member %s %w", member.ID, domain.ErrMemberInactive)
+	}
+
+	return expandLinkTemplate(c.config.UrlTemplate, member), nil
+}
+
+// FollowReferralLink records a click on a member's referral link and returns
+// the landing url of the member's program to redirect the visitor to.
+// Links of inactive members don't redirect and their clicks aren't recorded.
+func (c *linkCon) FollowReferralLink(ctx context.Context, code string, visit LinkVisit) (string, error) {
+	member, referralCode, err := resolveCode(ctx, c.db, c.codes, code)
+	if err != nil {
+		return "", err
+	}
+	if !member.IsActive {
+		return "", fmt.Errorf("referral code %s %w", referralCode.Code, domain.ErrMemberInactive)
+	}
+	program, err := c.db.GetProgram(ctx, member.ProgramId)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = c.db.AddClick(ctx, domain.Click{
+		ReferralCode: referralCode.Code,
+		MemberId:     member.ID,
+		ProgramId:    member.ProgramId,
+		UserAgent:    visit.UserAgent,
+		Referer:      visit.Referer,
+		IpHash:       hashIP(c.config.IpHashSalt, visit.IP),
+	})
+	if err != nil {
+		// a lost click shouldn't break the visitor's redirect.
+		c.log.Error("record referral click", zap.String("referral_code", code), zap.Error(err))
+	}
+
+	landingUrl := program.LandingUrl
+	if landingUrl == "" {
+		landingUrl = c.config.DefaultLandingUrl
+	}
+	return expandLinkTemplate(landingUrl, member), nil
+}
+
+func (c *linkCon) GetClickStats(ctx context.Context, memberId *string, programId *string) (domain.ClickStats, error) {
+	return c.db.GetClickStats(ctx, memberId, programId)
+}
+
+// hashIP salts and hashes a client ip so clicks and referrals can be grouped by it without storing it.
+func hashIP(salt string, ip string) string {
+	if ip == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(salt + ip))
+	return hex.EncodeToString(sum[:])
+}
+
+// expandLinkTemplate replaces the {referral_code} and {program_id} placeholders with the member's values.
+func expandLinkTemplate(template string, member domain.Member) string {
+	return strings.NewReplacer(
+		"{referral_code}", url.QueryEscape(member.ReferralCode),
+		"{program_id}", url.QueryEscape(member.ProgramId),
+	).Replace(template)
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"referral-service/domain"
+	"referral-service/repository"
+
+	"go.uber.org/zap"
+)
+
+func TestFollowReferralLink(t *testing.T) {
+	ctx := context.Background()
+	db := repository.NewMemory(zap.NewNop())
+	c := &linkCon{
+		log:    zap.NewNop(),
+		db:     db,
+		codes:  newCodes(t, false),
+		config: linkConfig{UrlTemplate: "https://example.com/join?code={referral_code}", DefaultLandingUrl: "https://example.com/{referral_code}"},
+	}
+	programId, err := db.AddProgram(ctx, "friends", "Friends", true, "", "", domain.RewardPolicy{}, domain.QualificationRule{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	member := addMember(t, db, programId, "ada@example.com")
+
+	landingUrl, err := c.FollowReferralLink(ctx, member.ReferralCode, LinkVisit{IP: "203.0.113.7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if landingUrl != "https://example.com/"+member.ReferralCode {
+		t.Fatalf("landing url = %s", landingUrl)
+	}
+
+	inactive := false
+	if err := db.UpdateMember(ctx, member.ID, nil, nil, nil, nil, &inactive); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.FollowReferralLink(ctx, member.ReferralCode, LinkVisit{IP: "203.0.113.8"}); !errors.Is(err, domain.ErrMemberInactive) {
+		t.Fatalf("following an inactive member's link = %v, want ErrMemberInactive", err)
+	}
+	stats, err := c.GetClickStats(ctx, &member.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Clicks != 1 {
+		t.Fatalf("clicks = %d, want only the active member's", stats.Clicks)
+	}
+}
@@ -2,8 +2,10 @@ package controller
 
 import (
 	"context"
+	"errors"
 
 	"referral-service/domain"
+	"referral-service/referralcode"
 	"referral-service/repository"
 
 	"go.uber.org/fx"
@@ -20,38 +22,104 @@ type MemberController interface {
 		referral_code *string,
 		is_active *bool,
 	) (string, error)
-	GetMembers(ctx context.Context, page int, size int) ([]domain.Member, error)
+	GetMembers(ctx context.Context, filter domain.MemberFilter, page domain.Page) ([]domain.Member, error)
+	UpdateMember(ctx context.Context,
+		id string,
+		first_name *string,
+		last_name *string,
+		email *string,
+		program_id *string,
+		is_active *bool,
+	) (*domain.Member, error)
+	DeactivateMember(ctx context.Context, id string) (*domain.Member, error)
+	GetMember(ctx context.Context, id string) (*domain.Member, error)
+	GetMemberByEmail(ctx context.Context, email string, program_id *string) (*domain.Member, error)
+	GetMemberByReferralCode(ctx context.Context, referralCode string) (*domain.Member, error)
+	ClaimVanityCode(ctx context.Context, id string, code string) (*domain.Member, error)
 }
 
+// maxCodeAttempts bounds how many generated referral codes are tried before giving up on collisions.
+const maxCodeAttempts = 5
+
 type memberCon struct {
-	log *zap.Logger
-	db  repository.Repository
+	log    *zap.Logger
+	db     repository.Repository
+	codes  referralcode.Generator
+	vanity referralcode.VanityRules
 }
 
 type MemberParams struct {
 	fx.In
 
-	Log *zap.Logger
-	Db  repository.Repository
+	Log    *zap.Logger
+	Db     repository.Repository
+	Codes  referralcode.Generator
+	Vanity referralcode.VanityRules
 }
 
 func MemberNew(p MemberParams) MemberController {
 	newController := &memberCon{
-		log: p.Log,
-		db:  p.Db,
+		log:    p.Log,
+		db:     p.Db,
+		codes:  p.Codes,
+		vanity: p.Vanity,
 	}
 
 	return newController
 }
 
-func (c *memberCon) GetMembers(ctx context.Context, page int, size int) ([]domain.Member, error) {
-	members, err := c.db.GetMembers(ctx, page, size)
+func (c *memberCon) GetMembers(ctx context.Context, filter domain.MemberFilter, page domain.Page) ([]domain.Member, error) {
+	members, err := c.db.GetMembers(ctx, filter, page)
 	if err != nil {
 		return nil, err
 	}
 	return members, nil
 }
 
+func (c *memberCon) GetMember(ctx context.Context, id string) (*domain.Member, error) {
+	member, err := c.db.GetMember(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetMemberByEmail finds the enrolment of email in program_id. program_id may be
+// left out when the email is enrolled in a single program.
+func (c *memberCon) GetMemberByEmail(ctx context.Context, email string, program_id *string) (*domain.Member, error) {
+	member, err := c.db.GetMemberByEmail(ctx, email, program_id)
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (c *memberCon) GetMemberByReferralCode(ctx context.Context, referralCode string) (*domain.Member, error) {
+	member, _, err := resolveCode(ctx, c.db, c.codes, referralCode)
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// ClaimVanityCode makes code, which the member picked, their current referral code.
+// Codes are case insensitive and must pass the vanity rules. The member's previous
+// codes keep resolving to them so that links already shared still work.
+func (c *memberCon) ClaimVanityCode(ctx context.Context, id string, code string) (*domain.Member, error) {
+	code = referralcode.Normalize(code)
+	if err := c.vanity.Check(code); err != nil {
+		return nil, err
+	}
+	if err := c.db.ChangeReferralCode(ctx, id, code); err != nil {
+		return nil, err
+	}
+	member, err := c.db.GetMember(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
 func (c *memberCon) AddMember(ctx context.Context,
 	first_name string,
 	last_name *string,
@@ -59,13 +127,75 @@ func (c *memberCon) AddMember(ctx context.Context,
 	program_id string,
 	referral_code *string,
 	is_active *bool) (string, error) {
-	memberId, err := c.db.AddMember(ctx,
+	if referral_code != nil {
+		// a code picked by the caller follows the same rules as a claimed one.
+		code := referralcode.Normalize(*referral_code)
+		if err := c.vanity.Check(code); err != nil {
+			return "", err
+		}
+		return c.db.AddMember(ctx, first_name, last_name, email, program_id, code, is_active)
+	}
+
+	program, err := c.db.GetProgram(ctx, program_id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", domain.NewError(domain.ErrInvalidArgument, "program does not exist")
+	}
+	if err != nil {
+		return "", err
+	}
+	var memberId string
+	err = generateCode(c.log, c.codes, program, func(code string) error {
+		memberId, err = c.db.AddMember(ctx, first_name, last_name, email, program_id, code, is_active)
+		return err
+	})
+	return memberId, err
+}
+
+// generateCode calls add with a code generated for program, and again with another
+// code while the generated one is already taken, up to maxCodeAttempts times.
+func generateCode(log *zap.Logger, codes referralcode.Generator, program domain.Program, add func(code string) error) error {
+	for attempt := 1; ; attempt++ {
+		code, err := codes.Generate(program.CodePrefix)
+		if err != nil {
+			return err
+		}
+		err = add(code)
+		if !errors.Is(err, domain.ErrReferralCodeTaken) || attempt == maxCodeAttempts {
+			return err
+		}
+		log.Info("generated referral code taken, regenerating",
+			zap.String("program_id", program.ID),
+			zap.Int("attempt", attempt))
+	}
+}
+
+// UpdateMember changes the fields that are set. program_id can't change, the member's
+// referrals and rewards stay with the program they enrolled in.
+func (c *memberCon) UpdateMember(ctx context.Context,
+	id string,
+	first_name *string,
+	last_name *string,
+	email *string,
+	program_id *string,
+	is_active *bool) (*domain.Member, error) {
+	err := c.db.UpdateMember(ctx,
+		id,
 		first_name,
 		last_name,
 		email,
 		program_id,
-		referral_code,
 		is_active,
 	)
-	return memberId, err
+	if err != nil {
+		return nil, err
+	}
+	member, getErr := c.db.GetMember(ctx, id)
+	return &member, getErr
+}
+
+// DeactivateMember stops the member's referral code from accepting new referrals.
+// The member and their referral history are kept.
+func (c *memberCon) DeactivateMember(ctx context.Context, id string) (*domain.Member, error) {
+	inactive := false
+	return c.UpdateMember(ctx, id, nil, nil, nil, nil, &inactive)
 }
@@ -8,5 +8,10 @@ var Module = fx.Module(
 		ProgramNew,
 		MemberNew,
 		ReferralNew,
+		RewardNew,
+		LinkNew,
+		CodeNew,
+		ReviewNew,
+		ConversionNew,
 	),
 )
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"fmt"
 
 	"referral-service/domain"
 	"referral-service/repository"
@@ -12,10 +13,10 @@ import (
 
 // Contract for referral programs
 type ProgramController interface {
-	AddProgram(ctx context.Context, name string, title string, active bool) (string, error)
-	UpdateProgram(ctx context.Context, id string, name *string, title *string, active *bool) (*domain.Program, error)
+	AddProgram(ctx context.Context, name string, title string, active bool, landingUrl string, codePrefix string, policy domain.RewardPolicy, rule domain.QualificationRule, attributionWindowDays int64) (string, error)
+	UpdateProgram(ctx context.Context, id string, name *string, title *string, active *bool, landingUrl *string, codePrefix *string, policy *domain.RewardPolicy, rule *domain.QualificationRule, attributionWindowDays *int64) (*domain.Program, error)
 	GetProgram(ctx context.Context, id string) (*domain.Program, error)
-	GetPrograms(ctx context.Context, page int, size int) ([]domain.Program, error)
+	GetPrograms(ctx context.Context, page domain.Page) ([]domain.Program, error)
 }
 
 type programCon struct {
@@ -47,24 +48,76 @@ func (c *programCon) GetProgram(ctx context.Context, id string) (*domain.Program
 	return &program, nil
 }
 
-func (c *programCon) GetPrograms(ctx context.Context, page int, size int) ([]domain.Program, error) {
-	programs, err := c.db.GetPrograms(ctx, page, size)
+func (c *programCon) GetPrograms(ctx context.Context, page domain.Page) ([]domain.Program, error) {
+	programs, err := c.db.GetPrograms(ctx, page)
 	if err != nil {
 		return nil, err
 	}
 	return programs, nil
 }
 
-func (c *programCon) AddProgram(ctx context.Context, name string, title string, active bool) (string, error) {
-	programId, err := c.db.AddProgram(ctx, name, title, active)
+func (c *programCon) AddProgram(ctx context.Context, name string, title string, active bool, landingUrl string, codePrefix string, policy domain.RewardPolicy, rule domain.QualificationRule, attributionWindowDays int64) (string, error) {
+	if err := validateRewardPolicy(policy); err != nil {
+		return "", err
+	}
+	if err := validateQualificationRule(rule); err != nil {
+		return "", err
+	}
+	if attributionWindowDays < 0 {
+		return "", domain.NewError(domain.ErrInvalidArgument, "attribution window can't be negative")
+	}
+	programId, err := c.db.AddProgram(ctx, name, title, active, landingUrl, codePrefix, policy, rule, attributionWindowDays)
 	return programId, err
 }
 
-func (c *programCon) UpdateProgram(ctx context.Context, id string, name *string, title *string, active *bool) (*domain.Program, error) {
-	err := c.db.UpdateProgram(ctx, id, name, title, active)
+func (c *programCon) UpdateProgram(ctx context.Context, id string, name *string, title *string, active *bool, landingUrl *string, codePrefix *string, policy *domain.RewardPolicy, rule *domain.QualificationRule, attributionWindowDays *int64) (*domain.Program, error) {
+	if policy != nil {
+		if err := validateRewardPolicy(*policy); err != nil {
+			return nil, err
+		}
+	}
+	if rule != nil {
+		if err := validateQualificationRule(*rule); err != nil {
+			return nil, err
+		}
+	}
+	if attributionWindowDays != nil && *attributionWindowDays < 0 {
+		return nil, domain.NewError(domain.ErrInvalidArgument, "attribution window can't be negative")
+	}
+	err := c.db.UpdateProgram(ctx, id, name, title, active, landingUrl, codePrefix, policy, rule, attributionWindowDays)
 	if err != nil {
 		return nil, err
 	}
 	program, getErr := c.db.GetProgram(ctx, id)
 	return &program, getErr
 }
+
+// validateRewardPolicy checks a policy is either unset or complete.
+func validateRewardPolicy(policy domain.RewardPolicy) error {
+	switch policy.RewardType {
+	case "":
+		return nil
+	case domain.RewardTypeFixed, domain.RewardTypePercentage:
+	default:
+		return fmt.Errorf("reward type %q %w", policy.RewardType, domain.ErrInvalidRewardPolicy)
+	}
+	if policy.RewardCurrency == "" {
+		return fmt.Errorf("reward currency is required %w", domain.ErrInvalidRewardPolicy)
+	}
+	if policy.ReferrerReward < 0 || policy.RefereeReward < 0 || policy.MaxRewardsPerMember < 0 || policy.RewardCap < 0 {
+		return fmt.Errorf("reward amounts and limits can't be negative %w", domain.ErrInvalidRewardPolicy)
+	}
+	if policy.RewardType == domain.RewardTypePercentage &&
+		(policy.ReferrerReward > domain.MaxPercentageReward || policy.RefereeReward > domain.MaxPercentageReward) {
+		return fmt.Errorf("percentage rewards can't exceed %d basis points %w", domain.MaxPercentageReward, domain.ErrInvalidRewardPolicy)
+	}
+	return nil
+}
+
+// validateQualificationRule checks a rule's limits, a rule without an event is off.
+func validateQualificationRule(rule domain.QualificationRule) error {
+	if rule.QualifyMinOrder < 0 || rule.QualifyWithinDays < 0 {
+		return domain.NewError(domain.ErrInvalidArgument, "qualification limits can't be negative")
+	}
+	return nil
+}
@@ -2,10 +2,16 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"referral-service/domain"
+	"referral-service/fraud"
+	"referral-service/referralcode"
 	"referral-service/repository"
 
+	"go.uber.org/config"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -17,33 +23,75 @@ type ReferralController interface {
 		last_name *string,
 		email *string,
 		phone *string,
-		referral_code string) (string, error)
-	GetReferrals(ctx context.Context, page int, size int) ([]domain.Referral, error)
+		referral_code string,
+		source_ip string) (string, error)
+	GetReferrals(ctx context.Context, filter domain.ReferralFilter, page domain.Page) ([]domain.Referral, error)
+	QualifyReferral(ctx context.Context, id string, changedBy string, reason string) (*domain.Referral, error)
+	ApproveReferral(ctx context.Context, id string, changedBy string, reason string, orderAmount *int64) (*domain.Referral, error)
+	DenyReferral(ctx context.Context, id string, changedBy string, reason string) (*domain.Referral, error)
+	ReviewReferrals(ctx context.Context, ids []string, to string, changedBy string, reason string) ([]domain.Referral, error)
 }
 
 type referralCon struct {
-	log *zap.Logger
-	db  repository.Repository
+	log     *zap.Logger
+	db      repository.Repository
+	fraud   fraud.Screener
+	codes   referralcode.Generator
+	rewards rewardConfig
+	review  reviewConfig
+	ipSalt  string
+}
+
+// rewardConfig is the reward credited to the referring member on approval
+// for programs without their own reward policy.
+type rewardConfig struct {
+	Amount   int64  `yaml:"amount"`
+	Currency string `yaml:"currency"`
 }
 
 type ReferralParams struct {
 	fx.In
 
-	Log *zap.Logger
-	Db  repository.Repository
+	Log   *zap.Logger
+	Db    repository.Repository
+	Cfg   config.Provider
+	Fraud fraud.Screener
+	Codes referralcode.Generator
 }
 
-func ReferralNew(p ReferralParams) ReferralController {
+func ReferralNew(p ReferralParams) (ReferralController, error) {
+	var rewards rewardConfig
+	if err := p.Cfg.Get("rewards").Populate(&rewards); err != nil {
+		return nil, fmt.Errorf("rewards config populate %w", err)
+	}
+	review, err := newReviewConfig(p.Cfg)
+	if err != nil {
+		return nil, err
+	}
+	var ipSalt string
+	if err := p.Cfg.Get("referral_link.ip_hash_salt").Populate(&ipSalt); err != nil {
+		return nil, fmt.Errorf("referral_link config populate %w", err)
+	}
+
 	newController := &referralCon{
-		log: p.Log,
-		db:  p.Db,
+		log:     p.Log,
+		db:      p.Db,
+		fraud:   p.Fraud,
+		codes:   p.Codes,
+		rewards: rewards,
+		review:  review,
+		ipSalt:  ipSalt,
 	}
 
-	return newController
+	return newController, nil
 }
 
-func (c *referralCon) GetReferrals(ctx context.Context, page int, size int) ([]domain.Referral, error) {
-	referrals, err := c.db.GetReferrals(ctx, page, size)
+func (c *referralCon) GetReferrals(ctx context.Context, filter domain.ReferralFilter, page domain.Page) ([]domain.Referral, error) {
+	if err := checkReferralFilter(filter); err != nil {
+		return nil, err
+	}
+
+	referrals, err := c.db.GetReferrals(ctx, filter, page)
 	if err != nil {
 		return nil, err
 	}
@@ -55,13 +103,264 @@ func (c *referralCon) AddReferral(ctx context.Context,
 	last_name *string,
 	email *string,
 	phone *string,
-	referral_code string) (string, error) {
-	referralId, err := c.db.AddReferral(ctx,
-		first_name,
-		last_name,
-		email,
-		phone,
-		referral_code,
-	)
+	referral_code string,
+	source_ip string) (string, error) {
+	member, code, err := resolveCode(ctx, c.db, c.codes, referral_code)
+	if err != nil {
+		return "", err
+	}
+	if !member.IsActive {
+		return "", fmt.Errorf("referral code %s %w", referral_code, domain.ErrMemberInactive)
+	}
+
+	referral := domain.Referral{
+		FirstName:    valueOrEmpty(first_name),
+		LastName:     valueOrEmpty(last_name),
+		Email:        valueOrEmpty(email),
+		Phone:        valueOrEmpty(phone),
+		ReferralCode: code.Code,
+		IpHash:       hashIP(c.ipSalt, source_ip),
+	}
+	referral.ContactEmail = domain.NormalizeEmail(referral.Email)
+	referral.ContactPhone = domain.NormalizePhone(referral.Phone)
+
+	verdict, err := c.fraud.Screen(ctx, referral, member)
+	if err != nil {
+		return "", err
+	}
+	if verdict.Reject {
+		c.log.Info("referral rejected by fraud checks",
+			zap.String("referral_code", code.Code),
+			zap.Strings("reasons", verdict.Reasons))
+		return "", domain.NewError(domain.ErrConflict, "referral rejected, %s", verdict.Reason())
+	}
+	referral.Flagged = verdict.Flagged()
+	referral.FlagReason = verdict.Reason()
+	referral.RiskScore = verdict.RiskScore
+
+	referralId, err := c.db.AddReferral(ctx, referral, verdict.Limits...)
+	if errors.Is(err, domain.ErrConflict) {
+		c.log.Info("referral rejected by velocity limits",
+			zap.String("referral_code", code.Code),
+			zap.Error(err))
+	}
 	return referralId, err
 }
+
+// checkReferralFilter rejects sort orders and statuses referrals can't be listed by.
+func checkReferralFilter(filter domain.ReferralFilter) error {
+	switch filter.SortBy {
+	case "", domain.ReferralSortCreatedAt, domain.ReferralSortUpdatedAt, domain.ReferralSortRiskScore:
+	default:
+		return fmt.Errorf("sort by %q %w", filter.SortBy, domain.ErrInvalidFilter)
+	}
+	if filter.Status != nil {
+		switch *filter.Status {
+		case domain.ReferralStatusPending, domain.ReferralStatusQualified, domain.ReferralStatusApproved, domain.ReferralStatusDenied, domain.ReferralStatusExpired:
+		default:
+			return fmt.Errorf("status %q %w", *filter.Status, domain.ErrInvalidFilter)
+		}
+	}
+	return nil
+}
+
+// valueOrEmpty dereferences an optional string field.
+func valueOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func (c *referralCon) QualifyReferral(ctx context.Context, id string, changedBy string, reason string) (*domain.Referral, error) {
+	return c.transition(ctx, id, domain.ReferralStatusQualified, changedBy, reason, 0)
+}
+
+// ApproveReferral approves a referral and credits the rewards its program pays.
+// orderAmount is the base for percentage reward policies, defaulting to the order
+// recorded when the referral qualified.
+func (c *referralCon) ApproveReferral(ctx context.Context, id string, changedBy string, reason string, orderAmount *int64) (*domain.Referral, error) {
+	var amount int64
+	if orderAmount != nil {
+		amount = *orderAmount
+	}
+	return c.transition(ctx, id, domain.ReferralStatusApproved, changedBy, reason, amount)
+}
+
+func (c *referralCon) DenyReferral(ctx context.Context, id string, changedBy string, reason string) (*domain.Referral, error) {
+	return c.transition(ctx, id, domain.ReferralStatusDenied, changedBy, reason, 0)
+}
+
+// ReviewReferrals approves or denies several referrals together, either every one of them changes or none does.
+// Approving a pending referral qualifies it first, both changes are recorded for the reviewer.
+func (c *referralCon) ReviewReferrals(ctx context.Context, ids []string, to string, changedBy string, reason string) ([]domain.Referral, error) {
+	if to != domain.ReferralStatusApproved && to != domain.ReferralStatusDenied {
+		return nil, domain.NewError(domain.ErrInvalidArgument, "referrals can only be reviewed to %s or %s", domain.ReferralStatusApproved, domain.ReferralStatusDenied)
+	}
+
+	var changes []domain.ReferralStatusChange
+	var rewards []domain.Reward
+	var limits []domain.RewardLimit
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			return nil, domain.NewError(domain.ErrInvalidArgument, "referral %s is listed twice", id)
+		}
+		seen[id] = true
+
+		referral, err := c.db.GetReferral(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if to == domain.ReferralStatusApproved && referral.Status == domain.ReferralStatusPending {
+			qualify, _, _, err := c.statusChange(ctx, referral, domain.ReferralStatusQualified, changedBy, reason, 0)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, qualify)
+			referral.Status = domain.ReferralStatusQualified
+		}
+		change, earned, limit, err := c.statusChange(ctx, referral, to, changedBy, reason, 0)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+		rewards = append(rewards, earned...)
+		limits = append(limits, limit...)
+	}
+
+	if err := c.db.UpdateReferralStatuses(ctx, changes, rewards, limits...); err != nil {
+		return nil, err
+	}
+	referrals := make([]domain.Referral, 0, len(ids))
+	for _, change := range changes {
+		c.logStatusChange(change)
+		if change.ToStatus != to {
+			continue
+		}
+		updated, err := c.db.GetReferral(ctx, change.ReferralId)
+		if err != nil {
+			return nil, err
+		}
+		referrals = append(referrals, updated)
+	}
+	return referrals, nil
+}
+
+// transition moves a referral to the given status if the lifecycle allows it.
+func (c *referralCon) transition(ctx context.Context, id string, to string, changedBy string, reason string, orderAmount int64) (*domain.Referral, error) {
+	referral, err := c.db.GetReferral(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	change, rewards, limits, err := c.statusChange(ctx, referral, to, changedBy, reason, orderAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.db.UpdateReferralStatus(ctx, change, rewards, limits...)
+	if err != nil {
+		return nil, err
+	}
+	c.logStatusChange(change)
+
+	updated, getErr := c.db.GetReferral(ctx, id)
+	return &updated, getErr
+}
+
+// statusChange checks the lifecycle and the review claim allow moving referral to the
+// given status and returns the change with the rewards it earns and the limits the
+// repository holds them to.
+func (c *referralCon) statusChange(ctx context.Context,
+	referral domain.Referral,
+	to string,
+	changedBy string,
+	reason string,
+	orderAmount int64) (domain.ReferralStatusChange, []domain.Reward, []domain.RewardLimit, error) {
+	now := time.Now()
+	// the repository checks the claim again as it writes the change.
+	change := domain.ReferralStatusChange{
+		ReferralId:        referral.ID,
+		FromStatus:        referral.Status,
+		ToStatus:          to,
+		ChangedBy:         changedBy,
+		Reason:            reason,
+		StaleClaimsBefore: c.review.staleBefore(now),
+	}
+	if !domain.CanTransitionReferral(referral.Status, to) {
+		return change, nil, nil, fmt.Errorf("referral %s %s -> %s %w", referral.ID, referral.Status, to, domain.ErrInvalidStatusTransition)
+	}
+	if c.review.claimedByOther(referral, changedBy, now) {
+		return change, nil, nil, domain.ErrReferralClaimed
+	}
+
+	if to != domain.ReferralStatusApproved {
+		return change, nil, nil, nil
+	}
+	// referrals qualified by a conversion event carry its order amount.
+	if orderAmount == 0 {
+		orderAmount = referral.OrderAmount
+	}
+	rewards, limit, err := c.approvalRewards(ctx, referral, changedBy, orderAmount)
+	return change, rewards, []domain.RewardLimit{limit}, err
+}
+
+func (c *referralCon) logStatusChange(change domain.ReferralStatusChange) {
+	c.log.Info("referral status changed",
+		zap.String("referral_id", change.ReferralId),
+		zap.String("from", change.FromStatus),
+		zap.String("to", change.ToStatus),
+		zap.String("changed_by", change.ChangedBy),
+	)
+}
+
+// approvalRewards returns the ledger entries earned by approving a referral under its program's
+// reward policy, with the policy's limits. The repository leaves out the rewards that would
+// exceed the member's or the program's limits as it adds them. Programs without a policy
+// credit the configured default to the referrer.
+func (c *referralCon) approvalRewards(ctx context.Context, referral domain.Referral, changedBy string, orderAmount int64) ([]domain.Reward, domain.RewardLimit, error) {
+	program, err := c.db.GetProgram(ctx, referral.ProgramId)
+	if err != nil {
+		return nil, domain.RewardLimit{}, err
+	}
+
+	policy := program.RewardPolicy
+	if !policy.IsSet() {
+		policy = domain.RewardPolicy{
+			RewardType:     domain.RewardTypeFixed,
+			RewardCurrency: c.rewards.Currency,
+			ReferrerReward: c.rewards.Amount,
+		}
+	}
+
+	referrerAmount := policy.RewardAmount(policy.ReferrerReward, orderAmount)
+	refereeAmount := policy.RewardAmount(policy.RefereeReward, orderAmount)
+
+	var rewards []domain.Reward
+	if referrerAmount > 0 {
+		rewards = append(rewards, domain.Reward{
+			Beneficiary: domain.RewardBeneficiaryReferrer,
+			MemberId:    referral.MemberId,
+			ProgramId:   referral.ProgramId,
+			ReferralId:  referral.ID,
+			EntryType:   domain.RewardEntryCredit,
+			Amount:      referrerAmount,
+			Currency:    policy.RewardCurrency,
+			Reason:      "referral approved",
+			CreatedBy:   changedBy,
+		})
+	}
+	if refereeAmount > 0 {
+		rewards = append(rewards, domain.Reward{
+			Beneficiary: domain.RewardBeneficiaryReferee,
+			ProgramId:   referral.ProgramId,
+			ReferralId:  referral.ID,
+			EntryType:   domain.RewardEntryCredit,
+			Amount:      refereeAmount,
+			Currency:    policy.RewardCurrency,
+			Reason:      "referral approved",
+			CreatedBy:   changedBy,
+		})
+	}
+	return rewards, policy.Limit(referral.ProgramId), nil
+}
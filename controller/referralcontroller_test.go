@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"referral-service/domain"
+	"referral-service/fraud"
+	"referral-service/referralcode"
+	"referral-service/repository"
+
+	"go.uber.org/zap"
+)
+
+// noFraud turns every fraud check off so tests control the referrals they add.
+var noFraud = fraud.Config{
+	SelfReferral:     fraud.ActionOff,
+	DuplicateContact: fraud.ActionOff,
+	ExistingMember:   fraud.ActionOff,
+	Velocity:         fraud.VelocityConfig{Action: fraud.ActionOff},
+}
+
+// newCodes returns the default code generator, adding check characters when checksum is set.
+func newCodes(t *testing.T, checksum bool) referralcode.Generator {
+	t.Helper()
+	opts := referralcode.DefaultOptions
+	opts.Checksum = checksum
+	codes, err := referralcode.NewGenerator(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return codes
+}
+
+func newReferralCon(t *testing.T, db repository.Repository) *referralCon {
+	t.Helper()
+	screener, err := fraud.NewScreener(db, noFraud)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &referralCon{
+		log:     zap.NewNop(),
+		db:      db,
+		fraud:   screener,
+		codes:   newCodes(t, false),
+		rewards: rewardConfig{Amount: 1000, Currency: "USD"},
+		review:  reviewConfig{ClaimMinutes: 30},
+	}
+}
+
+// addMember enrols a member in programId and returns it.
+func addMember(t *testing.T, db repository.Repository, programId string, email string) domain.Member {
+	t.Helper()
+	ctx := context.Background()
+	id, err := db.AddMember(ctx, "Member", nil, email, programId, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	member, err := db.GetMember(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return member
+}
+
+func addReferral(t *testing.T, c *referralCon, code string, email string) string {
+	t.Helper()
+	id, err := c.AddReferral(context.Background(), nil, nil, &email, nil, code, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestReferralLifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := repository.NewMemory(zap.NewNop())
+	c := newReferralCon(t, db)
+	programId, err := db.AddProgram(ctx, "friends", "Friends", true, "", "", domain.RewardPolicy{}, domain.QualificationRule{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	member := addMember(t, db, programId, "ada@example.com")
+
+	id := addReferral(t, c, member.ReferralCode, "cy@example.com")
+	if _, err := c.ApproveReferral(ctx, id, "ann", "", nil); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("approving a pending referral = %v, want a conflict", err)
+	}
+	if _, err := c.QualifyReferral(ctx, id, "ann", "signed up"); err != nil {
+		t.Fatal(err)
+	}
+	approved, err := c.ApproveReferral(ctx, id, "ann", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if approved.Status != domain.ReferralStatusApproved {
+		t.Fatalf("status = %s, want approved", approved.Status)
+	}
+	if _, err := c.DenyReferral(ctx, id, "ann", ""); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("denying an approved referral = %v, want a conflict", err)
+	}
+
+	// the review queue approves pending referrals by qualifying them first.
+	pending := addReferral(t, c, member.ReferralCode, "di@example.com")
+	reviewed, err := c.ReviewReferrals(ctx, []string{pending}, domain.ReferralStatusApproved, "bob", "reviewed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reviewed) != 1 || reviewed[0].Status != domain.ReferralStatusApproved || reviewed[0].StatusChangedBy != "bob" {
+		t.Fatalf("reviewed = %+v", reviewed)
+	}
+}
+
+func TestConcurrentReferralVelocity(t *testing.T) {
+	ctx := context.Background()
+	db := repository.NewMemory(zap.NewNop())
+	c := newReferralCon(t, db)
+	cfg := noFraud
+	cfg.Velocity = fraud.VelocityConfig{Action: fraud.ActionReject, PerCode: fraud.Limit{Max: 5, WindowMinutes: 60}}
+	screener, err := fraud.NewScreener(db, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.fraud = screener
+	programId, err := db.AddProgram(ctx, "friends", "Friends", true, "", "", domain.RewardPolicy{}, domain.QualificationRule{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	member := addMember(t, db, programId, "ada@example.com")
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			email := fmt.Sprintf("r%d@example.com", i)
+			_, err := c.AddReferral(ctx, nil, nil, &email, nil, member.ReferralCode, "")
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	added := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			added++
+		case !errors.Is(err, domain.ErrConflict):
+			t.Fatal(err)
+		}
+	}
+	stored, err := db.CountReferrals(ctx, domain.ReferralFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added != 5 || stored != 5 {
+		t.Fatalf("%d referrals added and %d stored, want the code's limit of 5", added, stored)
+	}
+}
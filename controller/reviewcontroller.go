@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"referral-service/domain"
+	"referral-service/repository"
+
+	"go.uber.org/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Contract for reviewers working through the queue of pending referrals
+type ReviewController interface {
+	GetReviewQueue(ctx context.Context, filter domain.ReferralFilter, page domain.Page) ([]domain.Referral, error)
+	ClaimReferral(ctx context.Context, id string, reviewer string) (*domain.Referral, error)
+	ReleaseReferral(ctx context.Context, id string, reviewer string) (*domain.Referral, error)
+	FlagReferral(ctx context.Context, id string, flagged bool, reason string) (*domain.Referral, error)
+	AddReferralNote(ctx context.Context, id string, author string, note string) (string, error)
+	GetReferralNotes(ctx context.Context, id string) ([]domain.ReferralNote, error)
+}
+
+type reviewCon struct {
+	log    *zap.Logger
+	db     repository.Repository
+	config reviewConfig
+}
+
+// reviewConfig configures the review queue.
+type reviewConfig struct {
+	// ClaimMinutes is how long a claim holds before another reviewer can take the referral over.
+	ClaimMinutes int64 `yaml:"claim_minutes"`
+}
+
+func newReviewConfig(cfg config.Provider) (reviewConfig, error) {
+	review := reviewConfig{ClaimMinutes: 30}
+	if err := cfg.Get("review_queue").Populate(&review); err != nil {
+		return review, fmt.Errorf("review_queue config populate %w", err)
+	}
+	if review.ClaimMinutes <= 0 {
+		return review, fmt.Errorf("review_queue claim_minutes must be positive")
+	}
+	return review, nil
+}
+
+// staleBefore returns the time claims made before are abandoned at, in unix seconds.
+func (c reviewConfig) staleBefore(now time.Time) int64 {
+	return now.Add(-time.Duration(c.ClaimMinutes) * time.Minute).Unix()
+}
+
+// claimedByOther reports whether a reviewer other than reviewer holds referral's claim.
+func (c reviewConfig) claimedByOther(referral domain.Referral, reviewer string, now time.Time) bool {
+	return referral.ClaimedBy != "" && referral.ClaimedBy != reviewer && referral.ClaimedAt >= c.staleBefore(now)
+}
+
+type ReviewParams struct {
+	fx.In
+
+	Log *zap.Logger
+	Db  repository.Repository
+	Cfg config.Provider
+}
+
+func ReviewNew(p ReviewParams) (ReviewController, error) {
+	review, err := newReviewConfig(p.Cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	newController := &reviewCon{
+		log:    p.Log,
+		db:     p.Db,
+		config: review,
+	}
+
+	return newController, nil
+}
+
+// GetReviewQueue lists pending referrals, riskiest first unless sorted otherwise.
+func (c *reviewCon) GetReviewQueue(ctx context.Context, filter domain.ReferralFilter, page domain.Page) ([]domain.Referral, error) {
+	pending := domain.ReferralStatusPending
+	filter.Status = &pending
+	if filter.SortBy == "" {
+		filter.SortBy = domain.ReferralSortRiskScore
+		filter.Descending = true
+	}
+	if err := checkReferralFilter(filter); err != nil {
+		return nil, err
+	}
+
+	return c.db.GetReferrals(ctx, filter, page)
+}
+
+// ClaimReferral assigns a pending referral to reviewer so no one else reviews it meanwhile.
+// Claiming a referral again renews the claim.
+func (c *reviewCon) ClaimReferral(ctx context.Context, id string, reviewer string) (*domain.Referral, error) {
+	if err := c.db.ClaimReferral(ctx, id, reviewer, c.config.staleBefore(time.Now())); err != nil {
+		return nil, err
+	}
+	c.log.Info("referral claimed", zap.String("referral_id", id), zap.String("reviewer", reviewer))
+
+	referral, err := c.db.GetReferral(ctx, id)
+	return &referral, err
+}
+
+func (c *reviewCon) ReleaseReferral(ctx context.Context, id string, reviewer string) (*domain.Referral, error) {
+	if err := c.db.ReleaseReferral(ctx, id, reviewer); err != nil {
+		return nil, err
+	}
+
+	referral, err := c.db.GetReferral(ctx, id)
+	return &referral, err
+}
+
+// FlagReferral sets or clears a referral's flag, replacing the reason the fraud checks gave.
+func (c *reviewCon) FlagReferral(ctx context.Context, id string, flagged bool, reason string) (*domain.Referral, error) {
+	if err := c.db.SetReferralFlag(ctx, id, flagged, reason); err != nil {
+		return nil, err
+	}
+
+	referral, err := c.db.GetReferral(ctx, id)
+	return &referral, err
+}
+
+func (c *reviewCon) AddReferralNote(ctx context.Context, id string, author string, note string) (string, error) {
+	if _, err := c.db.GetReferral(ctx, id); err != nil {
+		return "", err
+	}
+
+	return c.db.AddReferralNote(ctx, domain.ReferralNote{
+		ReferralId: id,
+		Author:     author,
+		Note:       note,
+	})
+}
+
+func (c *reviewCon) GetReferralNotes(ctx context.Context, id string) ([]domain.ReferralNote, error) {
+	if _, err := c.db.GetReferral(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return c.db.GetReferralNotes(ctx, id)
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"referral-service/domain"
+	"referral-service/repository"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Contract for the member reward ledger
+type RewardController interface {
+	GetRewards(ctx context.Context, memberId string, page domain.Page) ([]domain.Reward, error)
+	GetRewardBalances(ctx context.Context, memberId string) ([]domain.RewardBalance, error)
+	ReverseReward(ctx context.Context, id string, reversedBy string, reason string) (*domain.Reward, error)
+}
+
+type rewardCon struct {
+	log *zap.Logger
+	db  repository.Repository
+}
+
+type RewardParams struct {
+	fx.In
+
+	Log *zap.Logger
+	Db  repository.Repository
+}
+
+func RewardNew(p RewardParams) RewardController {
+	newController := &rewardCon{
+		log: p.Log,
+		db:  p.Db,
+	}
+
+	return newController
+}
+
+func (c *rewardCon) GetRewards(ctx context.Context, memberId string, page domain.Page) ([]domain.Reward, error) {
+	rewards, err := c.db.GetRewards(ctx, memberId, page)
+	if err != nil {
+		return nil, err
+	}
+	return rewards, nil
+}
+
+func (c *rewardCon) GetRewardBalances(ctx context.Context, memberId string) ([]domain.RewardBalance, error) {
+	balances, err := c.db.GetRewardBalances(ctx, memberId)
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// ReverseReward cancels a credit by appending a matching debit to the ledger.
+func (c *rewardCon) ReverseReward(ctx context.Context, id string, reversedBy string, reason string) (*domain.Reward, error) {
+	credit, err := c.db.GetReward(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if credit.EntryType != domain.RewardEntryCredit {
+		return nil, fmt.Errorf("reward %s is a %s %w", id, credit.EntryType, domain.ErrRewardNotReversible)
+	}
+	// reversing a beneficiary's credit reverses its balancing entry with it.
+	if credit.Account == domain.RewardAccountProgram {
+		return nil, fmt.Errorf("reward %s balances reward %s %w", id, credit.BalanceOf, domain.ErrRewardNotReversible)
+	}
+
+	debitId, err := c.db.AddReward(ctx, domain.Reward{
+		Beneficiary: credit.Beneficiary,
+		MemberId:    credit.MemberId,
+		ProgramId:   credit.ProgramId,
+		ReferralId:  credit.ReferralId,
+		EntryType:   domain.RewardEntryDebit,
+		Amount:      credit.Amount,
+		Currency:    credit.Currency,
+		ReversalOf:  credit.ID,
+		Reason:      reason,
+		CreatedBy:   reversedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.log.Info("reward reversed",
+		zap.String("reward_id", credit.ID),
+		zap.String("reversal_id", debitId),
+		zap.String("reversed_by", reversedBy),
+	)
+
+	debit, getErr := c.db.GetReward(ctx, debitId)
+	return &debit, getErr
+}
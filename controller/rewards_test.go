@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"referral-service/domain"
+	"referral-service/repository"
+
+	"go.uber.org/zap"
+)
+
+// earned is what one approval credited the referrer and the referee.
+type earned struct {
+	referrer int64
+	referee  int64
+}
+
+func TestApprovalRewards(t *testing.T) {
+	fixed := func(referrer int64, referee int64) domain.RewardPolicy {
+		return domain.RewardPolicy{RewardType: domain.RewardTypeFixed, RewardCurrency: "USD", ReferrerReward: referrer, RefereeReward: referee}
+	}
+	percentage := func(referrer int64, referee int64) domain.RewardPolicy {
+		return domain.RewardPolicy{RewardType: domain.RewardTypePercentage, RewardCurrency: "USD", ReferrerReward: referrer, RefereeReward: referee}
+	}
+	withLimits := func(policy domain.RewardPolicy, maxPerMember int64, rewardCap int64) domain.RewardPolicy {
+		policy.MaxRewardsPerMember = maxPerMember
+		policy.RewardCap = rewardCap
+		return policy
+	}
+
+	tests := []struct {
+		name        string
+		policy      domain.RewardPolicy
+		orderAmount int64
+		want        []earned
+	}{
+		{"default reward without a policy", domain.RewardPolicy{}, 0, []earned{{1000, 0}, {1000, 0}}},
+		{"fixed both sides", fixed(1000, 500), 0, []earned{{1000, 500}}},
+		{"fixed ignores the order", fixed(1000, 0), 99999, []earned{{1000, 0}}},
+		{"percentage of the order", percentage(1000, 500), 20000, []earned{{2000, 1000}}},
+		{"percentage rounds down", percentage(250, 1000), 999, []earned{{24, 99}}},
+		{"percentage below a minor unit", percentage(1, 0), 9999, []earned{{0, 0}}},
+		{"percentage without an order", percentage(1000, 500), 0, []earned{{0, 0}}},
+		{"percentage of the largest order", percentage(5000, 0), math.MaxInt64, []earned{{math.MaxInt64 / 2, 0}}},
+		{"per member limit", withLimits(fixed(1000, 500), 2, 0), 0, []earned{{1000, 500}, {1000, 500}, {0, 500}}},
+		{"cap skips the referee once the referrer uses it up", withLimits(fixed(1000, 500), 0, 2500), 0, []earned{{1000, 500}, {1000, 0}, {0, 0}}},
+		{"cap still pays a referee that fits", withLimits(fixed(1000, 500), 0, 2000), 0, []earned{{1000, 500}, {0, 500}, {0, 0}}},
+		{"cap and limit together", withLimits(percentage(1000, 1000), 1, 3000), 10000, []earned{{1000, 1000}, {0, 1000}, {0, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := repository.NewMemory(zap.NewNop())
+			c := newReferralCon(t, db)
+			programId, err := db.AddProgram(ctx, "friends", "Friends", true, "", "", tt.policy, domain.QualificationRule{}, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			member := addMember(t, db, programId, "ada@example.com")
+
+			var got []earned
+			var referrerTotal, programTotal int64
+			for i := range tt.want {
+				id := addReferral(t, c, member.ReferralCode, string(rune('a'+i))+"@example.com")
+				if _, err := c.QualifyReferral(ctx, id, "ann", ""); err != nil {
+					t.Fatal(err)
+				}
+				orderAmount := tt.orderAmount
+				if _, err := c.ApproveReferral(ctx, id, "ann", "", &orderAmount); err != nil {
+					t.Fatal(err)
+				}
+
+				referrer := referrerRewards(t, db, member.ID)
+				program, err := db.SumProgramRewards(ctx, programId, "USD")
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, earned{referrer - referrerTotal, program - programTotal - (referrer - referrerTotal)})
+				referrerTotal, programTotal = referrer, program
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("approvals earned %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRewardCapPerCurrency(t *testing.T) {
+	ctx := context.Background()
+	db := repository.NewMemory(zap.NewNop())
+	c := newReferralCon(t, db)
+	policy := domain.RewardPolicy{RewardType: domain.RewardTypeFixed, RewardCurrency: "USD", ReferrerReward: 1000, RewardCap: 1500}
+	programId, err := db.AddProgram(ctx, "friends", "Friends", true, "", "", policy, domain.QualificationRule{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	member := addMember(t, db, programId, "ada@example.com")
+	approve := func(email string) {
+		t.Helper()
+		id := addReferral(t, c, member.ReferralCode, email)
+		if _, err := c.ReviewReferrals(ctx, []string{id}, domain.ReferralStatusApproved, "ann", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	approve("cy@example.com")
+
+	// rewards paid before the program switched currency don't use up the new cap.
+	policy.RewardCurrency = "EUR"
+	if err := db.UpdateProgram(ctx, programId, nil, nil, nil, nil, nil, &policy, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	approve("di@example.com")
+	eur, err := db.SumProgramRewards(ctx, programId, "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eur != 1000 {
+		t.Fatalf("EUR rewards = %d, want 1000", eur)
+	}
+}
+
+func TestValidateRewardPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy domain.RewardPolicy
+		ok     bool
+	}{
+		{"unset", domain.RewardPolicy{}, true},
+		{"fixed", domain.RewardPolicy{RewardType: domain.RewardTypeFixed, RewardCurrency: "USD", ReferrerReward: 1000000}, true},
+		{"whole order", domain.RewardPolicy{RewardType: domain.RewardTypePercentage, RewardCurrency: "USD", ReferrerReward: 10000, RefereeReward: 10000}, true},
+		{"unknown type", domain.RewardPolicy{RewardType: "points", RewardCurrency: "USD"}, false},
+		{"no currency", domain.RewardPolicy{RewardType: domain.RewardTypeFixed}, false},
+		{"referrer above the order", domain.RewardPolicy{RewardType: domain.RewardTypePercentage, RewardCurrency: "USD", ReferrerReward: 10001}, false},
+		{"referee above the order", domain.RewardPolicy{RewardType: domain.RewardTypePercentage, RewardCurrency: "USD", RefereeReward: math.MaxInt64}, false},
+		{"negative percentage", domain.RewardPolicy{RewardType: domain.RewardTypePercentage, RewardCurrency: "USD", ReferrerReward: -1}, false},
+		{"negative cap", domain.RewardPolicy{RewardType: domain.RewardTypeFixed, RewardCurrency: "USD", RewardCap: -1}, false},
+		{"negative member limit", domain.RewardPolicy{RewardType: domain.RewardTypeFixed, RewardCurrency: "USD", MaxRewardsPerMember: -1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRewardPolicy(tt.policy)
+			if tt.ok && err != nil || !tt.ok && !errors.Is(err, domain.ErrInvalidArgument) {
+				t.Fatalf("validateRewardPolicy = %v, want ok %t", err, tt.ok)
+			}
+		})
+	}
+}
+
+// referrerRewards sums the credits of a referring member.
+func referrerRewards(t *testing.T, db repository.Repository, memberId string) int64 {
+	t.Helper()
+	rewards, err := db.GetRewards(context.Background(), memberId, domain.Page{Number: 1, Size: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total int64
+	for _, reward := range rewards {
+		total += reward.Amount
+	}
+	return total
+}
@@ -0,0 +1,20 @@
+package domain
+
+// Click corresponds to the referral_clicks table, one row per visit of a shared referral link.
+// The visitor's ip is only stored hashed.
+type Click struct {
+	ID           string `json:"id,omitempty" db:"id"`
+	ReferralCode string `json:"referral_code,omitempty" db:"referral_code"`
+	MemberId     string `json:"member_id,omitempty" db:"member_id"`
+	ProgramId    string `json:"program_id,omitempty" db:"program_id"`
+	UserAgent    string `json:"user_agent,omitempty" db:"user_agent"`
+	Referer      string `json:"referer,omitempty" db:"referer"`
+	IpHash       string `json:"ip_hash,omitempty" db:"ip_hash"`
+	CreatedAt    int64  `json:"created_at,omitempty"  db:"created_at"`
+}
+
+// ClickStats aggregates clicks for a member, a program or both.
+type ClickStats struct {
+	Clicks         int64 `json:"clicks,omitempty" db:"clicks"`
+	UniqueVisitors int64 `json:"unique_visitors,omitempty" db:"unique_visitors"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "strings"
+
+// NormalizeEmail returns the form of email referees and members are compared by: lowercase,
+// without a plus suffix, and for gmail without dots, so aliases of an inbox match.
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || strings.Contains(email[:at], "@") {
+		return email
+	}
+	local, host := email[:at], email[at+1:]
+	if plus := strings.IndexByte(local, '+'); plus > 0 {
+		local = local[:plus]
+	}
+	if host == "googlemail.com" {
+		host = "gmail.com"
+	}
+	if host == "gmail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	return local + "@" + host
+}
+
+// NormalizePhone returns the digits of phone, the form referees are compared by.
+func NormalizePhone(phone string) string {
+	var digits strings.Builder
+	for _, c := range phone {
+		if c >= '0' && c <= '9' {
+			digits.WriteRune(c)
+		}
+	}
+	return digits.String()
+}
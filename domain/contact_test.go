@@ -0,0 +1,25 @@
+package domain
+
+import "testing"
+
+func TestNormalizeEmail(t *testing.T) {
+	for email, want := range map[string]string{
+		" Ada@Example.com ":     "ada@example.com",
+		"ada+promo@example.com": "ada@example.com",
+		"a.da+1@gmail.com":      "ada@gmail.com",
+		"A.Da@googlemail.com":   "ada@gmail.com",
+		"a.da@example.com":      "a.da@example.com",
+		"+ada@example.com":      "+ada@example.com",
+		"not an email":          "not an email",
+	} {
+		if got := NormalizeEmail(email); got != want {
+			t.Errorf("NormalizeEmail(%q) = %q, want %q", email, got, want)
+		}
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	if got := NormalizePhone("+1 (555) 010-0100"); got != "15550100100" {
+		t.Fatalf("NormalizePhone = %q", got)
+	}
+}
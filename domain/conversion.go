@@ -0,0 +1,19 @@
+package domain
+
+// ConversionEvent corresponds to the conversion_events table, something a referee did
+// that may qualify their referral, ex. signed_up or first_purchase.
+type ConversionEvent struct {
+	ID string `json:"id,omitempty" db:"id"`
+	// ExternalId is the sender's id for the event, events sent again with it are ignored.
+	ExternalId   string `json:"external_id,omitempty" db:"external_id"`
+	EventType    string `json:"event_type,omitempty" db:"event_type"`
+	ContactEmail string `json:"contact_email,omitempty" db:"contact_email"`
+	ContactPhone string `json:"contact_phone,omitempty" db:"contact_phone"`
+	OrderAmount  int64  `json:"order_amount,omitempty" db:"order_amount"`
+	// ReferralId is the referral the event was matched to, empty when none was.
+	ReferralId string `json:"referral_id,omitempty" db:"referral_id"`
+	// Qualified is set when the event moved its referral to qualified.
+	Qualified  bool  `json:"qualified,omitempty" db:"qualified"`
+	OccurredAt int64 `json:"occurred_at,omitempty" db:"occurred_at"`
+	CreatedAt  int64 `json:"created_at,omitempty"  db:"created_at"`
+}
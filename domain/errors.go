@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error kinds. The repository and controllers return errors wrapping one of these
+// and the handlers translate them to grpc status codes.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrConflict        = errors.New("conflict")
+)
+
+// Error is an error of a given kind whose message is safe to return to clients.
+type Error struct {
+	Kind error
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// NewError returns an error of the given kind with a formatted client safe message.
+func NewError(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Msg: fmt.Sprintf(format, args...)}
+}
+
+// ErrInvalidStatusTransition is returned when a referral status change is not allowed by the lifecycle.
+var ErrInvalidStatusTransition = NewError(ErrConflict, "invalid referral status transition")
+
+// ErrRewardNotReversible is returned when reversing a ledger entry that isn't a beneficiary's credit.
+var ErrRewardNotReversible = NewError(ErrConflict, "reward entry is not reversible")
+
+// ErrInvalidRewardPolicy is returned when a program's reward policy is malformed.
+var ErrInvalidRewardPolicy = NewError(ErrInvalidArgument, "invalid reward policy")
+
+// ErrInvalidFilter is returned when a list filter or sort order isn't supported.
+var ErrInvalidFilter = NewError(ErrInvalidArgument, "invalid filter")
+
+// ErrMemberInactive is returned when an inactive member's referral code is used.
+var ErrMemberInactive = NewError(ErrConflict, "member is inactive")
+
+// ErrReferralCodeTaken is returned when a new member's referral code belongs to another member.
+var ErrReferralCodeTaken = NewError(ErrAlreadyExists, "referral code is already taken")
+
+// ErrAmbiguousEmail is returned when looking up a member by an email enrolled in several programs without naming one.
+var ErrAmbiguousEmail = NewError(ErrInvalidArgument, "email is enrolled in several programs, program_id is required")
+
+// ErrMemberProgramChange is returned when updating a member's program, which would move their referral history.
+var ErrMemberProgramChange = NewError(ErrInvalidArgument, "a member can't move to another program, enrol the email in it instead")
+
+// ErrReferralClaimed is returned when a referral under review is claimed by another reviewer.
+var ErrReferralClaimed = NewError(ErrConflict, "referral is claimed by another reviewer")
+
+// ErrReferralNotPending is returned when claiming a referral that is no longer pending review.
+var ErrReferralNotPending = NewError(ErrConflict, "referral is not pending review")
+
+// ErrReferralCodeInactive is returned when a revoked or expired referral code is used.
+var ErrReferralCodeInactive = NewError(ErrConflict, "referral code is revoked or expired")
@@ -1,14 +1,52 @@
 package domain
 
-// Member corresponds to the members table
+// Person corresponds to the people table. A person is identified by their email
+// and enrols in programs as members.
+type Person struct {
+	ID        string `json:"id,omitempty" db:"id"`
+	Email     string `json:"email,omitempty" db:"email"`
+	CreatedAt int64  `json:"created_at,omitempty"  db:"created_at"`
+}
+
+// Member corresponds to the members table, one enrolment of a person in a program
 type Member struct {
 	ID           string `json:"id,omitempty" db:"id"`
+	PersonId     string `json:"person_id,omitempty" db:"person_id"`
 	FirstName    string `json:"first_name,omitempty" db:"first_name"`
 	LastName     string `json:"last_name,omitempty" db:"last_name"`
 	Email        string `json:"email,omitempty" db:"email"`
+	ContactEmail string `json:"contact_email,omitempty" db:"contact_email"`
 	ProgramId    string `json:"program_id,omitempty" db:"program_id"`
 	ReferralCode string `json:"referral_code,omitempty" db:"referral_code"`
 	IsActive     bool   `json:"is_active,omitempty" db:"is_active"`
 	CreatedAt    int64  `json:"created_at,omitempty"  db:"created_at"`
 	UpdatedAt    int64  `json:"updated_at,omitempty"  db:"updated_at"`
 }
+
+// MemberFilter narrows a member listing. Nil fields don't filter, Email matches regardless of case
+// and ContactEmail matches the normalised email, see NormalizeEmail.
+type MemberFilter struct {
+	ProgramId    *string
+	PersonId     *string
+	Email        *string
+	ContactEmail *string
+}
+
+// ReferralCode corresponds to the referral_codes table. A member has a current code,
+// the one on the member, and may have more, ex. one per channel. Codes replaced by a
+// vanity code are retired but keep resolving so that links already shared still work;
+// revoked or expired codes don't resolve any more.
+type ReferralCode struct {
+	Code      string `json:"code,omitempty" db:"code"`
+	MemberId  string `json:"member_id,omitempty" db:"member_id"`
+	Channel   string `json:"channel,omitempty" db:"channel"`
+	CreatedAt int64  `json:"created_at,omitempty" db:"created_at"`
+	ExpiresAt int64  `json:"expires_at,omitempty" db:"expires_at"`
+	RetiredAt int64  `json:"retired_at,omitempty" db:"retired_at"`
+	RevokedAt int64  `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// Active reports whether the code accepts referrals at now, in unix seconds.
+func (c ReferralCode) Active(now int64) bool {
+	return c.RevokedAt == 0 && (c.ExpiresAt == 0 || c.ExpiresAt > now)
+}
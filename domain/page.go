@@ -0,0 +1,17 @@
+package domain
+
+// Page selects a slice of a list ordered by (sort key, id).
+// When After is set the list continues after that row (keyset pagination) and
+// Number is ignored; otherwise Number is a 1-based page offset.
+type Page struct {
+	Number int
+	Size   int
+	After  *Cursor
+}
+
+// Cursor is the position of a row in a list ordered by (Key, ID).
+// Key holds the row's sort column value, created_at unless the list says otherwise.
+type Cursor struct {
+	Key int64  `json:"k"`
+	ID  string `json:"i"`
+}
@@ -1,11 +1,104 @@
 package domain
 
+// Reward policy types.
+const (
+	RewardTypeFixed      = "fixed"
+	RewardTypePercentage = "percentage"
+)
+
+// MaxPercentageReward is the largest percentage reward, 100% of the order in basis points.
+const MaxPercentageReward = 10000
+
 // Referral Program corresponds to the program table
 type Program struct {
-	ID        string `json:"id,omitempty" db:"id"`
-	Name      string `json:"name,omitempty" db:"name"`
-	Title     string `json:"title,omitempty" db:"title"`
-	IsActive  bool   `json:"is_active,omitempty" db:"is_active"`
-	CreatedAt int64  `json:"created_at,omitempty"  db:"created_at"`
-	UpdatedAt int64  `json:"updated_at,omitempty"  db:"updated_at"`
+	ID         string `json:"id,omitempty" db:"id"`
+	Name       string `json:"name,omitempty" db:"name"`
+	Title      string `json:"title,omitempty" db:"title"`
+	IsActive   bool   `json:"is_active,omitempty" db:"is_active"`
+	LandingUrl string `json:"landing_url,omitempty" db:"landing_url"`
+	// CodePrefix starts the referral codes generated for the program's members.
+	CodePrefix string `json:"code_prefix,omitempty" db:"code_prefix"`
+	// AttributionWindowDays is how long referrals stay pending before they expire, 0 never expires them.
+	AttributionWindowDays int64 `json:"attribution_window_days,omitempty" db:"attribution_window_days"`
+	CreatedAt             int64 `json:"created_at,omitempty"  db:"created_at"`
+	UpdatedAt             int64 `json:"updated_at,omitempty"  db:"updated_at"`
+	RewardPolicy
+	QualificationRule
+}
+
+// Expired reports whether referral's attribution window closed by now, in unix seconds.
+func (p Program) Expired(referral Referral, now int64) bool {
+	return p.AttributionWindowDays > 0 && now >= referral.CreatedAt+p.AttributionWindowDays*24*60*60
+}
+
+// RewardPolicy holds a program's reward rules, stored alongside the programs table.
+// Fixed policies pay rewards in the currency's minor unit, percentage policies pay
+// basis points of the referral's order amount. Zero limits mean unlimited.
+type RewardPolicy struct {
+	RewardType          string `json:"reward_type,omitempty" db:"reward_type"`
+	RewardCurrency      string `json:"reward_currency,omitempty" db:"reward_currency"`
+	ReferrerReward      int64  `json:"referrer_reward,omitempty" db:"referrer_reward"`
+	RefereeReward       int64  `json:"referee_reward,omitempty" db:"referee_reward"`
+	MaxRewardsPerMember int64  `json:"max_rewards_per_member,omitempty" db:"max_rewards_per_member"`
+	RewardCap           int64  `json:"reward_cap,omitempty" db:"reward_cap"`
+}
+
+// QualificationRule holds the conversion that qualifies a program's referrals, stored
+// alongside the programs table. Programs without a QualifyEvent don't qualify referrals
+// from conversion events. Zero limits mean unlimited.
+type QualificationRule struct {
+	// QualifyEvent is the conversion event type, ex. first_purchase.
+	QualifyEvent string `json:"qualify_event,omitempty" db:"qualify_event"`
+	// QualifyMinOrder is the smallest order amount that qualifies, in minor units.
+	QualifyMinOrder int64 `json:"qualify_min_order,omitempty" db:"qualify_min_order"`
+	// QualifyWithinDays is how long after the referral the event must happen.
+	QualifyWithinDays int64 `json:"qualify_within_days,omitempty" db:"qualify_within_days"`
+}
+
+// Unmet returns why a conversion event doesn't qualify referral under the rule, empty if it does.
+func (q QualificationRule) Unmet(event ConversionEvent, referral Referral) string {
+	switch {
+	case q.QualifyEvent == "":
+		return "program doesn't qualify referrals from conversion events"
+	case event.EventType != q.QualifyEvent:
+		return "program qualifies referrals on " + q.QualifyEvent
+	case event.OrderAmount < q.QualifyMinOrder:
+		return "order amount is below the program's minimum"
+	case event.OccurredAt < referral.CreatedAt:
+		return "event happened before the referral"
+	case q.QualifyWithinDays > 0 && event.OccurredAt > referral.CreatedAt+q.QualifyWithinDays*24*60*60:
+		return "event happened after the program's qualification window"
+	}
+	return ""
+}
+
+// IsSet reports whether the program carries its own reward policy.
+func (p RewardPolicy) IsSet() bool {
+	return p.RewardType != ""
+}
+
+// Limit returns the policy's limits on the rewards of program programId.
+func (p RewardPolicy) Limit(programId string) RewardLimit {
+	return RewardLimit{
+		ProgramId:           programId,
+		Currency:            p.RewardCurrency,
+		MaxRewardsPerMember: p.MaxRewardsPerMember,
+		RewardCap:           p.RewardCap,
+	}
+}
+
+// RewardAmount returns the reward for a configured value, given the referral's order amount.
+// Percentage rewards are rounded down to the minor unit and never exceed the order.
+func (p RewardPolicy) RewardAmount(value int64, orderAmount int64) int64 {
+	if p.RewardType != RewardTypePercentage {
+		return value
+	}
+	if value <= 0 || orderAmount <= 0 {
+		return 0
+	}
+	if value > MaxPercentageReward {
+		value = MaxPercentageReward
+	}
+	// splitting the order keeps the product below orderAmount, so it can't overflow.
+	return orderAmount/MaxPercentageReward*value + orderAmount%MaxPercentageReward*value/MaxPercentageReward
 }
@@ -1,16 +1,128 @@
 package domain
 
+// Referral lifecycle statuses, mirrors the referrals.status check constraint.
+const (
+	ReferralStatusPending   = "pending"
+	ReferralStatusQualified = "qualified"
+	ReferralStatusApproved  = "approved"
+	ReferralStatusDenied    = "denied"
+	ReferralStatusExpired   = "expired"
+)
+
+// referralTransitions lists the statuses a referral can move to from its current status.
+// Referrals are qualified before they're approved, approved, denied and expired are terminal.
+// Only the expiry job expires referrals.
+var referralTransitions = map[string][]string{
+	ReferralStatusPending:   {ReferralStatusQualified, ReferralStatusDenied, ReferralStatusExpired},
+	ReferralStatusQualified: {ReferralStatusApproved, ReferralStatusDenied},
+}
+
+// CanTransitionReferral reports whether a referral can move from one status to another.
+func CanTransitionReferral(from string, to string) bool {
+	for _, next := range referralTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
 // Referral corresponds to the referrals table
 type Referral struct {
-	ID           string `json:"id,omitempty" db:"id"`
-	FirstName    string `json:"first_name,omitempty" db:"first_name"`
-	LastName     string `json:"last_name,omitempty" db:"last_name"`
-	Email        string `json:"email,omitempty" db:"email"`
-	Phone        string `json:"phone,omitempty" db:"phone"`
-	ReferralCode string `json:"referral_code,omitempty" db:"referral_code"`
-	Status       string `json:"status,omitempty" db:"status"`
-	CreatedAt    int64  `json:"created_at,omitempty"  db:"created_at"`
-	UpdatedAt    int64  `json:"updated_at,omitempty"  db:"updated_at"`
-	ProgramId    string `json:"program_id,omitempty" db:"program_id"`
-	MemberId     string `json:"member_id,omitempty" db:"member_id"`
+	ID              string `json:"id,omitempty" db:"id"`
+	FirstName       string `json:"first_name,omitempty" db:"first_name"`
+	LastName        string `json:"last_name,omitempty" db:"last_name"`
+	Email           string `json:"email,omitempty" db:"email"`
+	Phone           string `json:"phone,omitempty" db:"phone"`
+	ContactEmail    string `json:"contact_email,omitempty" db:"contact_email"`
+	ContactPhone    string `json:"contact_phone,omitempty" db:"contact_phone"`
+	IpHash          string `json:"ip_hash,omitempty" db:"ip_hash"`
+	ReferralCode    string `json:"referral_code,omitempty" db:"referral_code"`
+	Status          string `json:"status,omitempty" db:"status"`
+	StatusChangedBy string `json:"status_changed_by,omitempty" db:"status_changed_by"`
+	StatusReason    string `json:"status_reason,omitempty" db:"status_reason"`
+	Flagged         bool   `json:"flagged,omitempty" db:"flagged"`
+	FlagReason      string `json:"flag_reason,omitempty" db:"flag_reason"`
+	RiskScore       int64  `json:"risk_score,omitempty" db:"risk_score"`
+	// OrderAmount is the referee's order that qualified the referral, in minor units.
+	OrderAmount int64  `json:"order_amount,omitempty" db:"order_amount"`
+	ClaimedBy   string `json:"claimed_by,omitempty" db:"claimed_by"`
+	ClaimedAt   int64  `json:"claimed_at,omitempty" db:"claimed_at"`
+	CreatedAt   int64  `json:"created_at,omitempty"  db:"created_at"`
+	UpdatedAt   int64  `json:"updated_at,omitempty"  db:"updated_at"`
+	ProgramId   string `json:"program_id,omitempty" db:"program_id"`
+	MemberId    string `json:"member_id,omitempty" db:"member_id"`
+	Channel     string `json:"channel,omitempty" db:"channel"`
+}
+
+// Referral list sort columns.
+const (
+	ReferralSortCreatedAt = "created_at"
+	ReferralSortUpdatedAt = "updated_at"
+	ReferralSortRiskScore = "risk_score"
+)
+
+// ReferralLimit caps the referrals matching Filter created in the last WindowMinutes at Max.
+// A new referral over the limit is rejected when Reject is set, flagged with Reason otherwise.
+type ReferralLimit struct {
+	Filter        ReferralFilter
+	Max           int64
+	WindowMinutes int64
+	Reject        bool
+	Reason        string
+}
+
+// ReferralFilter narrows and orders a referral listing. Nil fields don't filter.
+// The created range includes CreatedAfter and excludes CreatedBefore.
+type ReferralFilter struct {
+	ProgramId     *string
+	MemberId      *string
+	ReferralCode  *string
+	Channel       *string
+	Status        *string
+	Flagged       *bool
+	Email         *string
+	ContactEmail  *string
+	ContactPhone  *string
+	IpHash        *string
+	ClaimedBy     *string
+	CreatedAfter  *int64
+	CreatedBefore *int64
+	SortBy        string
+	Descending    bool
+}
+
+// SortKey returns the referral's value for the filter's sort column, used as its page cursor key.
+func (f ReferralFilter) SortKey(referral Referral) int64 {
+	switch f.SortBy {
+	case ReferralSortUpdatedAt:
+		return referral.UpdatedAt
+	case ReferralSortRiskScore:
+		return referral.RiskScore
+	}
+	return referral.CreatedAt
+}
+
+// ReferralStatusChange corresponds to the referral_status_changes table,
+// an audit trail of who moved a referral between statuses and why.
+type ReferralStatusChange struct {
+	ID         string `json:"id,omitempty" db:"id"`
+	ReferralId string `json:"referral_id,omitempty" db:"referral_id"`
+	FromStatus string `json:"from_status,omitempty" db:"from_status"`
+	ToStatus   string `json:"to_status,omitempty" db:"to_status"`
+	ChangedBy  string `json:"changed_by,omitempty" db:"changed_by"`
+	Reason     string `json:"reason,omitempty" db:"reason"`
+	CreatedAt  int64  `json:"created_at,omitempty"  db:"created_at"`
+	// StaleClaimsBefore, when set, fails the change while a reviewer other than ChangedBy
+	// holds a claim on the referral made at or after it, in unix seconds. It isn't stored.
+	StaleClaimsBefore int64 `json:"-" db:"-"`
+}
+
+// ReferralNote corresponds to the referral_notes table, reviewers' notes on a referral.
+type ReferralNote struct {
+	ID         string `json:"id,omitempty" db:"id"`
+	ReferralId string `json:"referral_id,omitempty" db:"referral_id"`
+	Author     string `json:"author,omitempty" db:"author"`
+	Note       string `json:"note,omitempty" db:"note"`
+	CreatedAt  int64  `json:"created_at,omitempty"  db:"created_at"`
 }
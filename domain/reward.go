@@ -0,0 +1,68 @@
+package domain
+
+// Reward beneficiaries.
+const (
+	RewardBeneficiaryReferrer = "referrer"
+	RewardBeneficiaryReferee  = "referee"
+)
+
+// Reward ledger entry types.
+const (
+	RewardEntryCredit = "credit"
+	RewardEntryDebit  = "debit"
+)
+
+// Reward ledger accounts. Beneficiary entries are what a referrer or referee is owed,
+// program entries what their program owes them.
+const (
+	RewardAccountBeneficiary = "beneficiary"
+	RewardAccountProgram     = "program"
+)
+
+// Reward corresponds to the rewards table, a double-entry ledger of what members earn.
+// Entries are never updated; a reversal is a debit entry pointing at the credit it cancels.
+// Each beneficiary entry is balanced by the opposite entry on its program's account, with
+// BalanceOf set to the entry's id, so a program's entries net to zero in every currency.
+// Amount is always positive and in the currency's minor unit (ex. cents).
+// Referee and program entries have no MemberId since neither account is a member's.
+type Reward struct {
+	ID          string `json:"id,omitempty" db:"id"`
+	Account     string `json:"account,omitempty" db:"account"`
+	Beneficiary string `json:"beneficiary,omitempty" db:"beneficiary"`
+	MemberId    string `json:"member_id,omitempty" db:"member_id"`
+	ProgramId   string `json:"program_id,omitempty" db:"program_id"`
+	ReferralId  string `json:"referral_id,omitempty" db:"referral_id"`
+	EntryType   string `json:"entry_type,omitempty" db:"entry_type"`
+	Amount      int64  `json:"amount,omitempty" db:"amount"`
+	Currency    string `json:"currency,omitempty" db:"currency"`
+	ReversalOf  string `json:"reversal_of,omitempty" db:"reversal_of"`
+	BalanceOf   string `json:"balance_of,omitempty" db:"balance_of"`
+	Reason      string `json:"reason,omitempty" db:"reason"`
+	CreatedBy   string `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   int64  `json:"created_at,omitempty"  db:"created_at"`
+}
+
+// RewardLimit bounds the credits a program pays, from its RewardPolicy. Referrer credits
+// stop once a member earned MaxRewardsPerMember of them, and credits in Currency stop
+// before the program's net rewards would exceed RewardCap. Zero limits mean unlimited.
+type RewardLimit struct {
+	ProgramId           string
+	Currency            string
+	MaxRewardsPerMember int64
+	RewardCap           int64
+}
+
+// SignedAmount is the amount the entry adds to a balance, negative for debits.
+func (r Reward) SignedAmount() int64 {
+	if r.EntryType == RewardEntryCredit {
+		return r.Amount
+	}
+	return -r.Amount
+}
+
+// RewardBalance is a member's net reward balance in a single currency.
+type RewardBalance struct {
+	MemberId string `json:"member_id,omitempty" db:"member_id"`
+	Currency string `json:"currency,omitempty" db:"currency"`
+	Amount   int64  `json:"amount,omitempty" db:"amount"`
+}
@@ -0,0 +1,111 @@
+// Package expiry expires pending referrals once their program's attribution window closes.
+package expiry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"referral-service/repository"
+
+	"go.uber.org/zap"
+)
+
+// Status change recorded on the referrals the job expires.
+const (
+	ChangedBy = "referral-expiry"
+	Reason    = "attribution window closed"
+)
+
+// Config sets how often the job runs and how many referrals it expires per transaction.
+type Config struct {
+	// IntervalMinutes is the time between runs, 0 turns the job off.
+	IntervalMinutes int64 `yaml:"interval_minutes"`
+	BatchSize       int64 `yaml:"batch_size"`
+}
+
+var DefaultConfig = Config{
+	IntervalMinutes: 60,
+	BatchSize:       500,
+}
+
+// Job expires stale pending referrals in batches until none are left.
+type Job struct {
+	log *zap.Logger
+	db  repository.Repository
+	cfg Config
+	now func() time.Time
+
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+func NewJob(log *zap.Logger, db repository.Repository, cfg Config) (*Job, error) {
+	if cfg.IntervalMinutes < 0 || cfg.BatchSize < 1 {
+		return nil, fmt.Errorf("invalid referral expiry config %+v", cfg)
+	}
+	return &Job{log: log, db: db, cfg: cfg, now: time.Now}, nil
+}
+
+// Run expires every referral whose attribution window closed, a batch per transaction,
+// and returns how many it expired. Batches expired before an error stay expired.
+func (j *Job) Run(ctx context.Context) (int, error) {
+	now := j.now().UTC().Unix()
+	total := 0
+	for {
+		expired, err := j.db.ExpireReferrals(ctx, now, j.cfg.BatchSize, ChangedBy, Reason)
+		if err != nil {
+			return total, fmt.Errorf("expire referrals %w", err)
+		}
+		total += len(expired)
+		if int64(len(expired)) < j.cfg.BatchSize || ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+	}
+}
+
+// Start runs the job now and then every IntervalMinutes until Stop.
+func (j *Job) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+	j.done.Add(1)
+	go func() {
+		defer j.done.Done()
+		ticker := time.NewTicker(time.Duration(j.cfg.IntervalMinutes) * time.Minute)
+		defer ticker.Stop()
+		for {
+			j.runOnce(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop cancels a run in progress and waits for it to return.
+func (j *Job) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.done.Wait()
+}
+
+func (j *Job) runOnce(ctx context.Context) {
+	started := time.Now()
+	expired, err := j.Run(ctx)
+	if err != nil && ctx.Err() == nil {
+		j.log.Error("referral expiry run failed",
+			zap.Int("expired", expired),
+			zap.Duration("duration", time.Since(started)),
+			zap.Error(err),
+		)
+		return
+	}
+	j.log.Info("referral expiry run",
+		zap.Int("expired", expired),
+		zap.Duration("duration", time.Since(started)),
+	)
+}
@@ -0,0 +1,65 @@
+package expiry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"referral-service/domain"
+	"referral-service/repository"
+
+	"go.uber.org/zap"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	db := repository.NewMemory(zap.NewNop())
+	programId, err := db.AddProgram(ctx, "friends", "Friends", true, "", "", domain.RewardPolicy{}, domain.QualificationRule{}, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.AddMember(ctx, "Ada", nil, "ada@example.com", programId, "ada01", nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, email := range []string{"bo@example.com", "cy@example.com", "di@example.com", "ed@example.com", "fay@example.com"} {
+		if _, err := db.AddReferral(ctx, domain.Referral{Email: email, ContactEmail: email, ReferralCode: "ada01"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	job, err := NewJob(zap.NewNop(), db, Config{IntervalMinutes: 60, BatchSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name  string
+		after time.Duration
+		want  int
+	}{
+		{"within the window", 6 * 24 * time.Hour, 0},
+		{"every batch", 8 * 24 * time.Hour, 5},
+		{"nothing left", 8 * 24 * time.Hour, 0},
+	} {
+		job.now = func() time.Time { return time.Now().Add(tt.after) }
+		expired, err := job.Run(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expired != tt.want {
+			t.Fatalf("%s: expired %d, want %d", tt.name, expired, tt.want)
+		}
+	}
+
+	status := domain.ReferralStatusExpired
+	referrals, err := db.GetReferrals(ctx, domain.ReferralFilter{Status: &status}, domain.Page{Number: 1, Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(referrals) != 5 || referrals[0].StatusChangedBy != ChangedBy || referrals[0].StatusReason != Reason {
+		t.Fatalf("expired referrals = %+v", referrals)
+	}
+
+	if _, err := NewJob(zap.NewNop(), db, Config{IntervalMinutes: 60}); err == nil {
+		t.Fatal("NewJob accepted a batch size of 0")
+	}
+}
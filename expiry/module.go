@@ -0,0 +1,59 @@
+package expiry
+
+import (
+	"context"
+	"fmt"
+
+	"referral-service/repository"
+
+	"go.uber.org/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var Module = fx.Module(
+	"expiry",
+	fx.Invoke(New),
+)
+
+type Params struct {
+	fx.In
+
+	Log *zap.Logger
+	Lc  fx.Lifecycle
+	Cfg config.Provider
+	Db  repository.Repository
+}
+
+// New schedules the job configured under referral_expiry, DefaultConfig for anything unset,
+// to run while the app does.
+func New(p Params) error {
+	cfg := DefaultConfig
+	if err := p.Cfg.Get("referral_expiry").Populate(&cfg); err != nil {
+		return fmt.Errorf("referral_expiry config populate %w", err)
+	}
+	job, err := NewJob(p.Log, p.Db, cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.IntervalMinutes == 0 {
+		p.Log.Info("referral expiry job is off")
+		return nil
+	}
+
+	p.Lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			p.Log.Info("starting referral expiry job",
+				zap.Int64("interval_minutes", cfg.IntervalMinutes),
+				zap.Int64("batch_size", cfg.BatchSize),
+			)
+			job.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			job.Stop()
+			return nil
+		},
+	})
+	return nil
+}
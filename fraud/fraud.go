@@ -0,0 +1,286 @@
+// Package fraud screens new referrals for abuse before they are stored.
+//
+// Every check has an action: rejected referrals aren't stored, flagged ones are
+// stored with the reasons so they can be reviewed, and checks that are off don't run.
+// Referrals are also given a risk score by the built-in and any extra Scorers,
+// and flagged when it's high.
+package fraud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"referral-service/domain"
+	"referral-service/repository"
+)
+
+// Check actions.
+const (
+	ActionOff    = "off"
+	ActionFlag   = "flag"
+	ActionReject = "reject"
+)
+
+// Config sets the action of every check.
+type Config struct {
+	// SelfReferral catches referees with the referring member's email.
+	SelfReferral string `yaml:"self_referral"`
+	// DuplicateContact catches referees whose email or phone was already referred in the program.
+	DuplicateContact string `yaml:"duplicate_contact"`
+	// ExistingMember catches referees who are already members of the program.
+	ExistingMember string `yaml:"existing_member"`
+	// Velocity catches bursts of referrals from one code, program or address.
+	Velocity VelocityConfig `yaml:"velocity"`
+	// Risk scores referrals.
+	Risk RiskConfig `yaml:"risk"`
+}
+
+// VelocityConfig limits how many referrals are accepted in a window before
+// further ones are caught. Every limit is checked on its own.
+type VelocityConfig struct {
+	Action     string `yaml:"action"`
+	PerCode    Limit  `yaml:"per_code"`
+	PerProgram Limit  `yaml:"per_program"`
+	// PerIp counts referrals by the hashed address they were submitted from.
+	PerIp Limit `yaml:"per_ip"`
+}
+
+// Limit allows Max referrals in the last WindowMinutes, a zero Max is no limit.
+type Limit struct {
+	Max           int64 `yaml:"max"`
+	WindowMinutes int64 `yaml:"window_minutes"`
+}
+
+var DefaultConfig = Config{
+	SelfReferral:     ActionReject,
+	DuplicateContact: ActionFlag,
+	ExistingMember:   ActionFlag,
+	Velocity: VelocityConfig{
+		Action:  ActionFlag,
+		PerCode: Limit{Max: 20, WindowMinutes: 24 * 60},
+		PerIp:   Limit{Max: 10, WindowMinutes: 60},
+	},
+	Risk: DefaultRiskConfig,
+}
+
+// Verdict is the outcome of screening a referral.
+type Verdict struct {
+	Reject    bool
+	Reasons   []string
+	RiskScore int64
+	// Limits are the velocity limits the referral is stored under, see repository.AddReferral.
+	// They're counted when it's stored so that referrals arriving together can't all fit.
+	Limits []domain.ReferralLimit
+}
+
+// Flagged reports whether the referral is stored but needs review.
+func (v Verdict) Flagged() bool {
+	return !v.Reject && len(v.Reasons) > 0
+}
+
+// Reason joins the reasons of the verdict.
+func (v Verdict) Reason() string {
+	return strings.Join(v.Reasons, "; ")
+}
+
+// Screener screens a new referral made with a code of referrer. The referral's
+// ContactEmail and ContactPhone must be set, see domain.NormalizeEmail and domain.NormalizePhone.
+type Screener interface {
+	Screen(ctx context.Context, referral domain.Referral, referrer domain.Member) (Verdict, error)
+}
+
+// check reports the reason referral is suspicious, empty if it isn't.
+type check struct {
+	action string
+	run    func(ctx context.Context, referral domain.Referral, referrer domain.Member) (string, error)
+}
+
+// velocityLimit is a velocity limit whose scope narrows the referrals it counts
+// and reports whether it applies.
+type velocityLimit struct {
+	name  string
+	limit Limit
+	scope func(filter *domain.ReferralFilter, referral domain.Referral, referrer domain.Member) bool
+}
+
+type screener struct {
+	db        repository.Repository
+	checks    []check
+	scorers   []Scorer
+	flagScore int64
+	velocity  []velocityLimit
+	reject    bool
+}
+
+// NewScreener returns a screener running the checks cfg doesn't turn off and
+// scoring with the built-in scorer followed by scorers.
+func NewScreener(db repository.Repository, cfg Config, scorers ...Scorer) (Screener, error) {
+	builtin, err := newRiskScorer(db, cfg.Risk)
+	if err != nil {
+		return nil, err
+	}
+	s := &screener{
+		db:        db,
+		scorers:   append([]Scorer{builtin}, scorers...),
+		flagScore: cfg.Risk.FlagScore,
+		reject:    cfg.Velocity.Action == ActionReject,
+	}
+	checks := []check{
+		{cfg.SelfReferral, s.selfReferral},
+		{cfg.DuplicateContact, s.duplicateContact},
+		{cfg.ExistingMember, s.existingMember},
+	}
+	for _, l := range []velocityLimit{
+		{"referral code", cfg.Velocity.PerCode, byCode},
+		{"program", cfg.Velocity.PerProgram, byProgram},
+		{"address", cfg.Velocity.PerIp, byIp},
+	} {
+		if l.limit.Max < 0 || (l.limit.Max > 0 && l.limit.WindowMinutes <= 0) {
+			return nil, fmt.Errorf("invalid %s velocity limit %+v", l.name, l.limit)
+		}
+		if l.limit.Max > 0 {
+			s.velocity = append(s.velocity, l)
+		}
+	}
+	switch {
+	case len(s.velocity) == 0:
+	case cfg.Velocity.Action == ActionOff:
+		s.velocity = nil
+	case cfg.Velocity.Action != ActionFlag && cfg.Velocity.Action != ActionReject:
+		return nil, fmt.Errorf("unknown fraud check action %q", cfg.Velocity.Action)
+	}
+	for _, c := range checks {
+		switch c.action {
+		case ActionOff:
+		case ActionFlag, ActionReject:
+			s.checks = append(s.checks, c)
+		default:
+			return nil, fmt.Errorf("unknown fraud check action %q", c.action)
+		}
+	}
+	return s, nil
+}
+
+// Screen runs every check and scorer. A referral is rejected when any rejecting check
+// catches it, the verdict then only holds the reasons for rejecting.
+func (s *screener) Screen(ctx context.Context, referral domain.Referral, referrer domain.Member) (Verdict, error) {
+	score, risks, err := s.score(ctx, referral, referrer)
+	if err != nil {
+		return Verdict{}, err
+	}
+	var flags, rejects []string
+	for _, c := range s.checks {
+		reason, err := c.run(ctx, referral, referrer)
+		if err != nil {
+			return Verdict{}, err
+		}
+		switch {
+		case reason == "":
+		case c.action == ActionReject:
+			rejects = append(rejects, reason)
+		default:
+			flags = append(flags, reason)
+		}
+	}
+	if len(rejects) > 0 {
+		return Verdict{Reject: true, Reasons: rejects, RiskScore: score}, nil
+	}
+	if s.flagScore > 0 && score >= s.flagScore {
+		flags = append(flags, fmt.Sprintf("risk score %d: %s", score, strings.Join(risks, ", ")))
+	}
+	return Verdict{Reasons: flags, RiskScore: score, Limits: s.limits(referral, referrer)}, nil
+}
+
+// score sums the points of the risks the scorers find, up to MaxRiskScore.
+func (s *screener) score(ctx context.Context, referral domain.Referral, referrer domain.Member) (int64, []string, error) {
+	var score int64
+	var reasons []string
+	for _, scorer := range s.scorers {
+		risks, err := scorer.Score(ctx, referral, referrer)
+		if err != nil {
+			return 0, nil, err
+		}
+		for _, risk := range risks {
+			score += risk.Points
+			reasons = append(reasons, risk.Reason)
+		}
+	}
+	if score > MaxRiskScore {
+		score = MaxRiskScore
+	}
+	if score < 0 {
+		score = 0
+	}
+	return score, reasons, nil
+}
+
+func (s *screener) selfReferral(ctx context.Context, referral domain.Referral, referrer domain.Member) (string, error) {
+	if referral.ContactEmail != "" && referral.ContactEmail == domain.NormalizeEmail(referrer.Email) {
+		return "referee is the referring member", nil
+	}
+	return "", nil
+}
+
+func (s *screener) duplicateContact(ctx context.Context, referral domain.Referral, referrer domain.Member) (string, error) {
+	count, err := s.db.CountReferralsByContact(ctx, referrer.ProgramId, referral.ContactEmail, referral.ContactPhone)
+	if err != nil {
+		return "", fmt.Errorf("duplicate contact check %w", err)
+	}
+	if count > 0 {
+		return "referee was already referred", nil
+	}
+	return "", nil
+}
+
+// existingMember looks members up by the referee's normalised email, so aliases of a member's inbox match.
+func (s *screener) existingMember(ctx context.Context, referral domain.Referral, referrer domain.Member) (string, error) {
+	if referral.ContactEmail == "" {
+		return "", nil
+	}
+	members, err := s.db.GetMembers(ctx,
+		domain.MemberFilter{ProgramId: &referrer.ProgramId, ContactEmail: &referral.ContactEmail},
+		domain.Page{Number: 1, Size: 1})
+	if err != nil {
+		return "", fmt.Errorf("existing member check %w", err)
+	}
+	if len(members) > 0 {
+		return "referee is already a member", nil
+	}
+	return "", nil
+}
+
+// limits returns the velocity limits that apply to referral.
+func (s *screener) limits(referral domain.Referral, referrer domain.Member) []domain.ReferralLimit {
+	var limits []domain.ReferralLimit
+	for _, l := range s.velocity {
+		var filter domain.ReferralFilter
+		if !l.scope(&filter, referral, referrer) {
+			continue
+		}
+		limits = append(limits, domain.ReferralLimit{
+			Filter:        filter,
+			Max:           l.limit.Max,
+			WindowMinutes: l.limit.WindowMinutes,
+			Reject:        s.reject,
+			Reason:        fmt.Sprintf("%s exceeded %d referrals in %d minutes", l.name, l.limit.Max, l.limit.WindowMinutes),
+		})
+	}
+	return limits
+}
+
+func byCode(filter *domain.ReferralFilter, referral domain.Referral, referrer domain.Member) bool {
+	filter.ReferralCode = &referral.ReferralCode
+	return true
+}
+
+func byProgram(filter *domain.ReferralFilter, referral domain.Referral, referrer domain.Member) bool {
+	filter.ProgramId = &referrer.ProgramId
+	return true
+}
+
+// byIp doesn't apply to referrals without a known address.
+func byIp(filter *domain.ReferralFilter, referral domain.Referral, referrer domain.Member) bool {
+	filter.IpHash = &referral.IpHash
+	return referral.IpHash != ""
+}
@@ -0,0 +1,223 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"referral-service/domain"
+	"referral-service/repository"
+
+	"go.uber.org/zap"
+)
+
+func TestScreen(t *testing.T) {
+	ctx := context.Background()
+	db := repository.NewMemory(zap.NewNop())
+	programId, err := db.AddProgram(ctx, "friends", "Friends", true, "", "", domain.RewardPolicy{}, domain.QualificationRule{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adaId, err := db.AddMember(ctx, "Ada", nil, "Ada@example.com", programId, "ada01", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.AddMember(ctx, "Bob", nil, "bob@example.com", programId, "bob01", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.AddMember(ctx, "Foo", nil, "foobar@gmail.com", programId, "foo01", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.AddMember(ctx, "Eve", nil, "Eve.Lyn+work@example.com", programId, "eve01", nil); err != nil {
+		t.Fatal(err)
+	}
+	ada, err := db.GetMember(ctx, adaId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	referred := domain.Referral{ContactEmail: "cy@example.com", ContactPhone: "15550100", ReferralCode: "ada01"}
+	if _, err := db.AddReferral(ctx, referred); err != nil {
+		t.Fatal(err)
+	}
+
+	screener, err := NewScreener(db, DefaultConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name  string
+		email string
+		phone string
+		want  Verdict
+	}{
+		{"clean", "dee@example.com", "15550199", Verdict{}},
+		{"self referral", " ADA@example.com", "", Verdict{Reject: true, Reasons: []string{"referee is the referring member"}}},
+		{"same email", "cy@example.com", "", Verdict{Reasons: []string{"referee was already referred"}}},
+		{"same phone", "", "15550100", Verdict{Reasons: []string{"referee was already referred"}}},
+		{"member", "Bob@example.com", "", Verdict{Reasons: []string{"referee is already a member"}}},
+		{"member's gmail alias", "Foo.Bar+x@Gmail.com", "", Verdict{Reasons: []string{"referee is already a member"}}},
+		{"member enrolled with an alias", "eve.lyn@example.com", "", Verdict{Reasons: []string{"referee is already a member"}}},
+		{"other inbox", "eve.lyn@gmail.com", "", Verdict{}},
+	} {
+		referral := domain.Referral{Email: tt.email, ContactEmail: domain.NormalizeEmail(tt.email), ContactPhone: domain.NormalizePhone(tt.phone)}
+		got, err := screener.Screen(ctx, referral, ada)
+		if err != nil {
+			t.Fatalf("%s: Screen: %v", tt.name, err)
+		}
+		if got.Reject != tt.want.Reject || !reflect.DeepEqual(got.Reasons, tt.want.Reasons) {
+			t.Errorf("%s: Screen = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	// checks that are off don't run.
+	off, err := NewScreener(db, Config{SelfReferral: ActionOff, DuplicateContact: ActionOff, ExistingMember: ActionFlag})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := off.Screen(ctx, domain.Referral{Email: "ada@example.com", ContactEmail: "ada@example.com"}, ada)
+	if err != nil || got.Reject || !reflect.DeepEqual(got.Reasons, []string{"referee is already a member"}) {
+		t.Fatalf("Screen with checks off = %+v, %v", got, err)
+	}
+
+	if _, err := NewScreener(db, Config{SelfReferral: "block"}); err == nil {
+		t.Fatal("NewScreener accepted an unknown action")
+	}
+}
+
+func TestVelocity(t *testing.T) {
+	ctx := context.Background()
+	db := repository.NewMemory(zap.NewNop())
+	programId, err := db.AddProgram(ctx, "friends", "Friends", true, "", "", domain.RewardPolicy{}, domain.QualificationRule{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adaId, err := db.AddMember(ctx, "Ada", nil, "ada@example.com", programId, "ada01", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ada, err := db.GetMember(ctx, adaId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ip := range []string{"a", "b"} {
+		if _, err := db.AddReferral(ctx, domain.Referral{ReferralCode: "ada01", IpHash: ip}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := Config{SelfReferral: ActionOff, DuplicateContact: ActionOff, ExistingMember: ActionOff,
+		Velocity: VelocityConfig{
+			Action:  ActionReject,
+			PerCode: Limit{Max: 3, WindowMinutes: 60},
+			PerIp:   Limit{Max: 1, WindowMinutes: 60},
+		}}
+	s, err := NewScreener(db, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// add screens referral and stores it under the limits of its verdict.
+	add := func(s Screener, ip string) (domain.Referral, error) {
+		t.Helper()
+		referral := domain.Referral{ReferralCode: "ada01", IpHash: ip}
+		verdict, err := s.Screen(ctx, referral, ada)
+		if err != nil || verdict.Reject || len(verdict.Reasons) > 0 {
+			t.Fatalf("Screen = %+v, %v", verdict, err)
+		}
+		id, err := db.AddReferral(ctx, referral, verdict.Limits...)
+		if err != nil {
+			return domain.Referral{}, err
+		}
+		return db.GetReferral(ctx, id)
+	}
+
+	if _, err := add(s, "a"); !errors.Is(err, domain.ErrConflict) || err.Error() != "referral rejected, address exceeded 1 referrals in 60 minutes" {
+		t.Fatalf("referral from a used address = %v", err)
+	}
+	if _, err := add(s, "c"); err != nil {
+		t.Fatalf("referral from a new address = %v", err)
+	}
+	// referrals without a known address only count against the code.
+	if _, err := add(s, ""); !errors.Is(err, domain.ErrConflict) || err.Error() != "referral rejected, referral code exceeded 3 referrals in 60 minutes" {
+		t.Fatalf("referral over the code limit = %v", err)
+	}
+
+	cfg.Velocity.Action = ActionFlag
+	flagging, err := NewScreener(db, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flagged, err := add(flagging, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !flagged.Flagged || flagged.FlagReason != "referral code exceeded 3 referrals in 60 minutes; address exceeded 1 referrals in 60 minutes" {
+		t.Fatalf("referral over the limits = %+v", flagged)
+	}
+
+	cfg.Velocity.PerProgram = Limit{Max: 5}
+	if _, err := NewScreener(db, cfg); err == nil {
+		t.Fatal("NewScreener accepted a limit without a window")
+	}
+}
+
+func TestRiskScore(t *testing.T) {
+	ctx := context.Background()
+	db := repository.NewMemory(zap.NewNop())
+	programId, err := db.AddProgram(ctx, "friends", "Friends", true, "", "", domain.RewardPolicy{}, domain.QualificationRule{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adaId, err := db.AddMember(ctx, "Ada", nil, "ada@example.com", programId, "ada01", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ada, err := db.GetMember(ctx, adaId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.AddReferral(ctx, domain.Referral{ContactEmail: "cy@example.com", ContactPhone: "15550100", ReferralCode: "ada01"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{SelfReferral: ActionOff, DuplicateContact: ActionOff, ExistingMember: ActionOff, Risk: DefaultRiskConfig}
+	cfg.Risk.DisposableDomains = []string{"Junk.example"}
+	s, err := NewScreener(db, cfg, extraRisk{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name    string
+		email   string
+		phone   string
+		score   int64
+		flagged bool
+	}{
+		{"clean", "dee@example.com", "15550199", 5, false},
+		{"same contact", "cy@example.com", "15550100", 5, false},
+		{"disposable", "dee@mailinator.com", "", 75, true},
+		{"configured disposable subdomain", "dee@mx.junk.example", "", 75, true},
+		{"recycled phone", "dee@example.com", "15550100", 45, false},
+		{"both", "dee@yopmail.com", "15550100", MaxRiskScore, true},
+	} {
+		got, err := s.Screen(ctx, domain.Referral{ContactEmail: domain.NormalizeEmail(tt.email), ContactPhone: tt.phone}, ada)
+		if err != nil {
+			t.Fatalf("%s: Screen: %v", tt.name, err)
+		}
+		if got.RiskScore != tt.score || got.Flagged() != tt.flagged {
+			t.Errorf("%s: Screen = %+v, want score %d flagged %v", tt.name, got, tt.score, tt.flagged)
+		}
+	}
+
+	cfg.Risk.FlagScore = -1
+	if _, err := NewScreener(db, cfg); err == nil {
+		t.Fatal("NewScreener accepted a negative flag score")
+	}
+}
+
+// extraRisk is a plugged in scorer adding 5 points to every referral.
+type extraRisk struct{}
+
+func (extraRisk) Score(ctx context.Context, referral domain.Referral, referrer domain.Member) ([]Risk, error) {
+	return []Risk{{5, "extra"}}, nil
+}
@@ -0,0 +1,33 @@
+package fraud
+
+import (
+	"fmt"
+
+	"referral-service/repository"
+
+	"go.uber.org/config"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"fraud",
+	fx.Provide(New),
+)
+
+type Params struct {
+	fx.In
+
+	Cfg     config.Provider
+	Db      repository.Repository
+	Scorers []Scorer `group:"risk_scorers"`
+}
+
+// New provides the screener configured under fraud_checks, DefaultConfig for anything unset.
+// Other modules add risk signals by providing Scorers to the risk_scorers group.
+func New(p Params) (Screener, error) {
+	cfg := DefaultConfig
+	if err := p.Cfg.Get("fraud_checks").Populate(&cfg); err != nil {
+		return nil, fmt.Errorf("fraud_checks config populate %w", err)
+	}
+	return NewScreener(p.Db, cfg, p.Scorers...)
+}
@@ -0,0 +1,126 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"referral-service/domain"
+	"referral-service/repository"
+)
+
+// MaxRiskScore caps the risk score of a referral.
+const MaxRiskScore = 100
+
+// Risk is a reason a referral may be abusive and the points it adds to its risk score.
+type Risk struct {
+	Points int64
+	Reason string
+}
+
+// Scorer rates a new referral like a Screener, returning the risks it finds.
+// Scorers in the risk_scorers fx group run after the built-in one.
+type Scorer interface {
+	Score(ctx context.Context, referral domain.Referral, referrer domain.Member) ([]Risk, error)
+}
+
+// RiskConfig sets the points of the built-in risks and the score referrals are flagged at.
+type RiskConfig struct {
+	// FlagScore flags referrals scoring at least it, 0 never flags.
+	FlagScore int64 `yaml:"flag_score"`
+	// DisposableEmail scores referee emails at a disposable domain.
+	DisposableEmail int64 `yaml:"disposable_email"`
+	// RecycledPhone scores referee phones that were referred before with another email.
+	RecycledPhone int64 `yaml:"recycled_phone"`
+	// DisposableDomains adds to the built-in disposable domains.
+	DisposableDomains []string `yaml:"disposable_domains"`
+}
+
+var DefaultRiskConfig = RiskConfig{
+	FlagScore:       70,
+	DisposableEmail: 70,
+	RecycledPhone:   40,
+}
+
+// disposableDomains are throwaway inbox providers, their subdomains count too.
+var disposableDomains = []string{
+	"10minutemail.com", "10minutemail.net", "burnermail.io", "discard.email",
+	"dispostable.com", "emailondeck.com", "fakeinbox.com", "getnada.com",
+	"grr.la", "guerrillamail.com", "guerrillamail.net", "guerrillamail.org",
+	"inboxkitten.com", "mailcatch.com", "maildrop.cc", "mailinator.com",
+	"mailnesia.com", "mintemail.com", "moakt.com", "mohmal.com",
+	"mytemp.email", "pokemail.net", "sharklasers.com", "spam4.me",
+	"spamgourmet.com", "temp-mail.org", "tempail.com", "tempr.email",
+	"throwawaymail.com", "tmpmail.org", "trashmail.com", "yopmail.com",
+}
+
+type riskScorer struct {
+	db         repository.Repository
+	cfg        RiskConfig
+	disposable map[string]bool
+}
+
+func newRiskScorer(db repository.Repository, cfg RiskConfig) (*riskScorer, error) {
+	if cfg.FlagScore < 0 || cfg.DisposableEmail < 0 || cfg.RecycledPhone < 0 {
+		return nil, fmt.Errorf("invalid risk config %+v", cfg)
+	}
+	r := &riskScorer{db: db, cfg: cfg, disposable: map[string]bool{}}
+	for _, domains := range [][]string{disposableDomains, cfg.DisposableDomains} {
+		for _, d := range domains {
+			r.disposable[strings.ToLower(strings.TrimSpace(d))] = true
+		}
+	}
+	return r, nil
+}
+
+func (r *riskScorer) Score(ctx context.Context, referral domain.Referral, referrer domain.Member) ([]Risk, error) {
+	var risks []Risk
+	if r.cfg.DisposableEmail > 0 && r.isDisposable(referral.ContactEmail) {
+		risks = append(risks, Risk{r.cfg.DisposableEmail, "disposable email domain"})
+	}
+	if r.cfg.RecycledPhone > 0 && referral.ContactPhone != "" {
+		recycled, err := r.recycledPhone(ctx, referral)
+		if err != nil {
+			return nil, err
+		}
+		if recycled {
+			risks = append(risks, Risk{r.cfg.RecycledPhone, "phone was referred before with another email"})
+		}
+	}
+	return risks, nil
+}
+
+// isDisposable reports whether email is at a disposable domain or one of its subdomains.
+func (r *riskScorer) isDisposable(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := email[at+1:]
+	for domain != "" {
+		if r.disposable[domain] {
+			return true
+		}
+		_, domain, _ = strings.Cut(domain, ".")
+	}
+	return false
+}
+
+// recycledPhone reports whether the referee's phone was referred in any program with another email.
+func (r *riskScorer) recycledPhone(ctx context.Context, referral domain.Referral) (bool, error) {
+	byPhone, err := r.db.CountReferrals(ctx, domain.ReferralFilter{ContactPhone: &referral.ContactPhone})
+	if err != nil {
+		return false, fmt.Errorf("recycled phone score %w", err)
+	}
+	if byPhone == 0 {
+		return false, nil
+	}
+	byContact, err := r.db.CountReferrals(ctx, domain.ReferralFilter{
+		ContactPhone: &referral.ContactPhone,
+		ContactEmail: &referral.ContactEmail,
+	})
+	if err != nil {
+		return false, fmt.Errorf("recycled phone score %w", err)
+	}
+	return byPhone > byContact, nil
+}
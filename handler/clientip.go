@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// defaultTrustedProxies trust loopback only, where the gateway forwards http calls to grpc from.
+var defaultTrustedProxies = []string{"127.0.0.0/8", "::1/128"}
+
+// trustedProxies are the networks whose X-Forwarded-For hops are believed. Anyone
+// else could send a made up address in the header.
+type trustedProxies []*net.IPNet
+
+// newTrustedProxies parses addrs, each an address or a network in CIDR notation.
+func newTrustedProxies(addrs []string) (trustedProxies, error) {
+	proxies := make(trustedProxies, 0, len(addrs))
+	for _, addr := range addrs {
+		cidr := addr
+		if !strings.Contains(addr, "/") {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				return nil, fmt.Errorf("trusted proxy %q is not an address or network", addr)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				bits = 32
+			}
+			cidr = fmt.Sprintf("%s/%d", addr, bits)
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q is not an address or network", addr)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+func (p trustedProxies) trusts(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the address of the client behind remote, the address a call came from.
+// Each proxy appends the address it was called from to forwarded, so the hops are walked
+// from the last one for as long as the address they were added by is a trusted proxy.
+func (p trustedProxies) clientIP(remote string, forwarded []string) string {
+	var hops []string
+	for _, header := range forwarded {
+		for _, hop := range strings.Split(header, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	client := remote
+	for i := len(hops) - 1; i >= 0 && hops[i] != "" && p.trusts(client); i-- {
+		client = hops[i]
+	}
+	return client
+}
+
+// requestIP returns the visitor's address of an http request.
+func (p trustedProxies) requestIP(r *http.Request) string {
+	return p.clientIP(hostOnly(r.RemoteAddr), r.Header.Values("X-Forwarded-For"))
+}
+
+// callerIP returns the caller's address of a grpc call. Calls through the gateway come
+// from loopback and carry the http client's address in x-forwarded-for.
+func (p trustedProxies) callerIP(ctx context.Context) string {
+	remote := ""
+	if pr, ok := peer.FromContext(ctx); ok && pr.Addr != nil {
+		remote = hostOnly(pr.Addr.String())
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	return p.clientIP(remote, md.Get("x-forwarded-for"))
+}
+
+// hostOnly drops the port of addr, if it has one.
+func hostOnly(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientIP(t *testing.T) {
+	proxies, err := newTrustedProxies(append(defaultTrustedProxies, "10.0.0.0/8", "192.0.2.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		want      string
+	}{
+		{"direct", "203.0.113.7", nil, "203.0.113.7"},
+		{"spoofed header", "203.0.113.7", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"through a proxy", "10.0.0.2", []string{"203.0.113.7"}, "203.0.113.7"},
+		{"spoofed behind a proxy", "10.0.0.2", []string{"198.51.100.1, 203.0.113.7"}, "203.0.113.7"},
+		{"proxy chain", "127.0.0.1", []string{"203.0.113.7, 10.0.0.3", "192.0.2.1"}, "203.0.113.7"},
+		{"only proxies", "10.0.0.2", []string{"10.0.0.3"}, "10.0.0.3"},
+		{"empty hop", "10.0.0.2", []string{""}, "10.0.0.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := proxies.clientIP(tt.remote, tt.forwarded); got != tt.want {
+				t.Fatalf("clientIP = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := newTrustedProxies([]string{"proxy.internal"}); err == nil {
+		t.Fatal("newTrustedProxies accepted a host name")
+	}
+}
+
+func TestSpoofedForwardedForIgnored(t *testing.T) {
+	proxies, err := newTrustedProxies(defaultTrustedProxies)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest("GET", "/r/abc", nil)
+	r.RemoteAddr = "203.0.113.7:51234"
+	r.Header.Set("X-Forwarded-For", "198.51.100.1")
+	if got := proxies.requestIP(r); got != "203.0.113.7" {
+		t.Fatalf("requestIP = %s, want the remote address", got)
+	}
+
+	md := metadata.Pairs("x-forwarded-for", "198.51.100.1")
+	direct := peer.NewContext(metadata.NewIncomingContext(context.Background(), md),
+		&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 51234}})
+	if got := proxies.callerIP(direct); got != "203.0.113.7" {
+		t.Fatalf("callerIP of a direct call = %s, want the peer address", got)
+	}
+	// the gateway calls from loopback with the http client's address.
+	gateway := peer.NewContext(metadata.NewIncomingContext(context.Background(), md),
+		&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 51234}})
+	if got := proxies.callerIP(gateway); got != "198.51.100.1" {
+		t.Fatalf("callerIP through the gateway = %s, want the forwarded address", got)
+	}
+}
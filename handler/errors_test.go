@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"referral-service/domain"
+)
+
+func TestToStatusError(t *testing.T) {
+	h := &Handlers{log: zap.NewNop()}
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+		msg  string
+	}{
+		{"not found", domain.NewError(domain.ErrNotFound, "member m1 not found"), codes.NotFound, "member m1 not found"},
+		{"already exists", domain.ErrReferralCodeTaken, codes.AlreadyExists, "referral code is already taken"},
+		{"invalid argument", domain.ErrInvalidFilter, codes.InvalidArgument, "invalid filter"},
+		{"conflict", domain.ErrInvalidStatusTransition, codes.FailedPrecondition, "invalid referral status transition"},
+		{"wrapped kind", fmt.Errorf("referral code abc %w", domain.ErrMemberInactive), codes.FailedPrecondition, "referral code abc member is inactive"},
+		{"canceled", fmt.Errorf("member select %w", context.Canceled), codes.Canceled, "member select context canceled"},
+		{"deadline", context.DeadlineExceeded, codes.DeadlineExceeded, "context deadline exceeded"},
+		{"status kept", status.Error(codes.Unauthenticated, "no token"), codes.Unauthenticated, "no token"},
+		// driver errors are not returned to clients.
+		{"unknown", errors.New(`pq: relation "members" does not exist`), codes.Internal, "internal error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := status.FromError(h.toStatusError("/referral.ReferralService/Test", tt.err))
+			if s.Code() != tt.code || s.Message() != tt.msg {
+				t.Fatalf("got %s %q, want %s %q", s.Code(), s.Message(), tt.code, tt.msg)
+			}
+		})
+	}
+}
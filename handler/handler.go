@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 
@@ -19,6 +20,7 @@ import (
 
 	"referral-service/controller"
 	"referral-service/domain"
+	"referral-service/validator"
 
 	pb "referral-service/proto/referral"
 )
@@ -26,33 +28,63 @@ import (
 type Handlers struct {
 	pb.UnimplementedReferralServiceServer
 
-	log         *zap.Logger
-	referralCon controller.ReferralController
-	programCon  controller.ProgramController
-	memberCon   controller.MemberController
-	health      *health.Server
+	log           *zap.Logger
+	referralCon   controller.ReferralController
+	programCon    controller.ProgramController
+	memberCon     controller.MemberController
+	rewardCon     controller.RewardController
+	linkCon       controller.LinkController
+	codeCon       controller.CodeController
+	reviewCon     controller.ReviewController
+	conversionCon controller.ConversionController
+	linkCookie    linkCookie
+	proxies       trustedProxies
+	health        *health.Server
 }
 
 // Params defines constructor requirements.
 type Params struct {
 	fx.In
 
-	Log         *zap.Logger
-	Lc          fx.Lifecycle
-	Cfg         config.Provider
-	ReferralCon controller.ReferralController
-	ProgramCon  controller.ProgramController
-	MemberCon   controller.MemberController
+	Log           *zap.Logger
+	Lc            fx.Lifecycle
+	Cfg           config.Provider
+	ReferralCon   controller.ReferralController
+	ProgramCon    controller.ProgramController
+	MemberCon     controller.MemberController
+	RewardCon     controller.RewardController
+	LinkCon       controller.LinkController
+	CodeCon       controller.CodeController
+	ReviewCon     controller.ReviewController
+	ConversionCon controller.ConversionController
 }
 
 // New is the handler constructor.
 func New(p Params) (*Handlers, error) {
 	h := &Handlers{
-		log:         p.Log,
-		referralCon: p.ReferralCon,
-		programCon:  p.ProgramCon,
-		memberCon:   p.MemberCon,
+		log:           p.Log,
+		referralCon:   p.ReferralCon,
+		programCon:    p.ProgramCon,
+		memberCon:     p.MemberCon,
+		rewardCon:     p.RewardCon,
+		linkCon:       p.LinkCon,
+		codeCon:       p.CodeCon,
+		reviewCon:     p.ReviewCon,
+		conversionCon: p.ConversionCon,
 	}
+	err := p.Cfg.Get("referral_cookie").Populate(&h.linkCookie)
+	if err != nil {
+		return nil, fmt.Errorf("referral_cookie config populate %w", err)
+	}
+	proxies := defaultTrustedProxies
+	if err := p.Cfg.Get("trusted_proxies").Populate(&proxies); err != nil {
+		return nil, fmt.Errorf("trusted_proxies config populate %w", err)
+	}
+	h.proxies, err = newTrustedProxies(proxies)
+	if err != nil {
+		return nil, err
+	}
+
 	ln, err := net.Listen(
 		"tcp",
 		":5000",
@@ -62,7 +94,12 @@ func New(p Params) (*Handlers, error) {
 	}
 
 	// Create grpc server.
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			h.errorInterceptor,
+			validator.UnaryServerInterceptor(),
+		),
+	)
 
 	// Add reflection to service stack.
 	reflection.Register(grpcServer)
@@ -97,6 +134,11 @@ func New(p Params) (*Handlers, error) {
 	if err != nil {
 		return nil, fmt.Errorf("register proxy handler %w", err)
 	}
+	// Shared referral links land here before redirecting to the program.
+	err = gwmux.HandlePath("GET", "/r/{code}", h.redirectReferralLink)
+	if err != nil {
+		return nil, fmt.Errorf("register referral link redirect %w", err)
+	}
 
 	gwServer := &http.Server{
 		Addr:    ":8090",
@@ -148,27 +190,27 @@ func (h *Handlers) GetPrograms(
 	ctx context.Context,
 	req *pb.GetProgramsRequest,
 ) (*pb.GetProgramsResponse, error) {
-	var page = 1
-	if req.Page != nil {
-		page = int(*req.Page)
-	}
-	var size = 100
-	if req.Size != nil {
-		size = int(*req.Size)
+	scope := newListScope(req, fixedOrder, false)
+	page, err := toPage(req.Page, req.Size, req.PageToken, scope)
+	if err != nil {
+		return &pb.GetProgramsResponse{}, err
 	}
 
-	programs, err := h.programCon.GetPrograms(ctx, page, size)
+	programs, err := h.programCon.GetPrograms(ctx, page)
 	if err != nil {
 		return &pb.GetProgramsResponse{}, err
 	}
 
 	protoPrograms := make([]*pb.Program, 0, len(programs))
+	var last domain.Cursor
 	for _, p := range programs {
 		protoPrograms = append(protoPrograms, ToProtoProgram(p))
+		last = domain.Cursor{Key: p.CreatedAt, ID: p.ID}
 	}
 
 	return &pb.GetProgramsResponse{
-		Programs: protoPrograms,
+		Programs:      protoPrograms,
+		NextPageToken: nextPageToken(page, len(programs), last, scope),
 	}, nil
 }
 
@@ -190,7 +232,7 @@ func (h *Handlers) AddProgram(
 	ctx context.Context,
 	req *pb.AddProgramRequest,
 ) (*pb.AddProgramResponse, error) {
-	programId, err := h.programCon.AddProgram(ctx, req.Name, req.Title, req.Active)
+	programId, err := h.programCon.AddProgram(ctx, req.Name, req.Title, req.Active, req.LandingUrl, req.CodePrefix, FromProtoRewardPolicy(req.RewardPolicy), FromProtoQualificationRule(req.Qualification), req.AttributionWindowDays)
 
 	if err != nil {
 		return &pb.AddProgramResponse{}, err
@@ -205,7 +247,17 @@ func (h *Handlers) UpdateProgram(
 	ctx context.Context,
 	req *pb.UpdateProgramRequest,
 ) (*pb.UpdagteProgramResponse, error) {
-	program, err := h.programCon.UpdateProgram(ctx, req.Id, req.Name, req.Title, req.Active)
+	var policy *domain.RewardPolicy
+	if req.RewardPolicy != nil {
+		p := FromProtoRewardPolicy(req.RewardPolicy)
+		policy = &p
+	}
+	var rule *domain.QualificationRule
+	if req.Qualification != nil {
+		r := FromProtoQualificationRule(req.Qualification)
+		rule = &r
+	}
+	program, err := h.programCon.UpdateProgram(ctx, req.Id, req.Name, req.Title, req.Active, req.LandingUrl, req.CodePrefix, policy, rule, req.AttributionWindowDays)
 
 	if err != nil {
 		return &pb.UpdagteProgramResponse{}, err
@@ -224,27 +276,89 @@ func (h *Handlers) GetMembers(
 	ctx context.Context,
 	req *pb.GetMembersRequest,
 ) (*pb.GetMembersResponse, error) {
-	var page = 1
-	if req.Page != nil {
-		page = int(*req.Page)
+	scope := newListScope(req, fixedOrder, false)
+	page, err := toPage(req.Page, req.Size, req.PageToken, scope)
+	if err != nil {
+		return &pb.GetMembersResponse{}, err
 	}
-	var size = 100
-	if req.Size != nil {
-		size = int(*req.Size)
+
+	filter := domain.MemberFilter{
+		ProgramId: req.ProgramId,
+		PersonId:  req.PersonId,
+		Email:     req.Email,
 	}
 
-	members, err := h.memberCon.GetMembers(ctx, page, size)
+	members, err := h.memberCon.GetMembers(ctx, filter, page)
 	if err != nil {
 		return &pb.GetMembersResponse{}, err
 	}
 
 	protoMembers := make([]*pb.Member, 0, len(members))
+	var last domain.Cursor
 	for _, p := range members {
 		protoMembers = append(protoMembers, ToProtoMember(p))
+		last = domain.Cursor{Key: p.CreatedAt, ID: p.ID}
 	}
 
 	return &pb.GetMembersResponse{
-		Members: protoMembers,
+		Members:       protoMembers,
+		NextPageToken: nextPageToken(page, len(members), last, scope),
+	}, nil
+}
+
+func (h *Handlers) GetMember(
+	ctx context.Context,
+	req *pb.GetMemberRequest,
+) (*pb.GetMemberResponse, error) {
+	member, err := h.memberCon.GetMember(ctx, req.Id)
+	if err != nil {
+		return &pb.GetMemberResponse{}, err
+	}
+
+	return &pb.GetMemberResponse{
+		Member: ToProtoMember(*member),
+	}, nil
+}
+
+func (h *Handlers) GetMemberByEmail(
+	ctx context.Context,
+	req *pb.GetMemberByEmailRequest,
+) (*pb.GetMemberByEmailResponse, error) {
+	member, err := h.memberCon.GetMemberByEmail(ctx, req.Email, req.ProgramId)
+	if err != nil {
+		return &pb.GetMemberByEmailResponse{}, err
+	}
+
+	return &pb.GetMemberByEmailResponse{
+		Member: ToProtoMember(*member),
+	}, nil
+}
+
+func (h *Handlers) GetMemberByReferralCode(
+	ctx context.Context,
+	req *pb.GetMemberByReferralCodeRequest,
+) (*pb.GetMemberByReferralCodeResponse, error) {
+	member, err := h.memberCon.GetMemberByReferralCode(ctx, req.ReferralCode)
+	if err != nil {
+		return &pb.GetMemberByReferralCodeResponse{}, err
+	}
+
+	return &pb.GetMemberByReferralCodeResponse{
+		Member: ToProtoMember(*member),
+	}, nil
+}
+
+func (h *Handlers) ClaimVanityCode(
+	ctx context.Context,
+	req *pb.ClaimVanityCodeRequest,
+) (*pb.ClaimVanityCodeResponse, error) {
+	member, err := h.memberCon.ClaimVanityCode(ctx, req.Id, req.ReferralCode)
+	if err != nil {
+		return &pb.ClaimVanityCodeResponse{}, err
+	}
+
+	return &pb.ClaimVanityCodeResponse{
+		Member: ToProtoMember(*member),
 	}, nil
 }
 
@@ -270,6 +384,121 @@ func (h *Handlers) AddMember(
 	}, nil
 }
 
+func (h *Handlers) UpdateMember(
+	ctx context.Context,
+	req *pb.UpdateMemberRequest,
+) (*pb.UpdateMemberResponse, error) {
+	member, err := h.memberCon.UpdateMember(ctx,
+		req.Id,
+		req.FirstName,
+		req.LastName,
+		req.Email,
+		req.ProgramId,
+		req.IsActive,
+	)
+	if err != nil {
+		return &pb.UpdateMemberResponse{}, err
+	}
+
+	return &pb.UpdateMemberResponse{
+		Member: ToProtoMember(*member),
+	}, nil
+}
+
+func (h *Handlers) DeactivateMember(
+	ctx context.Context,
+	req *pb.DeactivateMemberRequest,
+) (*pb.DeactivateMemberResponse, error) {
+	member, err := h.memberCon.DeactivateMember(ctx, req.Id)
+	if err != nil {
+		return &pb.DeactivateMemberResponse{}, err
+	}
+
+	return &pb.DeactivateMemberResponse{
+		Member: ToProtoMember(*member),
+	}, nil
+}
+
+func (h *Handlers) AddReferralCode(
+	ctx context.Context,
+	req *pb.AddReferralCodeRequest,
+) (*pb.AddReferralCodeResponse, error) {
+	code, err := h.codeCon.AddReferralCode(ctx,
+		req.MemberId,
+		req.Code,
+		req.Channel,
+		req.ExpiresAt,
+	)
+	if err != nil {
+		return &pb.AddReferralCodeResponse{}, err
+	}
+
+	return &pb.AddReferralCodeResponse{
+		ReferralCode: ToProtoReferralCode(*code),
+	}, nil
+}
+
+func (h *Handlers) GetReferralCodes(
+	ctx context.Context,
+	req *pb.GetReferralCodesRequest,
+) (*pb.GetReferralCodesResponse, error) {
+	codes, err := h.codeCon.GetReferralCodes(ctx, req.MemberId)
+	if err != nil {
+		return &pb.GetReferralCodesResponse{}, err
+	}
+
+	protoCodes := make([]*pb.ReferralCode, 0, len(codes))
+	for _, code := range codes {
+		protoCodes = append(protoCodes, ToProtoReferralCode(code))
+	}
+	return &pb.GetReferralCodesResponse{
+		ReferralCodes: protoCodes,
+	}, nil
+}
+
+func (h *Handlers) RevokeReferralCode(
+	ctx context.Context,
+	req *pb.RevokeReferralCodeRequest,
+) (*pb.RevokeReferralCodeResponse, error) {
+	code, err := h.codeCon.RevokeReferralCode(ctx, req.MemberId, req.Code)
+	if err != nil {
+		return &pb.RevokeReferralCodeResponse{}, err
+	}
+
+	return &pb.RevokeReferralCodeResponse{
+		ReferralCode: ToProtoReferralCode(*code),
+	}, nil
+}
+
+func (h *Handlers) GenerateReferralLink(
+	ctx context.Context,
+	req *pb.ReferralLinkWrapper,
+) (*pb.GenerateReferralLinkResponse, error) {
+	link, err := h.linkCon.GenerateReferralLink(ctx, req.GetReferrallink().GetEmail(), req.GetReferrallink().ProgramId)
+	if err != nil {
+		return &pb.GenerateReferralLinkResponse{}, err
+	}
+
+	return &pb.GenerateReferralLinkResponse{
+		Url: link,
+	}, nil
+}
+
+func (h *Handlers) GetClickStats(
+	ctx context.Context,
+	req *pb.GetClickStatsRequest,
+) (*pb.GetClickStatsResponse, error) {
+	stats, err := h.linkCon.GetClickStats(ctx, req.MemberId, req.ProgramId)
+	if err != nil {
+		return &pb.GetClickStatsResponse{}, err
+	}
+
+	return &pb.GetClickStatsResponse{
+		Clicks:         stats.Clicks,
+		UniqueVisitors: stats.UniqueVisitors,
+	}, nil
+}
+
 // -------------------------------------------------------------
 // Referral API handlers
 // -------------------------------------------------------------
@@ -277,27 +506,44 @@ func (h *Handlers) GetReferrals(
 	ctx context.Context,
 	req *pb.GetReferralsRequest,
 ) (*pb.GetReferralsResponse, error) {
-	var page = 1
-	if req.Page != nil {
-		page = int(*req.Page)
+	filter := domain.ReferralFilter{
+		ProgramId:     req.ProgramId,
+		MemberId:      req.MemberId,
+		ReferralCode:  req.ReferralCode,
+		Channel:       req.Channel,
+		Status:        req.Status,
+		Flagged:       req.Flagged,
+		Email:         req.Email,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+		SortBy:        req.GetSortBy(),
+		Descending:    req.GetSortOrder() == "desc",
 	}
-	var size = 100
-	if req.Size != nil {
-		size = int(*req.Size)
+	sortBy := filter.SortBy
+	if sortBy == "" {
+		sortBy = domain.ReferralSortCreatedAt
+	}
+	scope := newListScope(req, sortBy, filter.Descending)
+	page, err := toPage(req.Page, req.Size, req.PageToken, scope)
+	if err != nil {
+		return &pb.GetReferralsResponse{}, err
 	}
 
-	referrals, err := h.referralCon.GetReferrals(ctx, page, size)
+	referrals, err := h.referralCon.GetReferrals(ctx, filter, page)
 	if err != nil {
 		return &pb.GetReferralsResponse{}, err
 	}
 
 	protoReferrals := make([]*pb.Referral, 0, len(referrals))
+	var last domain.Cursor
 	for _, r := range referrals {
 		protoReferrals = append(protoReferrals, ToProtoReferral(r))
+		last = domain.Cursor{Key: filter.SortKey(r), ID: r.ID}
 	}
 
 	return &pb.GetReferralsResponse{
-		Referrals: protoReferrals,
+		Referrals:     protoReferrals,
+		NextPageToken: nextPageToken(page, len(referrals), last, scope),
 	}, nil
 }
 
@@ -311,6 +557,7 @@ func (h *Handlers) AddReferral(
 		req.Email,
 		req.Phone,
 		req.ReferralCode,
+		h.proxies.callerIP(ctx),
 	)
 
 	if err != nil {
@@ -322,24 +569,351 @@ func (h *Handlers) AddReferral(
 	}, nil
 }
 
+func (h *Handlers) QualifyReferral(
+	ctx context.Context,
+	req *pb.QualifyReferralRequest,
+) (*pb.QualifyReferralResponse, error) {
+	referral, err := h.referralCon.QualifyReferral(ctx, req.Id, req.ChangedBy, req.Reason)
+	if err != nil {
+		return &pb.QualifyReferralResponse{}, err
+	}
+
+	return &pb.QualifyReferralResponse{
+		Referral: ToProtoReferral(*referral),
+	}, nil
+}
+
+func (h *Handlers) ApproveReferral(
+	ctx context.Context,
+	req *pb.ApproveReferralRequest,
+) (*pb.ApproveReferralResponse, error) {
+	referral, err := h.referralCon.ApproveReferral(ctx, req.Id, req.ChangedBy, req.Reason, req.OrderAmount)
+	if err != nil {
+		return &pb.ApproveReferralResponse{}, err
+	}
+
+	return &pb.ApproveReferralResponse{
+		Referral: ToProtoReferral(*referral),
+	}, nil
+}
+
+func (h *Handlers) DenyReferral(
+	ctx context.Context,
+	req *pb.DenyReferralRequest,
+) (*pb.DenyReferralResponse, error) {
+	referral, err := h.referralCon.DenyReferral(ctx, req.Id, req.ChangedBy, req.Reason)
+	if err != nil {
+		return &pb.DenyReferralResponse{}, err
+	}
+
+	return &pb.DenyReferralResponse{
+		Referral: ToProtoReferral(*referral),
+	}, nil
+}
+
+func (h *Handlers) ReviewReferrals(
+	ctx context.Context,
+	req *pb.ReviewReferralsRequest,
+) (*pb.ReviewReferralsResponse, error) {
+	referrals, err := h.referralCon.ReviewReferrals(ctx, req.Ids, req.Status, req.ChangedBy, req.Reason)
+	if err != nil {
+		return &pb.ReviewReferralsResponse{}, err
+	}
+
+	protoReferrals := make([]*pb.Referral, 0, len(referrals))
+	for _, r := range referrals {
+		protoReferrals = append(protoReferrals, ToProtoReferral(r))
+	}
+
+	return &pb.ReviewReferralsResponse{
+		Referrals: protoReferrals,
+	}, nil
+}
+
+// -------------------------------------------------------------
+// Review API handlers
+// -------------------------------------------------------------
+
+func (h *Handlers) GetReviewQueue(
+	ctx context.Context,
+	req *pb.GetReviewQueueRequest,
+) (*pb.GetReviewQueueResponse, error) {
+	// riskiest first by default, the cursor keys must use the same order.
+	sortBy := req.GetSortBy()
+	if sortBy == "" {
+		sortBy = domain.ReferralSortRiskScore
+	}
+	filter := domain.ReferralFilter{
+		ProgramId:  req.ProgramId,
+		Flagged:    req.Flagged,
+		ClaimedBy:  req.ClaimedBy,
+		SortBy:     sortBy,
+		Descending: req.GetSortOrder() == "desc" || req.SortOrder == nil && sortBy == domain.ReferralSortRiskScore,
+	}
+	scope := newListScope(req, filter.SortBy, filter.Descending)
+	page, err := toPage(req.Page, req.Size, req.PageToken, scope)
+	if err != nil {
+		return &pb.GetReviewQueueResponse{}, err
+	}
+
+	referrals, err := h.reviewCon.GetReviewQueue(ctx, filter, page)
+	if err != nil {
+		return &pb.GetReviewQueueResponse{}, err
+	}
+
+	protoReferrals := make([]*pb.Referral, 0, len(referrals))
+	var last domain.Cursor
+	for _, r := range referrals {
+		protoReferrals = append(protoReferrals, ToProtoReferral(r))
+		last = domain.Cursor{Key: filter.SortKey(r), ID: r.ID}
+	}
+
+	return &pb.GetReviewQueueResponse{
+		Referrals:     protoReferrals,
+		NextPageToken: nextPageToken(page, len(referrals), last, scope),
+	}, nil
+}
+
+func (h *Handlers) ClaimReferral(
+	ctx context.Context,
+	req *pb.ClaimReferralRequest,
+) (*pb.ClaimReferralResponse, error) {
+	referral, err := h.reviewCon.ClaimReferral(ctx, req.Id, req.Reviewer)
+	if err != nil {
+		return &pb.ClaimReferralResponse{}, err
+	}
+
+	return &pb.ClaimReferralResponse{
+		Referral: ToProtoReferral(*referral),
+	}, nil
+}
+
+func (h *Handlers) ReleaseReferral(
+	ctx context.Context,
+	req *pb.ReleaseReferralRequest,
+) (*pb.ReleaseReferralResponse, error) {
+	referral, err := h.reviewCon.ReleaseReferral(ctx, req.Id, req.Reviewer)
+	if err != nil {
+		return &pb.ReleaseReferralResponse{}, err
+	}
+
+	return &pb.ReleaseReferralResponse{
+		Referral: ToProtoReferral(*referral),
+	}, nil
+}
+
+func (h *Handlers) FlagReferral(
+	ctx context.Context,
+	req *pb.FlagReferralRequest,
+) (*pb.FlagReferralResponse, error) {
+	referral, err := h.reviewCon.FlagReferral(ctx, req.Id, req.Flagged, req.Reason)
+	if err != nil {
+		return &pb.FlagReferralResponse{}, err
+	}
+
+	return &pb.FlagReferralResponse{
+		Referral: ToProtoReferral(*referral),
+	}, nil
+}
+
+func (h *Handlers) AddReferralNote(
+	ctx context.Context,
+	req *pb.AddReferralNoteRequest,
+) (*pb.AddReferralNoteResponse, error) {
+	noteId, err := h.reviewCon.AddReferralNote(ctx, req.Id, req.Author, req.Note)
+	if err != nil {
+		return &pb.AddReferralNoteResponse{}, err
+	}
+
+	return &pb.AddReferralNoteResponse{
+		Id: noteId,
+	}, nil
+}
+
+func (h *Handlers) GetReferralNotes(
+	ctx context.Context,
+	req *pb.GetReferralNotesRequest,
+) (*pb.GetReferralNotesResponse, error) {
+	notes, err := h.reviewCon.GetReferralNotes(ctx, req.Id)
+	if err != nil {
+		return &pb.GetReferralNotesResponse{}, err
+	}
+
+	protoNotes := make([]*pb.ReferralNote, 0, len(notes))
+	for _, note := range notes {
+		protoNotes = append(protoNotes, ToProtoReferralNote(note))
+	}
+
+	return &pb.GetReferralNotesResponse{
+		Notes: protoNotes,
+	}, nil
+}
+
+// -------------------------------------------------------------
+// Conversion API handlers
+// -------------------------------------------------------------
+
+func (h *Handlers) IngestConversionEvent(
+	ctx context.Context,
+	req *pb.IngestConversionEventRequest,
+) (*pb.IngestConversionEventResponse, error) {
+	result, err := h.conversionCon.IngestConversionEvent(ctx, FromProtoConversionEvent(req.Event))
+	if err != nil {
+		return &pb.IngestConversionEventResponse{}, err
+	}
+
+	return &pb.IngestConversionEventResponse{
+		Result: ToProtoConversionResult(result),
+	}, nil
+}
+
+func (h *Handlers) IngestConversionEvents(
+	ctx context.Context,
+	req *pb.IngestConversionEventsRequest,
+) (*pb.IngestConversionEventsResponse, error) {
+	conversions := make([]controller.Conversion, 0, len(req.Events))
+	for _, event := range req.Events {
+		conversions = append(conversions, FromProtoConversionEvent(event))
+	}
+
+	results, err := h.conversionCon.IngestConversionEvents(ctx, conversions)
+	if err != nil {
+		return &pb.IngestConversionEventsResponse{}, err
+	}
+
+	protoResults := make([]*pb.ConversionResult, 0, len(results))
+	for _, result := range results {
+		protoResults = append(protoResults, ToProtoConversionResult(result))
+	}
+
+	return &pb.IngestConversionEventsResponse{
+		Results: protoResults,
+	}, nil
+}
+
+// -------------------------------------------------------------
+// Reward API handlers
+// -------------------------------------------------------------
+
+func (h *Handlers) GetRewardBalance(
+	ctx context.Context,
+	req *pb.GetRewardBalanceRequest,
+) (*pb.GetRewardBalanceResponse, error) {
+	balances, err := h.rewardCon.GetRewardBalances(ctx, req.MemberId)
+	if err != nil {
+		return &pb.GetRewardBalanceResponse{}, err
+	}
+
+	protoBalances := make([]*pb.RewardBalance, 0, len(balances))
+	for _, b := range balances {
+		protoBalances = append(protoBalances, &pb.RewardBalance{
+			Currency: b.Currency,
+			Amount:   b.Amount,
+		})
+	}
+
+	return &pb.GetRewardBalanceResponse{
+		MemberId: req.MemberId,
+		Balances: protoBalances,
+	}, nil
+}
+
+func (h *Handlers) GetRewards(
+	ctx context.Context,
+	req *pb.GetRewardsRequest,
+) (*pb.GetRewardsResponse, error) {
+	scope := newListScope(req, fixedOrder, false)
+	page, err := toPage(req.Page, req.Size, req.PageToken, scope)
+	if err != nil {
+		return &pb.GetRewardsResponse{}, err
+	}
+
+	rewards, err := h.rewardCon.GetRewards(ctx, req.MemberId, page)
+	if err != nil {
+		return &pb.GetRewardsResponse{}, err
+	}
+
+	protoRewards := make([]*pb.Reward, 0, len(rewards))
+	var last domain.Cursor
+	for _, r := range rewards {
+		protoRewards = append(protoRewards, ToProtoReward(r))
+		last = domain.Cursor{Key: r.CreatedAt, ID: r.ID}
+	}
+
+	return &pb.GetRewardsResponse{
+		Rewards:       protoRewards,
+		NextPageToken: nextPageToken(page, len(rewards), last, scope),
+	}, nil
+}
+
+func (h *Handlers) ReverseReward(
+	ctx context.Context,
+	req *pb.ReverseRewardRequest,
+) (*pb.ReverseRewardResponse, error) {
+	reward, err := h.rewardCon.ReverseReward(ctx, req.Id, req.ReversedBy, req.Reason)
+	if err != nil {
+		return &pb.ReverseRewardResponse{}, err
+	}
+
+	return &pb.ReverseRewardResponse{
+		Reward: ToProtoReward(*reward),
+	}, nil
+}
+
 // -------------------------------------------------------------
 // DTO transformations
 // -------------------------------------------------------------
 
 func ToProtoProgram(program domain.Program) *pb.Program {
 	return &pb.Program{
-		Id:        program.ID,
-		Name:      program.Name,
-		Title:     program.Title,
-		Active:    program.IsActive,
-		Createdat: program.CreatedAt,
-		Updatedat: program.UpdatedAt,
+		Id:                    program.ID,
+		Name:                  program.Name,
+		Title:                 program.Title,
+		Active:                program.IsActive,
+		LandingUrl:            program.LandingUrl,
+		CodePrefix:            program.CodePrefix,
+		AttributionWindowDays: program.AttributionWindowDays,
+		Createdat:             program.CreatedAt,
+		Updatedat:             program.UpdatedAt,
+		RewardPolicy: &pb.RewardPolicy{
+			Type:                program.RewardType,
+			Currency:            program.RewardCurrency,
+			ReferrerReward:      program.ReferrerReward,
+			RefereeReward:       program.RefereeReward,
+			MaxRewardsPerMember: program.MaxRewardsPerMember,
+			RewardCap:           program.RewardCap,
+		},
+		Qualification: &pb.QualificationRule{
+			Event:          program.QualifyEvent,
+			MinOrderAmount: program.QualifyMinOrder,
+			WithinDays:     program.QualifyWithinDays,
+		},
+	}
+}
+
+func FromProtoQualificationRule(rule *pb.QualificationRule) domain.QualificationRule {
+	return domain.QualificationRule{
+		QualifyEvent:      rule.GetEvent(),
+		QualifyMinOrder:   rule.GetMinOrderAmount(),
+		QualifyWithinDays: rule.GetWithinDays(),
+	}
+}
+
+func FromProtoRewardPolicy(policy *pb.RewardPolicy) domain.RewardPolicy {
+	return domain.RewardPolicy{
+		RewardType:          policy.GetType(),
+		RewardCurrency:      policy.GetCurrency(),
+		ReferrerReward:      policy.GetReferrerReward(),
+		RefereeReward:       policy.GetRefereeReward(),
+		MaxRewardsPerMember: policy.GetMaxRewardsPerMember(),
+		RewardCap:           policy.GetRewardCap(),
 	}
 }
 
 func ToProtoMember(member domain.Member) *pb.Member {
 	return &pb.Member{
 		Id:           member.ID,
+		PersonId:     member.PersonId,
 		FirstName:    member.FirstName,
 		LastName:     member.LastName,
 		Email:        member.Email,
@@ -361,7 +935,79 @@ func ToProtoReferral(referral domain.Referral) *pb.Referral {
 		ReferringMemberId: referral.MemberId,
 		ReferralCode:      referral.ReferralCode,
 		Status:            referral.Status,
+		StatusChangedBy:   referral.StatusChangedBy,
+		StatusReason:      referral.StatusReason,
+		Channel:           referral.Channel,
+		Flagged:           referral.Flagged,
+		FlagReason:        referral.FlagReason,
+		RiskScore:         referral.RiskScore,
+		ClaimedBy:         referral.ClaimedBy,
+		ClaimedAt:         referral.ClaimedAt,
+		OrderAmount:       referral.OrderAmount,
 		CreatedAt:         referral.CreatedAt,
 		UpdatedAt:         referral.UpdatedAt,
 	}
 }
+
+func ToProtoReferralCode(code domain.ReferralCode) *pb.ReferralCode {
+	return &pb.ReferralCode{
+		Code:      code.Code,
+		MemberId:  code.MemberId,
+		Channel:   code.Channel,
+		CreatedAt: code.CreatedAt,
+		ExpiresAt: code.ExpiresAt,
+		RetiredAt: code.RetiredAt,
+		RevokedAt: code.RevokedAt,
+		IsActive:  code.Active(time.Now().UTC().Unix()),
+	}
+}
+
+func ToProtoReward(reward domain.Reward) *pb.Reward {
+	return &pb.Reward{
+		Id:          reward.ID,
+		Beneficiary: reward.Beneficiary,
+		MemberId:    reward.MemberId,
+		ProgramId:   reward.ProgramId,
+		ReferralId:  reward.ReferralId,
+		EntryType:   reward.EntryType,
+		Amount:      reward.Amount,
+		Currency:    reward.Currency,
+		ReversalOf:  reward.ReversalOf,
+		Reason:      reward.Reason,
+		CreatedBy:   reward.CreatedBy,
+		CreatedAt:   reward.CreatedAt,
+	}
+}
+
+func ToProtoReferralNote(note domain.ReferralNote) *pb.ReferralNote {
+	return &pb.ReferralNote{
+		Id:         note.ID,
+		ReferralId: note.ReferralId,
+		Author:     note.Author,
+		Note:       note.Note,
+		CreatedAt:  note.CreatedAt,
+	}
+}
+
+func FromProtoConversionEvent(event *pb.ConversionEvent) controller.Conversion {
+	return controller.Conversion{
+		EventId:          event.GetEventId(),
+		EventType:        event.GetEventType(),
+		AttributionToken: event.GetAttributionToken(),
+		Email:            event.GetEmail(),
+		Phone:            event.GetPhone(),
+		ProgramId:        event.ProgramId,
+		OrderAmount:      event.GetOrderAmount(),
+		OccurredAt:       event.GetOccurredAt(),
+	}
+}
+
+func ToProtoConversionResult(result controller.ConversionResult) *pb.ConversionResult {
+	return &pb.ConversionResult{
+		Id:         result.Event.ID,
+		ReferralId: result.Event.ReferralId,
+		Qualified:  result.Event.Qualified,
+		Reason:     result.Reason,
+		Duplicate:  result.Duplicate,
+	}
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"referral-service/domain"
+)
+
+const (
+	defaultPage     = 1
+	defaultPageSize = 100
+)
+
+// fixedOrder is the sort key of lists that can't be sorted, they are listed oldest first.
+const fixedOrder = "created_at"
+
+// pagingFields are the list request fields that pick a page rather than the rows listed.
+var pagingFields = map[protoreflect.Name]bool{
+	"page":       true,
+	"size":       true,
+	"page_token": true,
+	"sort_by":    true,
+	"sort_order": true,
+}
+
+// listScope is the order and filters of a list. A page token only continues
+// the list it was issued for, as a cursor means nothing in another order.
+type listScope struct {
+	SortBy     string `json:"s"`
+	Descending bool   `json:"d,omitempty"`
+	// Filter hashes the request's fields other than the paging ones.
+	Filter string `json:"f,omitempty"`
+}
+
+// newListScope returns the scope of a list request sorted by sortBy.
+func newListScope(req proto.Message, sortBy string, descending bool) listScope {
+	filter := proto.Clone(req).ProtoReflect()
+	fields := filter.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if pagingFields[fields.Get(i).Name()] {
+			filter.Clear(fields.Get(i))
+		}
+	}
+	b, _ := proto.MarshalOptions{Deterministic: true}.Marshal(filter.Interface())
+	sum := sha256.Sum256(b)
+	return listScope{
+		SortBy:     sortBy,
+		Descending: descending,
+		Filter:     base64.RawURLEncoding.EncodeToString(sum[:8]),
+	}
+}
+
+// pageToken is the cursor of the last row returned and the list it belongs to.
+type pageToken struct {
+	domain.Cursor
+	listScope
+}
+
+// toPage builds the requested page of the list in scope from the list request fields.
+// A page token takes precedence over the page number.
+func toPage(page *int64, size *int64, token *string, scope listScope) (domain.Page, error) {
+	p := domain.Page{Number: defaultPage, Size: defaultPageSize}
+	if page != nil {
+		p.Number = int(*page)
+	}
+	if size != nil {
+		p.Size = int(*size)
+	}
+	if token != nil && *token != "" {
+		decoded, err := decodePageToken(*token)
+		if err != nil {
+			return p, status.Error(codes.InvalidArgument, err.Error())
+		}
+		switch {
+		case decoded.SortBy != scope.SortBy || decoded.Descending != scope.Descending:
+			return p, status.Error(codes.InvalidArgument, "page token was issued for another sort order")
+		case decoded.Filter != scope.Filter:
+			return p, status.Error(codes.InvalidArgument, "page token was issued for other filters")
+		}
+		p.After = &decoded.Cursor
+	}
+	return p, nil
+}
+
+// nextPageToken returns the token for the page after one that returned count rows
+// ending at last, or "" when the list is exhausted.
+func nextPageToken(page domain.Page, count int, last domain.Cursor, scope listScope) string {
+	if count == 0 || count < page.Size {
+		return ""
+	}
+	return encodePageToken(pageToken{Cursor: last, listScope: scope})
+}
+
+// page tokens are opaque to callers, base64 encoded cursors and scopes.
+func encodePageToken(token pageToken) string {
+	b, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageToken(token string) (pageToken, error) {
+	decoded := pageToken{}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return decoded, fmt.Errorf("malformed page token")
+	}
+	if err := json.Unmarshal(b, &decoded); err != nil || decoded.ID == "" {
+		return decoded, fmt.Errorf("malformed page token")
+	}
+	return decoded, nil
+}
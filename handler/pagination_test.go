@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"referral-service/domain"
+	pb "referral-service/proto/referral"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	req := &pb.GetReferralsRequest{ProgramId: proto.String("p1"), Size: proto.Int64(2)}
+	scope := newListScope(req, domain.ReferralSortRiskScore, true)
+	page, err := toPage(req.Page, req.Size, req.PageToken, scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.After != nil {
+		t.Fatalf("first page starts after %+v", page.After)
+	}
+	if token := nextPageToken(page, 1, domain.Cursor{Key: 7, ID: "r1"}, scope); token != "" {
+		t.Fatalf("a short page returned token %q", token)
+	}
+
+	token := nextPageToken(page, 2, domain.Cursor{Key: 7, ID: "r2"}, scope)
+	if token == "" {
+		t.Fatal("a full page returned no token")
+	}
+	// the token continues the same list whatever page number is sent with it.
+	next := proto.Clone(req).(*pb.GetReferralsRequest)
+	next.PageToken = &token
+	next.Page = proto.Int64(5)
+	page, err = toPage(next.Page, next.Size, next.PageToken, newListScope(next, domain.ReferralSortRiskScore, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.After == nil || *page.After != (domain.Cursor{Key: 7, ID: "r2"}) {
+		t.Fatalf("page starts after %+v, want r2", page.After)
+	}
+}
+
+func TestPageTokenMismatch(t *testing.T) {
+	req := &pb.GetReferralsRequest{ProgramId: proto.String("p1"), Size: proto.Int64(2)}
+	token := nextPageToken(domain.Page{Size: 2}, 2, domain.Cursor{Key: 7, ID: "r2"},
+		newListScope(req, domain.ReferralSortCreatedAt, false))
+
+	tests := []struct {
+		name       string
+		req        *pb.GetReferralsRequest
+		sortBy     string
+		descending bool
+	}{
+		{"sort", req, domain.ReferralSortUpdatedAt, false},
+		{"order", req, domain.ReferralSortCreatedAt, true},
+		{"filter", &pb.GetReferralsRequest{ProgramId: proto.String("p2"), Size: proto.Int64(2)}, domain.ReferralSortCreatedAt, false},
+		{"added filter", &pb.GetReferralsRequest{ProgramId: proto.String("p1"), Status: proto.String("pending"), Size: proto.Int64(2)}, domain.ReferralSortCreatedAt, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := toPage(nil, tt.req.Size, &token, newListScope(tt.req, tt.sortBy, tt.descending))
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("err = %v, want InvalidArgument", err)
+			}
+		})
+	}
+
+	malformed := "not a token"
+	if _, err := toPage(nil, nil, &malformed, listScope{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("malformed token err = %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"referral-service/controller"
+	"referral-service/domain"
+)
+
+// linkCookie configures the attribution cookie set when a referral link is followed.
+type linkCookie struct {
+	Name       string `yaml:"name"`
+	MaxAgeDays int    `yaml:"max_age_days"`
+}
+
+// redirectReferralLink serves GET /r/{code}: it records the click, sets the
+// attribution cookie and redirects the visitor to the program's landing page.
+func (h *Handlers) redirectReferralLink(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	code := pathParams["code"]
+	landingUrl, err := h.linkCon.FollowReferralLink(r.Context(), code, controller.LinkVisit{
+		UserAgent: r.UserAgent(),
+		Referer:   r.Referer(),
+		IP:        h.proxies.requestIP(r),
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	// revoked and expired codes, and codes of inactive members, no longer lead anywhere.
+	if errors.Is(err, domain.ErrConflict) {
+		http.Error(w, http.StatusText(http.StatusGone), http.StatusGone)
+		return
+	}
+	if err != nil {
+		h.log.Error("follow referral link", zap.String("referral_code", code), zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     h.linkCookie.Name,
+		Value:    code,
+		Path:     "/",
+		MaxAge:   h.linkCookie.MaxAgeDays * 24 * 60 * 60,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, landingUrl, http.StatusFound)
+}
@@ -1,19 +1,32 @@
 package main
 
 import (
+	"os"
+
 	"referral-service/app"
 	"referral-service/controller"
+	"referral-service/expiry"
+	"referral-service/fraud"
 	"referral-service/handler"
+	"referral-service/referralcode"
 	"referral-service/repository"
 
 	"go.uber.org/fx"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrateCommand(os.Args[2:])
+		return
+	}
+
 	fx.New(
-		app.Module,        // provide gateways.
-		repository.Module, // provide reposity interface.
-		controller.Module, // provide controller interface.
-		handler.Module,    // wire up to handlers.
+		app.Module,          // provide gateways.
+		repository.Module,   // provide reposity interface.
+		referralcode.Module, // provide referral code generator.
+		fraud.Module,        // provide referral fraud screening.
+		controller.Module,   // provide controller interface.
+		handler.Module,      // wire up to handlers.
+		expiry.Module,       // schedule the referral expiry job.
 	).Run()
 }
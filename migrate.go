@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"referral-service/app"
+	"referral-service/repository"
+	"referral-service/repository/migrate"
+
+	"go.uber.org/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const migrateUsage = "usage: referral-service migrate up | down [steps] | status"
+
+// migrateCommand runs the migrate subcommand against the configured postgres database and exits.
+func migrateCommand(args []string) {
+	err := fx.New(
+		app.Module,
+		fx.NopLogger,
+		fx.Invoke(func(log *zap.Logger, cfg config.Provider) error {
+			return runMigrate(context.Background(), log, cfg, args, os.Stdout)
+		}),
+	).Err()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func runMigrate(ctx context.Context, log *zap.Logger, cfg config.Provider, args []string, out io.Writer) error {
+	steps := 1
+	switch {
+	case len(args) == 1 && (args[0] == "up" || args[0] == "down" || args[0] == "status"):
+	case len(args) == 2 && args[0] == "down":
+		var err error
+		steps, err = strconv.Atoi(args[1])
+		if err != nil || steps < 1 {
+			return fmt.Errorf("steps must be a positive number, %s", migrateUsage)
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	db, err := repository.OpenPostgres(log, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	migrator, err := migrate.New(log, db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printMigrations(out, "applied", applied)
+		return err
+	case "down":
+		rolledBack, err := migrator.Down(ctx, steps)
+		printMigrations(out, "rolled back", rolledBack)
+		return err
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != 0 {
+			appliedAt = time.Unix(status.AppliedAt, 0).UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	return w.Flush()
+}
+
+func printMigrations(out io.Writer, done string, migrations []migrate.Migration) {
+	if len(migrations) == 0 {
+		fmt.Fprintln(out, "nothing to do")
+	}
+	for _, migration := range migrations {
+		fmt.Fprintf(out, "%s %04d_%s\n", done, migration.Version, migration.Name)
+	}
+}
@@ -24,8 +24,10 @@ const (
 )
 
 type GenerateReferralLinkRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Email string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// required when the email is enrolled in several programs.
+	ProgramId     *string `protobuf:"bytes,2,opt,name=program_id,json=programId,proto3,oneof" json:"program_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -67,6 +69,13 @@ func (x *GenerateReferralLinkRequest) GetEmail() string {
 	return ""
 }
 
+func (x *GenerateReferralLinkRequest) GetProgramId() string {
+	if x != nil && x.ProgramId != nil {
+		return *x.ProgramId
+	}
+	return ""
+}
+
 type GenerateReferralLinkResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
@@ -155,21 +164,280 @@ func (x *ReferralLinkWrapper) GetReferrallink() *GenerateReferralLinkRequest {
 	return nil
 }
 
-type Program struct {
+type GetClickStatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Active        bool                   `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
-	Createdat     int64                  `protobuf:"varint,5,opt,name=createdat,proto3" json:"createdat,omitempty"`
-	Updatedat     int64                  `protobuf:"varint,6,opt,name=updatedat,proto3" json:"updatedat,omitempty"`
+	MemberId      *string                `protobuf:"bytes,1,opt,name=member_id,json=memberId,proto3,oneof" json:"member_id,omitempty"`
+	ProgramId     *string                `protobuf:"bytes,2,opt,name=program_id,json=programId,proto3,oneof" json:"program_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *GetClickStatsRequest) Reset() {
+	*x = GetClickStatsRequest{}
+	mi := &file_referral_referral_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClickStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClickStatsRequest) ProtoMessage() {}
+
+func (x *GetClickStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClickStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetClickStatsRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetClickStatsRequest) GetMemberId() string {
+	if x != nil && x.MemberId != nil {
+		return *x.MemberId
+	}
+	return ""
+}
+
+func (x *GetClickStatsRequest) GetProgramId() string {
+	if x != nil && x.ProgramId != nil {
+		return *x.ProgramId
+	}
+	return ""
+}
+
+type GetClickStatsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Clicks         int64                  `protobuf:"varint,1,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	UniqueVisitors int64                  `protobuf:"varint,2,opt,name=unique_visitors,json=uniqueVisitors,proto3" json:"unique_visitors,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetClickStatsResponse) Reset() {
+	*x = GetClickStatsResponse{}
+	mi := &file_referral_referral_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClickStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClickStatsResponse) ProtoMessage() {}
+
+func (x *GetClickStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClickStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetClickStatsResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetClickStatsResponse) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *GetClickStatsResponse) GetUniqueVisitors() int64 {
+	if x != nil {
+		return x.UniqueVisitors
+	}
+	return 0
+}
+
+// RewardPolicy configures what a program pays out on approval.
+// type is "fixed" (amounts in the currency's minor unit) or "percentage"
+// (basis points of the order amount, at most 10000). Zero limits mean unlimited.
+type RewardPolicy struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Type                string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Currency            string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	ReferrerReward      int64                  `protobuf:"varint,3,opt,name=referrer_reward,json=referrerReward,proto3" json:"referrer_reward,omitempty"`
+	RefereeReward       int64                  `protobuf:"varint,4,opt,name=referee_reward,json=refereeReward,proto3" json:"referee_reward,omitempty"`
+	MaxRewardsPerMember int64                  `protobuf:"varint,5,opt,name=max_rewards_per_member,json=maxRewardsPerMember,proto3" json:"max_rewards_per_member,omitempty"`
+	RewardCap           int64                  `protobuf:"varint,6,opt,name=reward_cap,json=rewardCap,proto3" json:"reward_cap,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *RewardPolicy) Reset() {
+	*x = RewardPolicy{}
+	mi := &file_referral_referral_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewardPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardPolicy) ProtoMessage() {}
+
+func (x *RewardPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardPolicy.ProtoReflect.Descriptor instead.
+func (*RewardPolicy) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RewardPolicy) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RewardPolicy) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RewardPolicy) GetReferrerReward() int64 {
+	if x != nil {
+		return x.ReferrerReward
+	}
+	return 0
+}
+
+func (x *RewardPolicy) GetRefereeReward() int64 {
+	if x != nil {
+		return x.RefereeReward
+	}
+	return 0
+}
+
+func (x *RewardPolicy) GetMaxRewardsPerMember() int64 {
+	if x != nil {
+		return x.MaxRewardsPerMember
+	}
+	return 0
+}
+
+func (x *RewardPolicy) GetRewardCap() int64 {
+	if x != nil {
+		return x.RewardCap
+	}
+	return 0
+}
+
+// QualificationRule says which conversion events qualify a program's referrals.
+// event is the event type, min_order_amount is in the currency's minor unit and
+// within_days counts from the referral, zero limits mean unlimited.
+type QualificationRule struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Event          string                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	MinOrderAmount int64                  `protobuf:"varint,2,opt,name=min_order_amount,json=minOrderAmount,proto3" json:"min_order_amount,omitempty"`
+	WithinDays     int64                  `protobuf:"varint,3,opt,name=within_days,json=withinDays,proto3" json:"within_days,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *QualificationRule) Reset() {
+	*x = QualificationRule{}
+	mi := &file_referral_referral_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QualificationRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QualificationRule) ProtoMessage() {}
+
+func (x *QualificationRule) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QualificationRule.ProtoReflect.Descriptor instead.
+func (*QualificationRule) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *QualificationRule) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *QualificationRule) GetMinOrderAmount() int64 {
+	if x != nil {
+		return x.MinOrderAmount
+	}
+	return 0
+}
+
+func (x *QualificationRule) GetWithinDays() int64 {
+	if x != nil {
+		return x.WithinDays
+	}
+	return 0
+}
+
+type Program struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Title                 string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Active                bool                   `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
+	Createdat             int64                  `protobuf:"varint,5,opt,name=createdat,proto3" json:"createdat,omitempty"`
+	Updatedat             int64                  `protobuf:"varint,6,opt,name=updatedat,proto3" json:"updatedat,omitempty"`
+	RewardPolicy          *RewardPolicy          `protobuf:"bytes,7,opt,name=reward_policy,json=rewardPolicy,proto3" json:"reward_policy,omitempty"`
+	LandingUrl            string                 `protobuf:"bytes,8,opt,name=landing_url,json=landingUrl,proto3" json:"landing_url,omitempty"`
+	CodePrefix            string                 `protobuf:"bytes,9,opt,name=code_prefix,json=codePrefix,proto3" json:"code_prefix,omitempty"`
+	Qualification         *QualificationRule     `protobuf:"bytes,10,opt,name=qualification,proto3" json:"qualification,omitempty"`
+	AttributionWindowDays int64                  `protobuf:"varint,11,opt,name=attribution_window_days,json=attributionWindowDays,proto3" json:"attribution_window_days,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
 func (x *Program) Reset() {
 	*x = Program{}
-	mi := &file_referral_referral_proto_msgTypes[3]
+	mi := &file_referral_referral_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -181,7 +449,7 @@ func (x *Program) String() string {
 func (*Program) ProtoMessage() {}
 
 func (x *Program) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[3]
+	mi := &file_referral_referral_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -194,7 +462,7 @@ func (x *Program) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Program.ProtoReflect.Descriptor instead.
 func (*Program) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{3}
+	return file_referral_referral_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Program) GetId() string {
@@ -239,18 +507,62 @@ func (x *Program) GetUpdatedat() int64 {
 	return 0
 }
 
+func (x *Program) GetRewardPolicy() *RewardPolicy {
+	if x != nil {
+		return x.RewardPolicy
+	}
+	return nil
+}
+
+func (x *Program) GetLandingUrl() string {
+	if x != nil {
+		return x.LandingUrl
+	}
+	return ""
+}
+
+func (x *Program) GetCodePrefix() string {
+	if x != nil {
+		return x.CodePrefix
+	}
+	return ""
+}
+
+func (x *Program) GetQualification() *QualificationRule {
+	if x != nil {
+		return x.Qualification
+	}
+	return nil
+}
+
+func (x *Program) GetAttributionWindowDays() int64 {
+	if x != nil {
+		return x.AttributionWindowDays
+	}
+	return 0
+}
+
 type AddProgramRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Active        bool                   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Name         string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title        string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Active       bool                   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	RewardPolicy *RewardPolicy          `protobuf:"bytes,4,opt,name=reward_policy,json=rewardPolicy,proto3" json:"reward_policy,omitempty"`
+	// where followed referral links redirect to, may contain {referral_code} and {program_id}.
+	LandingUrl string `protobuf:"bytes,5,opt,name=landing_url,json=landingUrl,proto3" json:"landing_url,omitempty"`
+	// starts the referral codes generated for the program's members, lowercase letters and digits.
+	CodePrefix string `protobuf:"bytes,6,opt,name=code_prefix,json=codePrefix,proto3" json:"code_prefix,omitempty"`
+	// referrals qualify on conversion events meeting it, none are without.
+	Qualification *QualificationRule `protobuf:"bytes,7,opt,name=qualification,proto3" json:"qualification,omitempty"`
+	// days referrals stay pending before they expire, 0 never expires them.
+	AttributionWindowDays int64 `protobuf:"varint,8,opt,name=attribution_window_days,json=attributionWindowDays,proto3" json:"attribution_window_days,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *AddProgramRequest) Reset() {
 	*x = AddProgramRequest{}
-	mi := &file_referral_referral_proto_msgTypes[4]
+	mi := &file_referral_referral_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -262,7 +574,7 @@ func (x *AddProgramRequest) String() string {
 func (*AddProgramRequest) ProtoMessage() {}
 
 func (x *AddProgramRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[4]
+	mi := &file_referral_referral_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -275,7 +587,7 @@ func (x *AddProgramRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddProgramRequest.ProtoReflect.Descriptor instead.
 func (*AddProgramRequest) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{4}
+	return file_referral_referral_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AddProgramRequest) GetName() string {
@@ -299,6 +611,41 @@ func (x *AddProgramRequest) GetActive() bool {
 	return false
 }
 
+func (x *AddProgramRequest) GetRewardPolicy() *RewardPolicy {
+	if x != nil {
+		return x.RewardPolicy
+	}
+	return nil
+}
+
+func (x *AddProgramRequest) GetLandingUrl() string {
+	if x != nil {
+		return x.LandingUrl
+	}
+	return ""
+}
+
+func (x *AddProgramRequest) GetCodePrefix() string {
+	if x != nil {
+		return x.CodePrefix
+	}
+	return ""
+}
+
+func (x *AddProgramRequest) GetQualification() *QualificationRule {
+	if x != nil {
+		return x.Qualification
+	}
+	return nil
+}
+
+func (x *AddProgramRequest) GetAttributionWindowDays() int64 {
+	if x != nil {
+		return x.AttributionWindowDays
+	}
+	return 0
+}
+
 type AddProgramResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -308,7 +655,7 @@ type AddProgramResponse struct {
 
 func (x *AddProgramResponse) Reset() {
 	*x = AddProgramResponse{}
-	mi := &file_referral_referral_proto_msgTypes[5]
+	mi := &file_referral_referral_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -320,7 +667,7 @@ func (x *AddProgramResponse) String() string {
 func (*AddProgramResponse) ProtoMessage() {}
 
 func (x *AddProgramResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[5]
+	mi := &file_referral_referral_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -333,7 +680,7 @@ func (x *AddProgramResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddProgramResponse.ProtoReflect.Descriptor instead.
 func (*AddProgramResponse) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{5}
+	return file_referral_referral_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AddProgramResponse) GetId() string {
@@ -344,18 +691,25 @@ func (x *AddProgramResponse) GetId() string {
 }
 
 type UpdateProgramRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
-	Title         *string                `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
-	Active        *bool                  `protobuf:"varint,4,opt,name=active,proto3,oneof" json:"active,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name   *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Title  *string                `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Active *bool                  `protobuf:"varint,4,opt,name=active,proto3,oneof" json:"active,omitempty"`
+	// replaces the program's reward policy when set.
+	RewardPolicy *RewardPolicy `protobuf:"bytes,5,opt,name=reward_policy,json=rewardPolicy,proto3" json:"reward_policy,omitempty"`
+	LandingUrl   *string       `protobuf:"bytes,6,opt,name=landing_url,json=landingUrl,proto3,oneof" json:"landing_url,omitempty"`
+	CodePrefix   *string       `protobuf:"bytes,7,opt,name=code_prefix,json=codePrefix,proto3,oneof" json:"code_prefix,omitempty"`
+	// replaces the program's qualification rule when set.
+	Qualification         *QualificationRule `protobuf:"bytes,8,opt,name=qualification,proto3" json:"qualification,omitempty"`
+	AttributionWindowDays *int64             `protobuf:"varint,9,opt,name=attribution_window_days,json=attributionWindowDays,proto3,oneof" json:"attribution_window_days,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *UpdateProgramRequest) Reset() {
 	*x = UpdateProgramRequest{}
-	mi := &file_referral_referral_proto_msgTypes[6]
+	mi := &file_referral_referral_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -367,7 +721,7 @@ func (x *UpdateProgramRequest) String() string {
 func (*UpdateProgramRequest) ProtoMessage() {}
 
 func (x *UpdateProgramRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[6]
+	mi := &file_referral_referral_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -380,7 +734,7 @@ func (x *UpdateProgramRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProgramRequest.ProtoReflect.Descriptor instead.
 func (*UpdateProgramRequest) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{6}
+	return file_referral_referral_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateProgramRequest) GetId() string {
@@ -411,16 +765,51 @@ func (x *UpdateProgramRequest) GetActive() bool {
 	return false
 }
 
-type UpdagteProgramResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Program       *Program               `protobuf:"bytes,1,opt,name=program,proto3" json:"program,omitempty"`
-	unknownFields protoimpl.UnknownFields
+func (x *UpdateProgramRequest) GetRewardPolicy() *RewardPolicy {
+	if x != nil {
+		return x.RewardPolicy
+	}
+	return nil
+}
+
+func (x *UpdateProgramRequest) GetLandingUrl() string {
+	if x != nil && x.LandingUrl != nil {
+		return *x.LandingUrl
+	}
+	return ""
+}
+
+func (x *UpdateProgramRequest) GetCodePrefix() string {
+	if x != nil && x.CodePrefix != nil {
+		return *x.CodePrefix
+	}
+	return ""
+}
+
+func (x *UpdateProgramRequest) GetQualification() *QualificationRule {
+	if x != nil {
+		return x.Qualification
+	}
+	return nil
+}
+
+func (x *UpdateProgramRequest) GetAttributionWindowDays() int64 {
+	if x != nil && x.AttributionWindowDays != nil {
+		return *x.AttributionWindowDays
+	}
+	return 0
+}
+
+type UpdagteProgramResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Program       *Program               `protobuf:"bytes,1,opt,name=program,proto3" json:"program,omitempty"`
+	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdagteProgramResponse) Reset() {
 	*x = UpdagteProgramResponse{}
-	mi := &file_referral_referral_proto_msgTypes[7]
+	mi := &file_referral_referral_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -432,7 +821,7 @@ func (x *UpdagteProgramResponse) String() string {
 func (*UpdagteProgramResponse) ProtoMessage() {}
 
 func (x *UpdagteProgramResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[7]
+	mi := &file_referral_referral_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -445,7 +834,7 @@ func (x *UpdagteProgramResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdagteProgramResponse.ProtoReflect.Descriptor instead.
 func (*UpdagteProgramResponse) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{7}
+	return file_referral_referral_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdagteProgramResponse) GetProgram() *Program {
@@ -456,16 +845,18 @@ func (x *UpdagteProgramResponse) GetProgram() *Program {
 }
 
 type GetProgramsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          *int64                 `protobuf:"varint,1,opt,name=page,proto3,oneof" json:"page,omitempty"`
-	Size          *int64                 `protobuf:"varint,2,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  *int64                 `protobuf:"varint,1,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	Size  *int64                 `protobuf:"varint,2,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	// next_page_token of the previous response, takes precedence over page.
+	PageToken     *string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetProgramsRequest) Reset() {
 	*x = GetProgramsRequest{}
-	mi := &file_referral_referral_proto_msgTypes[8]
+	mi := &file_referral_referral_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -477,7 +868,7 @@ func (x *GetProgramsRequest) String() string {
 func (*GetProgramsRequest) ProtoMessage() {}
 
 func (x *GetProgramsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[8]
+	mi := &file_referral_referral_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -490,7 +881,7 @@ func (x *GetProgramsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProgramsRequest.ProtoReflect.Descriptor instead.
 func (*GetProgramsRequest) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{8}
+	return file_referral_referral_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetProgramsRequest) GetPage() int64 {
@@ -507,16 +898,25 @@ func (x *GetProgramsRequest) GetSize() int64 {
 	return 0
 }
 
+func (x *GetProgramsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
 type GetProgramsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Programs      []*Program             `protobuf:"bytes,1,rep,name=programs,proto3" json:"programs,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Programs []*Program             `protobuf:"bytes,1,rep,name=programs,proto3" json:"programs,omitempty"`
+	// empty when there are no more programs.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetProgramsResponse) Reset() {
 	*x = GetProgramsResponse{}
-	mi := &file_referral_referral_proto_msgTypes[9]
+	mi := &file_referral_referral_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -528,7 +928,7 @@ func (x *GetProgramsResponse) String() string {
 func (*GetProgramsResponse) ProtoMessage() {}
 
 func (x *GetProgramsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[9]
+	mi := &file_referral_referral_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,7 +941,7 @@ func (x *GetProgramsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProgramsResponse.ProtoReflect.Descriptor instead.
 func (*GetProgramsResponse) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{9}
+	return file_referral_referral_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetProgramsResponse) GetPrograms() []*Program {
@@ -551,6 +951,13 @@ func (x *GetProgramsResponse) GetPrograms() []*Program {
 	return nil
 }
 
+func (x *GetProgramsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type GetProgramRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -560,7 +967,7 @@ type GetProgramRequest struct {
 
 func (x *GetProgramRequest) Reset() {
 	*x = GetProgramRequest{}
-	mi := &file_referral_referral_proto_msgTypes[10]
+	mi := &file_referral_referral_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -572,7 +979,7 @@ func (x *GetProgramRequest) String() string {
 func (*GetProgramRequest) ProtoMessage() {}
 
 func (x *GetProgramRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[10]
+	mi := &file_referral_referral_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -585,7 +992,7 @@ func (x *GetProgramRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProgramRequest.ProtoReflect.Descriptor instead.
 func (*GetProgramRequest) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{10}
+	return file_referral_referral_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetProgramRequest) GetId() string {
@@ -604,7 +1011,7 @@ type GetProgramResponse struct {
 
 func (x *GetProgramResponse) Reset() {
 	*x = GetProgramResponse{}
-	mi := &file_referral_referral_proto_msgTypes[11]
+	mi := &file_referral_referral_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -616,7 +1023,7 @@ func (x *GetProgramResponse) String() string {
 func (*GetProgramResponse) ProtoMessage() {}
 
 func (x *GetProgramResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[11]
+	mi := &file_referral_referral_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -629,7 +1036,7 @@ func (x *GetProgramResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProgramResponse.ProtoReflect.Descriptor instead.
 func (*GetProgramResponse) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{11}
+	return file_referral_referral_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetProgramResponse) GetProgram() *Program {
@@ -641,23 +1048,25 @@ func (x *GetProgramResponse) GetProgram() *Program {
 
 // member
 type Member struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
-	ProgramId     string                 `protobuf:"bytes,5,opt,name=program_id,json=programId,proto3" json:"program_id,omitempty"`
-	ReferralCode  string                 `protobuf:"bytes,6,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
-	IsActive      bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     int64                  `protobuf:"varint,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName    string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName     string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Email        string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	ProgramId    string                 `protobuf:"bytes,5,opt,name=program_id,json=programId,proto3" json:"program_id,omitempty"`
+	ReferralCode string                 `protobuf:"bytes,6,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
+	IsActive     bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	CreatedAt    int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt    int64                  `protobuf:"varint,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// the person enrolled, shared by their enrolments in other programs.
+	PersonId      string `protobuf:"bytes,10,opt,name=person_id,json=personId,proto3" json:"person_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Member) Reset() {
 	*x = Member{}
-	mi := &file_referral_referral_proto_msgTypes[12]
+	mi := &file_referral_referral_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -669,7 +1078,7 @@ func (x *Member) String() string {
 func (*Member) ProtoMessage() {}
 
 func (x *Member) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[12]
+	mi := &file_referral_referral_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -682,7 +1091,7 @@ func (x *Member) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Member.ProtoReflect.Descriptor instead.
 func (*Member) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{12}
+	return file_referral_referral_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Member) GetId() string {
@@ -748,17 +1157,29 @@ func (x *Member) GetUpdatedAt() int64 {
 	return 0
 }
 
+func (x *Member) GetPersonId() string {
+	if x != nil {
+		return x.PersonId
+	}
+	return ""
+}
+
 type GetMembersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          *int64                 `protobuf:"varint,1,opt,name=page,proto3,oneof" json:"page,omitempty"`
-	Size          *int64                 `protobuf:"varint,2,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  *int64                 `protobuf:"varint,1,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	Size  *int64                 `protobuf:"varint,2,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	// next_page_token of the previous response, takes precedence over page.
+	PageToken     *string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	ProgramId     *string `protobuf:"bytes,4,opt,name=program_id,json=programId,proto3,oneof" json:"program_id,omitempty"`
+	PersonId      *string `protobuf:"bytes,5,opt,name=person_id,json=personId,proto3,oneof" json:"person_id,omitempty"`
+	Email         *string `protobuf:"bytes,6,opt,name=email,proto3,oneof" json:"email,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetMembersRequest) Reset() {
 	*x = GetMembersRequest{}
-	mi := &file_referral_referral_proto_msgTypes[13]
+	mi := &file_referral_referral_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -770,7 +1191,7 @@ func (x *GetMembersRequest) String() string {
 func (*GetMembersRequest) ProtoMessage() {}
 
 func (x *GetMembersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[13]
+	mi := &file_referral_referral_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -783,7 +1204,7 @@ func (x *GetMembersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMembersRequest.ProtoReflect.Descriptor instead.
 func (*GetMembersRequest) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{13}
+	return file_referral_referral_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetMembersRequest) GetPage() int64 {
@@ -800,16 +1221,46 @@ func (x *GetMembersRequest) GetSize() int64 {
 	return 0
 }
 
+func (x *GetMembersRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+func (x *GetMembersRequest) GetProgramId() string {
+	if x != nil && x.ProgramId != nil {
+		return *x.ProgramId
+	}
+	return ""
+}
+
+func (x *GetMembersRequest) GetPersonId() string {
+	if x != nil && x.PersonId != nil {
+		return *x.PersonId
+	}
+	return ""
+}
+
+func (x *GetMembersRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
 type GetMembersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Members       []*Member              `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Members []*Member              `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	// empty when there are no more members.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetMembersResponse) Reset() {
 	*x = GetMembersResponse{}
-	mi := &file_referral_referral_proto_msgTypes[14]
+	mi := &file_referral_referral_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -821,7 +1272,7 @@ func (x *GetMembersResponse) String() string {
 func (*GetMembersResponse) ProtoMessage() {}
 
 func (x *GetMembersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[14]
+	mi := &file_referral_referral_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -834,7 +1285,7 @@ func (x *GetMembersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMembersResponse.ProtoReflect.Descriptor instead.
 func (*GetMembersResponse) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{14}
+	return file_referral_referral_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetMembersResponse) GetMembers() []*Member {
@@ -844,33 +1295,41 @@ func (x *GetMembersResponse) GetMembers() []*Member {
 	return nil
 }
 
-type AddMemberRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FirstName     string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName      *string                `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3,oneof" json:"last_name,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	ReferralCode  *string                `protobuf:"bytes,4,opt,name=referral_code,json=referralCode,proto3,oneof" json:"referral_code,omitempty"`
-	ProgramId     string                 `protobuf:"bytes,5,opt,name=program_id,json=programId,proto3" json:"program_id,omitempty"`
-	IsActive      *bool                  `protobuf:"varint,6,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+func (x *GetMembersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateMemberRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName *string                `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3,oneof" json:"first_name,omitempty"`
+	LastName  *string                `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3,oneof" json:"last_name,omitempty"`
+	Email     *string                `protobuf:"bytes,4,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	// must be the member's program, members can't move to another one.
+	ProgramId     *string `protobuf:"bytes,5,opt,name=program_id,json=programId,proto3,oneof" json:"program_id,omitempty"`
+	IsActive      *bool   `protobuf:"varint,6,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddMemberRequest) Reset() {
-	*x = AddMemberRequest{}
-	mi := &file_referral_referral_proto_msgTypes[15]
+func (x *UpdateMemberRequest) Reset() {
+	*x = UpdateMemberRequest{}
+	mi := &file_referral_referral_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddMemberRequest) String() string {
+func (x *UpdateMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddMemberRequest) ProtoMessage() {}
+func (*UpdateMemberRequest) ProtoMessage() {}
 
-func (x *AddMemberRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[15]
+func (x *UpdateMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -881,75 +1340,75 @@ func (x *AddMemberRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddMemberRequest.ProtoReflect.Descriptor instead.
-func (*AddMemberRequest) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use UpdateMemberRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMemberRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *AddMemberRequest) GetFirstName() string {
+func (x *UpdateMemberRequest) GetId() string {
 	if x != nil {
-		return x.FirstName
+		return x.Id
 	}
 	return ""
 }
 
-func (x *AddMemberRequest) GetLastName() string {
-	if x != nil && x.LastName != nil {
-		return *x.LastName
+func (x *UpdateMemberRequest) GetFirstName() string {
+	if x != nil && x.FirstName != nil {
+		return *x.FirstName
 	}
 	return ""
 }
 
-func (x *AddMemberRequest) GetEmail() string {
-	if x != nil {
-		return x.Email
+func (x *UpdateMemberRequest) GetLastName() string {
+	if x != nil && x.LastName != nil {
+		return *x.LastName
 	}
 	return ""
 }
 
-func (x *AddMemberRequest) GetReferralCode() string {
-	if x != nil && x.ReferralCode != nil {
-		return *x.ReferralCode
+func (x *UpdateMemberRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
 	}
 	return ""
 }
 
-func (x *AddMemberRequest) GetProgramId() string {
-	if x != nil {
-		return x.ProgramId
+func (x *UpdateMemberRequest) GetProgramId() string {
+	if x != nil && x.ProgramId != nil {
+		return *x.ProgramId
 	}
 	return ""
 }
 
-func (x *AddMemberRequest) GetIsActive() bool {
+func (x *UpdateMemberRequest) GetIsActive() bool {
 	if x != nil && x.IsActive != nil {
 		return *x.IsActive
 	}
 	return false
 }
 
-type AddMemberResponse struct {
+type UpdateMemberResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Member        *Member                `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddMemberResponse) Reset() {
-	*x = AddMemberResponse{}
-	mi := &file_referral_referral_proto_msgTypes[16]
+func (x *UpdateMemberResponse) Reset() {
+	*x = UpdateMemberResponse{}
+	mi := &file_referral_referral_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddMemberResponse) String() string {
+func (x *UpdateMemberResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddMemberResponse) ProtoMessage() {}
+func (*UpdateMemberResponse) ProtoMessage() {}
 
-func (x *AddMemberResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[16]
+func (x *UpdateMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -960,51 +1419,40 @@ func (x *AddMemberResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddMemberResponse.ProtoReflect.Descriptor instead.
-func (*AddMemberResponse) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use UpdateMemberResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMemberResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *AddMemberResponse) GetId() string {
+func (x *UpdateMemberResponse) GetMember() *Member {
 	if x != nil {
-		return x.Id
+		return x.Member
 	}
-	return ""
+	return nil
 }
 
-// referral
-type Referral struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FirstName         string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName          string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	Email             string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
-	Phone             string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
-	ReferralCode      string                 `protobuf:"bytes,6,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
-	ProgramId         string                 `protobuf:"bytes,7,opt,name=program_id,json=programId,proto3" json:"program_id,omitempty"`
-	ReferringMemberId string                 `protobuf:"bytes,8,opt,name=referring_member_id,json=referringMemberId,proto3" json:"referring_member_id,omitempty"`
-	Status            string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt         int64                  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt         int64                  `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type DeactivateMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Referral) Reset() {
-	*x = Referral{}
-	mi := &file_referral_referral_proto_msgTypes[17]
+func (x *DeactivateMemberRequest) Reset() {
+	*x = DeactivateMemberRequest{}
+	mi := &file_referral_referral_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Referral) String() string {
+func (x *DeactivateMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Referral) ProtoMessage() {}
+func (*DeactivateMemberRequest) ProtoMessage() {}
 
-func (x *Referral) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[17]
+func (x *DeactivateMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1015,114 +1463,3159 @@ func (x *Referral) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Referral.ProtoReflect.Descriptor instead.
-func (*Referral) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use DeactivateMemberRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateMemberRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *Referral) GetId() string {
+func (x *DeactivateMemberRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Referral) GetFirstName() string {
-	if x != nil {
-		return x.FirstName
-	}
-	return ""
+type DeactivateMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *Member                `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Referral) GetLastName() string {
-	if x != nil {
-		return x.LastName
-	}
-	return ""
+func (x *DeactivateMemberResponse) Reset() {
+	*x = DeactivateMemberResponse{}
+	mi := &file_referral_referral_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Referral) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
+func (x *DeactivateMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Referral) GetPhone() string {
-	if x != nil {
-		return x.Phone
-	}
-	return ""
-}
+func (*DeactivateMemberResponse) ProtoMessage() {}
 
-func (x *Referral) GetReferralCode() string {
+func (x *DeactivateMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[22]
 	if x != nil {
-		return x.ReferralCode
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Referral) GetProgramId() string {
-	if x != nil {
-		return x.ProgramId
-	}
-	return ""
+// Deprecated: Use DeactivateMemberResponse.ProtoReflect.Descriptor instead.
+func (*DeactivateMemberResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *Referral) GetReferringMemberId() string {
+func (x *DeactivateMemberResponse) GetMember() *Member {
 	if x != nil {
-		return x.ReferringMemberId
+		return x.Member
 	}
-	return ""
+	return nil
 }
 
-func (x *Referral) GetStatus() string {
-	if x != nil {
+// ClaimVanityCodeRequest makes a code the member picked their referral code.
+// The member's previous codes keep working for links already shared.
+type ClaimVanityCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReferralCode  string                 `protobuf:"bytes,2,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimVanityCodeRequest) Reset() {
+	*x = ClaimVanityCodeRequest{}
+	mi := &file_referral_referral_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimVanityCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimVanityCodeRequest) ProtoMessage() {}
+
+func (x *ClaimVanityCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimVanityCodeRequest.ProtoReflect.Descriptor instead.
+func (*ClaimVanityCodeRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ClaimVanityCodeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ClaimVanityCodeRequest) GetReferralCode() string {
+	if x != nil {
+		return x.ReferralCode
+	}
+	return ""
+}
+
+type ClaimVanityCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *Member                `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimVanityCodeResponse) Reset() {
+	*x = ClaimVanityCodeResponse{}
+	mi := &file_referral_referral_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimVanityCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimVanityCodeResponse) ProtoMessage() {}
+
+func (x *ClaimVanityCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimVanityCodeResponse.ProtoReflect.Descriptor instead.
+func (*ClaimVanityCodeResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ClaimVanityCodeResponse) GetMember() *Member {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+// ReferralCode is one of a member's codes. Times are unix seconds, zero when unset.
+type ReferralCode struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Code      string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	MemberId  string                 `protobuf:"bytes,2,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Channel   string                 `protobuf:"bytes,3,opt,name=channel,proto3" json:"channel,omitempty"`
+	CreatedAt int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// set when the code was replaced by a vanity code, it still resolves.
+	RetiredAt     int64 `protobuf:"varint,6,opt,name=retired_at,json=retiredAt,proto3" json:"retired_at,omitempty"`
+	RevokedAt     int64 `protobuf:"varint,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	IsActive      bool  `protobuf:"varint,8,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReferralCode) Reset() {
+	*x = ReferralCode{}
+	mi := &file_referral_referral_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferralCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferralCode) ProtoMessage() {}
+
+func (x *ReferralCode) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferralCode.ProtoReflect.Descriptor instead.
+func (*ReferralCode) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReferralCode) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ReferralCode) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *ReferralCode) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *ReferralCode) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ReferralCode) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *ReferralCode) GetRetiredAt() int64 {
+	if x != nil {
+		return x.RetiredAt
+	}
+	return 0
+}
+
+func (x *ReferralCode) GetRevokedAt() int64 {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return 0
+}
+
+func (x *ReferralCode) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type AddReferralCodeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MemberId string                 `protobuf:"bytes,1,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	// generated when not given.
+	Code *string `protobuf:"bytes,2,opt,name=code,proto3,oneof" json:"code,omitempty"`
+	// label such as email, social or in-store.
+	Channel       *string `protobuf:"bytes,3,opt,name=channel,proto3,oneof" json:"channel,omitempty"`
+	ExpiresAt     *int64  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReferralCodeRequest) Reset() {
+	*x = AddReferralCodeRequest{}
+	mi := &file_referral_referral_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReferralCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReferralCodeRequest) ProtoMessage() {}
+
+func (x *AddReferralCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReferralCodeRequest.ProtoReflect.Descriptor instead.
+func (*AddReferralCodeRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AddReferralCodeRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *AddReferralCodeRequest) GetCode() string {
+	if x != nil && x.Code != nil {
+		return *x.Code
+	}
+	return ""
+}
+
+func (x *AddReferralCodeRequest) GetChannel() string {
+	if x != nil && x.Channel != nil {
+		return *x.Channel
+	}
+	return ""
+}
+
+func (x *AddReferralCodeRequest) GetExpiresAt() int64 {
+	if x != nil && x.ExpiresAt != nil {
+		return *x.ExpiresAt
+	}
+	return 0
+}
+
+type AddReferralCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferralCode  *ReferralCode          `protobuf:"bytes,1,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReferralCodeResponse) Reset() {
+	*x = AddReferralCodeResponse{}
+	mi := &file_referral_referral_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReferralCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReferralCodeResponse) ProtoMessage() {}
+
+func (x *AddReferralCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReferralCodeResponse.ProtoReflect.Descriptor instead.
+func (*AddReferralCodeResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AddReferralCodeResponse) GetReferralCode() *ReferralCode {
+	if x != nil {
+		return x.ReferralCode
+	}
+	return nil
+}
+
+type GetReferralCodesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MemberId      string                 `protobuf:"bytes,1,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReferralCodesRequest) Reset() {
+	*x = GetReferralCodesRequest{}
+	mi := &file_referral_referral_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReferralCodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReferralCodesRequest) ProtoMessage() {}
+
+func (x *GetReferralCodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReferralCodesRequest.ProtoReflect.Descriptor instead.
+func (*GetReferralCodesRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetReferralCodesRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+type GetReferralCodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferralCodes []*ReferralCode        `protobuf:"bytes,1,rep,name=referral_codes,json=referralCodes,proto3" json:"referral_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReferralCodesResponse) Reset() {
+	*x = GetReferralCodesResponse{}
+	mi := &file_referral_referral_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReferralCodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReferralCodesResponse) ProtoMessage() {}
+
+func (x *GetReferralCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReferralCodesResponse.ProtoReflect.Descriptor instead.
+func (*GetReferralCodesResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetReferralCodesResponse) GetReferralCodes() []*ReferralCode {
+	if x != nil {
+		return x.ReferralCodes
+	}
+	return nil
+}
+
+type RevokeReferralCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MemberId      string                 `protobuf:"bytes,1,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeReferralCodeRequest) Reset() {
+	*x = RevokeReferralCodeRequest{}
+	mi := &file_referral_referral_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeReferralCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeReferralCodeRequest) ProtoMessage() {}
+
+func (x *RevokeReferralCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeReferralCodeRequest.ProtoReflect.Descriptor instead.
+func (*RevokeReferralCodeRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RevokeReferralCodeRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *RevokeReferralCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type RevokeReferralCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferralCode  *ReferralCode          `protobuf:"bytes,1,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeReferralCodeResponse) Reset() {
+	*x = RevokeReferralCodeResponse{}
+	mi := &file_referral_referral_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeReferralCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeReferralCodeResponse) ProtoMessage() {}
+
+func (x *RevokeReferralCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeReferralCodeResponse.ProtoReflect.Descriptor instead.
+func (*RevokeReferralCodeResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RevokeReferralCodeResponse) GetReferralCode() *ReferralCode {
+	if x != nil {
+		return x.ReferralCode
+	}
+	return nil
+}
+
+type GetMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemberRequest) Reset() {
+	*x = GetMemberRequest{}
+	mi := &file_referral_referral_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemberRequest) ProtoMessage() {}
+
+func (x *GetMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemberRequest.ProtoReflect.Descriptor instead.
+func (*GetMemberRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetMemberRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *Member                `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemberResponse) Reset() {
+	*x = GetMemberResponse{}
+	mi := &file_referral_referral_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemberResponse) ProtoMessage() {}
+
+func (x *GetMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemberResponse.ProtoReflect.Descriptor instead.
+func (*GetMemberResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetMemberResponse) GetMember() *Member {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type GetMemberByEmailRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Email string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// required when the email is enrolled in several programs.
+	ProgramId     *string `protobuf:"bytes,2,opt,name=program_id,json=programId,proto3,oneof" json:"program_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemberByEmailRequest) Reset() {
+	*x = GetMemberByEmailRequest{}
+	mi := &file_referral_referral_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemberByEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemberByEmailRequest) ProtoMessage() {}
+
+func (x *GetMemberByEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemberByEmailRequest.ProtoReflect.Descriptor instead.
+func (*GetMemberByEmailRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetMemberByEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *GetMemberByEmailRequest) GetProgramId() string {
+	if x != nil && x.ProgramId != nil {
+		return *x.ProgramId
+	}
+	return ""
+}
+
+type GetMemberByEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *Member                `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemberByEmailResponse) Reset() {
+	*x = GetMemberByEmailResponse{}
+	mi := &file_referral_referral_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemberByEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemberByEmailResponse) ProtoMessage() {}
+
+func (x *GetMemberByEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemberByEmailResponse.ProtoReflect.Descriptor instead.
+func (*GetMemberByEmailResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetMemberByEmailResponse) GetMember() *Member {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type GetMemberByReferralCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferralCode  string                 `protobuf:"bytes,1,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemberByReferralCodeRequest) Reset() {
+	*x = GetMemberByReferralCodeRequest{}
+	mi := &file_referral_referral_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemberByReferralCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemberByReferralCodeRequest) ProtoMessage() {}
+
+func (x *GetMemberByReferralCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemberByReferralCodeRequest.ProtoReflect.Descriptor instead.
+func (*GetMemberByReferralCodeRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetMemberByReferralCodeRequest) GetReferralCode() string {
+	if x != nil {
+		return x.ReferralCode
+	}
+	return ""
+}
+
+type GetMemberByReferralCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *Member                `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemberByReferralCodeResponse) Reset() {
+	*x = GetMemberByReferralCodeResponse{}
+	mi := &file_referral_referral_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemberByReferralCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemberByReferralCodeResponse) ProtoMessage() {}
+
+func (x *GetMemberByReferralCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemberByReferralCodeResponse.ProtoReflect.Descriptor instead.
+func (*GetMemberByReferralCodeResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetMemberByReferralCodeResponse) GetMember() *Member {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type AddMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FirstName     string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      *string                `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3,oneof" json:"last_name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	ReferralCode  *string                `protobuf:"bytes,4,opt,name=referral_code,json=referralCode,proto3,oneof" json:"referral_code,omitempty"`
+	ProgramId     string                 `protobuf:"bytes,5,opt,name=program_id,json=programId,proto3" json:"program_id,omitempty"`
+	IsActive      *bool                  `protobuf:"varint,6,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMemberRequest) Reset() {
+	*x = AddMemberRequest{}
+	mi := &file_referral_referral_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMemberRequest) ProtoMessage() {}
+
+func (x *AddMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddMemberRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AddMemberRequest) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *AddMemberRequest) GetLastName() string {
+	if x != nil && x.LastName != nil {
+		return *x.LastName
+	}
+	return ""
+}
+
+func (x *AddMemberRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AddMemberRequest) GetReferralCode() string {
+	if x != nil && x.ReferralCode != nil {
+		return *x.ReferralCode
+	}
+	return ""
+}
+
+func (x *AddMemberRequest) GetProgramId() string {
+	if x != nil {
+		return x.ProgramId
+	}
+	return ""
+}
+
+func (x *AddMemberRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+type AddMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMemberResponse) Reset() {
+	*x = AddMemberResponse{}
+	mi := &file_referral_referral_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMemberResponse) ProtoMessage() {}
+
+func (x *AddMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddMemberResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AddMemberResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// referral
+type Referral struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName         string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName          string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Email             string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Phone             string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
+	ReferralCode      string                 `protobuf:"bytes,6,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
+	ProgramId         string                 `protobuf:"bytes,7,opt,name=program_id,json=programId,proto3" json:"program_id,omitempty"`
+	ReferringMemberId string                 `protobuf:"bytes,8,opt,name=referring_member_id,json=referringMemberId,proto3" json:"referring_member_id,omitempty"`
+	// pending, qualified, approved, denied or expired.
+	Status          string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt       int64  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       int64  `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	StatusChangedBy string `protobuf:"bytes,12,opt,name=status_changed_by,json=statusChangedBy,proto3" json:"status_changed_by,omitempty"`
+	StatusReason    string `protobuf:"bytes,13,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`
+	// channel of the code the referral was made with.
+	Channel string `protobuf:"bytes,14,opt,name=channel,proto3" json:"channel,omitempty"`
+	// set by the fraud checks when the referral needs review, with the reasons.
+	Flagged    bool   `protobuf:"varint,15,opt,name=flagged,proto3" json:"flagged,omitempty"`
+	FlagReason string `protobuf:"bytes,16,opt,name=flag_reason,json=flagReason,proto3" json:"flag_reason,omitempty"`
+	// how likely the referral is abusive, from 0 to 100.
+	RiskScore int64 `protobuf:"varint,17,opt,name=risk_score,json=riskScore,proto3" json:"risk_score,omitempty"`
+	// reviewer working on the referral and since when in unix seconds, empty when nobody is.
+	ClaimedBy string `protobuf:"bytes,18,opt,name=claimed_by,json=claimedBy,proto3" json:"claimed_by,omitempty"`
+	ClaimedAt int64  `protobuf:"varint,19,opt,name=claimed_at,json=claimedAt,proto3" json:"claimed_at,omitempty"`
+	// order amount of the conversion event that qualified the referral.
+	OrderAmount   int64 `protobuf:"varint,20,opt,name=order_amount,json=orderAmount,proto3" json:"order_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Referral) Reset() {
+	*x = Referral{}
+	mi := &file_referral_referral_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Referral) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Referral) ProtoMessage() {}
+
+func (x *Referral) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Referral.ProtoReflect.Descriptor instead.
+func (*Referral) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Referral) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Referral) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Referral) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Referral) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Referral) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Referral) GetReferralCode() string {
+	if x != nil {
+		return x.ReferralCode
+	}
+	return ""
+}
+
+func (x *Referral) GetProgramId() string {
+	if x != nil {
+		return x.ProgramId
+	}
+	return ""
+}
+
+func (x *Referral) GetReferringMemberId() string {
+	if x != nil {
+		return x.ReferringMemberId
+	}
+	return ""
+}
+
+func (x *Referral) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Referral) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Referral) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Referral) GetStatusChangedBy() string {
+	if x != nil {
+		return x.StatusChangedBy
+	}
+	return ""
+}
+
+func (x *Referral) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *Referral) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Referral) GetFlagged() bool {
+	if x != nil {
+		return x.Flagged
+	}
+	return false
+}
+
+func (x *Referral) GetFlagReason() string {
+	if x != nil {
+		return x.FlagReason
+	}
+	return ""
+}
+
+func (x *Referral) GetRiskScore() int64 {
+	if x != nil {
+		return x.RiskScore
+	}
+	return 0
+}
+
+func (x *Referral) GetClaimedBy() string {
+	if x != nil {
+		return x.ClaimedBy
+	}
+	return ""
+}
+
+func (x *Referral) GetClaimedAt() int64 {
+	if x != nil {
+		return x.ClaimedAt
+	}
+	return 0
+}
+
+func (x *Referral) GetOrderAmount() int64 {
+	if x != nil {
+		return x.OrderAmount
+	}
+	return 0
+}
+
+type AddReferralRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FirstName     *string                `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3,oneof" json:"first_name,omitempty"`
+	LastName      *string                `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3,oneof" json:"last_name,omitempty"`
+	Email         *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	Phone         *string                `protobuf:"bytes,4,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	ReferralCode  string                 `protobuf:"bytes,5,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReferralRequest) Reset() {
+	*x = AddReferralRequest{}
+	mi := &file_referral_referral_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReferralRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReferralRequest) ProtoMessage() {}
+
+func (x *AddReferralRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReferralRequest.ProtoReflect.Descriptor instead.
+func (*AddReferralRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AddReferralRequest) GetFirstName() string {
+	if x != nil && x.FirstName != nil {
+		return *x.FirstName
+	}
+	return ""
+}
+
+func (x *AddReferralRequest) GetLastName() string {
+	if x != nil && x.LastName != nil {
+		return *x.LastName
+	}
+	return ""
+}
+
+func (x *AddReferralRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *AddReferralRequest) GetPhone() string {
+	if x != nil && x.Phone != nil {
+		return *x.Phone
+	}
+	return ""
+}
+
+func (x *AddReferralRequest) GetReferralCode() string {
+	if x != nil {
+		return x.ReferralCode
+	}
+	return ""
+}
+
+type AddReferralResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReferralResponse) Reset() {
+	*x = AddReferralResponse{}
+	mi := &file_referral_referral_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReferralResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReferralResponse) ProtoMessage() {}
+
+func (x *AddReferralResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReferralResponse.ProtoReflect.Descriptor instead.
+func (*AddReferralResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AddReferralResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetReferralsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  *int64                 `protobuf:"varint,1,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	Size  *int64                 `protobuf:"varint,2,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	// next_page_token of the previous response, takes precedence over page.
+	PageToken    *string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	ProgramId    *string `protobuf:"bytes,4,opt,name=program_id,json=programId,proto3,oneof" json:"program_id,omitempty"`
+	MemberId     *string `protobuf:"bytes,5,opt,name=member_id,json=memberId,proto3,oneof" json:"member_id,omitempty"`
+	ReferralCode *string `protobuf:"bytes,6,opt,name=referral_code,json=referralCode,proto3,oneof" json:"referral_code,omitempty"`
+	Status       *string `protobuf:"bytes,7,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	Email        *string `protobuf:"bytes,8,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	// created range in unix seconds, created_after inclusive and created_before exclusive.
+	CreatedAfter  *int64 `protobuf:"varint,9,opt,name=created_after,json=createdAfter,proto3,oneof" json:"created_after,omitempty"`
+	CreatedBefore *int64 `protobuf:"varint,10,opt,name=created_before,json=createdBefore,proto3,oneof" json:"created_before,omitempty"`
+	// created_at (default), updated_at or risk_score.
+	SortBy *string `protobuf:"bytes,11,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
+	// asc (default) or desc.
+	SortOrder     *string `protobuf:"bytes,12,opt,name=sort_order,json=sortOrder,proto3,oneof" json:"sort_order,omitempty"`
+	Channel       *string `protobuf:"bytes,13,opt,name=channel,proto3,oneof" json:"channel,omitempty"`
+	Flagged       *bool   `protobuf:"varint,14,opt,name=flagged,proto3,oneof" json:"flagged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReferralsRequest) Reset() {
+	*x = GetReferralsRequest{}
+	mi := &file_referral_referral_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReferralsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReferralsRequest) ProtoMessage() {}
+
+func (x *GetReferralsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReferralsRequest.ProtoReflect.Descriptor instead.
+func (*GetReferralsRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetReferralsRequest) GetPage() int64 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+func (x *GetReferralsRequest) GetSize() int64 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *GetReferralsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+func (x *GetReferralsRequest) GetProgramId() string {
+	if x != nil && x.ProgramId != nil {
+		return *x.ProgramId
+	}
+	return ""
+}
+
+func (x *GetReferralsRequest) GetMemberId() string {
+	if x != nil && x.MemberId != nil {
+		return *x.MemberId
+	}
+	return ""
+}
+
+func (x *GetReferralsRequest) GetReferralCode() string {
+	if x != nil && x.ReferralCode != nil {
+		return *x.ReferralCode
+	}
+	return ""
+}
+
+func (x *GetReferralsRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *GetReferralsRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *GetReferralsRequest) GetCreatedAfter() int64 {
+	if x != nil && x.CreatedAfter != nil {
+		return *x.CreatedAfter
+	}
+	return 0
+}
+
+func (x *GetReferralsRequest) GetCreatedBefore() int64 {
+	if x != nil && x.CreatedBefore != nil {
+		return *x.CreatedBefore
+	}
+	return 0
+}
+
+func (x *GetReferralsRequest) GetSortBy() string {
+	if x != nil && x.SortBy != nil {
+		return *x.SortBy
+	}
+	return ""
+}
+
+func (x *GetReferralsRequest) GetSortOrder() string {
+	if x != nil && x.SortOrder != nil {
+		return *x.SortOrder
+	}
+	return ""
+}
+
+func (x *GetReferralsRequest) GetChannel() string {
+	if x != nil && x.Channel != nil {
+		return *x.Channel
+	}
+	return ""
+}
+
+func (x *GetReferralsRequest) GetFlagged() bool {
+	if x != nil && x.Flagged != nil {
+		return *x.Flagged
+	}
+	return false
+}
+
+type GetReferralsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Referrals []*Referral            `protobuf:"bytes,1,rep,name=referrals,proto3" json:"referrals,omitempty"`
+	// empty when there are no more referrals.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReferralsResponse) Reset() {
+	*x = GetReferralsResponse{}
+	mi := &file_referral_referral_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReferralsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReferralsResponse) ProtoMessage() {}
+
+func (x *GetReferralsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReferralsResponse.ProtoReflect.Descriptor instead.
+func (*GetReferralsResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetReferralsResponse) GetReferrals() []*Referral {
+	if x != nil {
+		return x.Referrals
+	}
+	return nil
+}
+
+func (x *GetReferralsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type QualifyReferralRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChangedBy     string                 `protobuf:"bytes,2,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QualifyReferralRequest) Reset() {
+	*x = QualifyReferralRequest{}
+	mi := &file_referral_referral_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QualifyReferralRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QualifyReferralRequest) ProtoMessage() {}
+
+func (x *QualifyReferralRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QualifyReferralRequest.ProtoReflect.Descriptor instead.
+func (*QualifyReferralRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *QualifyReferralRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *QualifyReferralRequest) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *QualifyReferralRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type QualifyReferralResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Referral      *Referral              `protobuf:"bytes,1,opt,name=referral,proto3" json:"referral,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QualifyReferralResponse) Reset() {
+	*x = QualifyReferralResponse{}
+	mi := &file_referral_referral_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QualifyReferralResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QualifyReferralResponse) ProtoMessage() {}
+
+func (x *QualifyReferralResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QualifyReferralResponse.ProtoReflect.Descriptor instead.
+func (*QualifyReferralResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *QualifyReferralResponse) GetReferral() *Referral {
+	if x != nil {
+		return x.Referral
+	}
+	return nil
+}
+
+type ApproveReferralRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChangedBy string                 `protobuf:"bytes,2,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
+	Reason    string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// base amount for percentage reward policies, in minor units.
+	OrderAmount   *int64 `protobuf:"varint,4,opt,name=order_amount,json=orderAmount,proto3,oneof" json:"order_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveReferralRequest) Reset() {
+	*x = ApproveReferralRequest{}
+	mi := &file_referral_referral_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveReferralRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveReferralRequest) ProtoMessage() {}
+
+func (x *ApproveReferralRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveReferralRequest.ProtoReflect.Descriptor instead.
+func (*ApproveReferralRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ApproveReferralRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApproveReferralRequest) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *ApproveReferralRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ApproveReferralRequest) GetOrderAmount() int64 {
+	if x != nil && x.OrderAmount != nil {
+		return *x.OrderAmount
+	}
+	return 0
+}
+
+type ApproveReferralResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Referral      *Referral              `protobuf:"bytes,1,opt,name=referral,proto3" json:"referral,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveReferralResponse) Reset() {
+	*x = ApproveReferralResponse{}
+	mi := &file_referral_referral_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveReferralResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveReferralResponse) ProtoMessage() {}
+
+func (x *ApproveReferralResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveReferralResponse.ProtoReflect.Descriptor instead.
+func (*ApproveReferralResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ApproveReferralResponse) GetReferral() *Referral {
+	if x != nil {
+		return x.Referral
+	}
+	return nil
+}
+
+type DenyReferralRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChangedBy     string                 `protobuf:"bytes,2,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyReferralRequest) Reset() {
+	*x = DenyReferralRequest{}
+	mi := &file_referral_referral_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyReferralRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyReferralRequest) ProtoMessage() {}
+
+func (x *DenyReferralRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyReferralRequest.ProtoReflect.Descriptor instead.
+func (*DenyReferralRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DenyReferralRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DenyReferralRequest) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *DenyReferralRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DenyReferralResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Referral      *Referral              `protobuf:"bytes,1,opt,name=referral,proto3" json:"referral,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyReferralResponse) Reset() {
+	*x = DenyReferralResponse{}
+	mi := &file_referral_referral_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyReferralResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyReferralResponse) ProtoMessage() {}
+
+func (x *DenyReferralResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyReferralResponse.ProtoReflect.Descriptor instead.
+func (*DenyReferralResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DenyReferralResponse) GetReferral() *Referral {
+	if x != nil {
+		return x.Referral
+	}
+	return nil
+}
+
+type ReviewReferralsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Ids   []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// approved or denied.
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ChangedBy     string `protobuf:"bytes,3,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewReferralsRequest) Reset() {
+	*x = ReviewReferralsRequest{}
+	mi := &file_referral_referral_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewReferralsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewReferralsRequest) ProtoMessage() {}
+
+func (x *ReviewReferralsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewReferralsRequest.ProtoReflect.Descriptor instead.
+func (*ReviewReferralsRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ReviewReferralsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ReviewReferralsRequest) GetStatus() string {
+	if x != nil {
 		return x.Status
 	}
 	return ""
 }
 
-func (x *Referral) GetCreatedAt() int64 {
+func (x *ReviewReferralsRequest) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *ReviewReferralsRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReviewReferralsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Referrals     []*Referral            `protobuf:"bytes,1,rep,name=referrals,proto3" json:"referrals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewReferralsResponse) Reset() {
+	*x = ReviewReferralsResponse{}
+	mi := &file_referral_referral_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewReferralsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewReferralsResponse) ProtoMessage() {}
+
+func (x *ReviewReferralsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewReferralsResponse.ProtoReflect.Descriptor instead.
+func (*ReviewReferralsResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ReviewReferralsResponse) GetReferrals() []*Referral {
+	if x != nil {
+		return x.Referrals
+	}
+	return nil
+}
+
+type GetReviewQueueRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  *int64                 `protobuf:"varint,1,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	Size  *int64                 `protobuf:"varint,2,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	// next_page_token of the previous response, takes precedence over page.
+	PageToken *string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	ProgramId *string `protobuf:"bytes,4,opt,name=program_id,json=programId,proto3,oneof" json:"program_id,omitempty"`
+	Flagged   *bool   `protobuf:"varint,5,opt,name=flagged,proto3,oneof" json:"flagged,omitempty"`
+	// empty for unclaimed referrals.
+	ClaimedBy *string `protobuf:"bytes,6,opt,name=claimed_by,json=claimedBy,proto3,oneof" json:"claimed_by,omitempty"`
+	// risk_score (default), created_at or updated_at.
+	SortBy *string `protobuf:"bytes,7,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
+	// desc (default for risk_score) or asc.
+	SortOrder     *string `protobuf:"bytes,8,opt,name=sort_order,json=sortOrder,proto3,oneof" json:"sort_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewQueueRequest) Reset() {
+	*x = GetReviewQueueRequest{}
+	mi := &file_referral_referral_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewQueueRequest) ProtoMessage() {}
+
+func (x *GetReviewQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewQueueRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewQueueRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetReviewQueueRequest) GetPage() int64 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+func (x *GetReviewQueueRequest) GetSize() int64 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *GetReviewQueueRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+func (x *GetReviewQueueRequest) GetProgramId() string {
+	if x != nil && x.ProgramId != nil {
+		return *x.ProgramId
+	}
+	return ""
+}
+
+func (x *GetReviewQueueRequest) GetFlagged() bool {
+	if x != nil && x.Flagged != nil {
+		return *x.Flagged
+	}
+	return false
+}
+
+func (x *GetReviewQueueRequest) GetClaimedBy() string {
+	if x != nil && x.ClaimedBy != nil {
+		return *x.ClaimedBy
+	}
+	return ""
+}
+
+func (x *GetReviewQueueRequest) GetSortBy() string {
+	if x != nil && x.SortBy != nil {
+		return *x.SortBy
+	}
+	return ""
+}
+
+func (x *GetReviewQueueRequest) GetSortOrder() string {
+	if x != nil && x.SortOrder != nil {
+		return *x.SortOrder
+	}
+	return ""
+}
+
+type GetReviewQueueResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Referrals []*Referral            `protobuf:"bytes,1,rep,name=referrals,proto3" json:"referrals,omitempty"`
+	// empty when there are no more referrals.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewQueueResponse) Reset() {
+	*x = GetReviewQueueResponse{}
+	mi := &file_referral_referral_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewQueueResponse) ProtoMessage() {}
+
+func (x *GetReviewQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewQueueResponse.ProtoReflect.Descriptor instead.
+func (*GetReviewQueueResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetReviewQueueResponse) GetReferrals() []*Referral {
+	if x != nil {
+		return x.Referrals
+	}
+	return nil
+}
+
+func (x *GetReviewQueueResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ClaimReferralRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reviewer      string                 `protobuf:"bytes,2,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimReferralRequest) Reset() {
+	*x = ClaimReferralRequest{}
+	mi := &file_referral_referral_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimReferralRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimReferralRequest) ProtoMessage() {}
+
+func (x *ClaimReferralRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimReferralRequest.ProtoReflect.Descriptor instead.
+func (*ClaimReferralRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ClaimReferralRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ClaimReferralRequest) GetReviewer() string {
+	if x != nil {
+		return x.Reviewer
+	}
+	return ""
+}
+
+type ClaimReferralResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Referral      *Referral              `protobuf:"bytes,1,opt,name=referral,proto3" json:"referral,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimReferralResponse) Reset() {
+	*x = ClaimReferralResponse{}
+	mi := &file_referral_referral_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimReferralResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimReferralResponse) ProtoMessage() {}
+
+func (x *ClaimReferralResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimReferralResponse.ProtoReflect.Descriptor instead.
+func (*ClaimReferralResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ClaimReferralResponse) GetReferral() *Referral {
+	if x != nil {
+		return x.Referral
+	}
+	return nil
+}
+
+type ReleaseReferralRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reviewer      string                 `protobuf:"bytes,2,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReferralRequest) Reset() {
+	*x = ReleaseReferralRequest{}
+	mi := &file_referral_referral_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReferralRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReferralRequest) ProtoMessage() {}
+
+func (x *ReleaseReferralRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReferralRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReferralRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ReleaseReferralRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReleaseReferralRequest) GetReviewer() string {
+	if x != nil {
+		return x.Reviewer
+	}
+	return ""
+}
+
+type ReleaseReferralResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Referral      *Referral              `protobuf:"bytes,1,opt,name=referral,proto3" json:"referral,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReferralResponse) Reset() {
+	*x = ReleaseReferralResponse{}
+	mi := &file_referral_referral_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReferralResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReferralResponse) ProtoMessage() {}
+
+func (x *ReleaseReferralResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReferralResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReferralResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ReleaseReferralResponse) GetReferral() *Referral {
+	if x != nil {
+		return x.Referral
+	}
+	return nil
+}
+
+type FlagReferralRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Flagged       bool                   `protobuf:"varint,2,opt,name=flagged,proto3" json:"flagged,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlagReferralRequest) Reset() {
+	*x = FlagReferralRequest{}
+	mi := &file_referral_referral_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlagReferralRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlagReferralRequest) ProtoMessage() {}
+
+func (x *FlagReferralRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlagReferralRequest.ProtoReflect.Descriptor instead.
+func (*FlagReferralRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *FlagReferralRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FlagReferralRequest) GetFlagged() bool {
+	if x != nil {
+		return x.Flagged
+	}
+	return false
+}
+
+func (x *FlagReferralRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type FlagReferralResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Referral      *Referral              `protobuf:"bytes,1,opt,name=referral,proto3" json:"referral,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlagReferralResponse) Reset() {
+	*x = FlagReferralResponse{}
+	mi := &file_referral_referral_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlagReferralResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlagReferralResponse) ProtoMessage() {}
+
+func (x *FlagReferralResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlagReferralResponse.ProtoReflect.Descriptor instead.
+func (*FlagReferralResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *FlagReferralResponse) GetReferral() *Referral {
+	if x != nil {
+		return x.Referral
+	}
+	return nil
+}
+
+type ReferralNote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReferralId    string                 `protobuf:"bytes,2,opt,name=referral_id,json=referralId,proto3" json:"referral_id,omitempty"`
+	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReferralNote) Reset() {
+	*x = ReferralNote{}
+	mi := &file_referral_referral_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferralNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferralNote) ProtoMessage() {}
+
+func (x *ReferralNote) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferralNote.ProtoReflect.Descriptor instead.
+func (*ReferralNote) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ReferralNote) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReferralNote) GetReferralId() string {
+	if x != nil {
+		return x.ReferralId
+	}
+	return ""
+}
+
+func (x *ReferralNote) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ReferralNote) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ReferralNote) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type AddReferralNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReferralNoteRequest) Reset() {
+	*x = AddReferralNoteRequest{}
+	mi := &file_referral_referral_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReferralNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReferralNoteRequest) ProtoMessage() {}
+
+func (x *AddReferralNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReferralNoteRequest.ProtoReflect.Descriptor instead.
+func (*AddReferralNoteRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *AddReferralNoteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AddReferralNoteRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *AddReferralNoteRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type AddReferralNoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReferralNoteResponse) Reset() {
+	*x = AddReferralNoteResponse{}
+	mi := &file_referral_referral_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReferralNoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReferralNoteResponse) ProtoMessage() {}
+
+func (x *AddReferralNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReferralNoteResponse.ProtoReflect.Descriptor instead.
+func (*AddReferralNoteResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *AddReferralNoteResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetReferralNotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReferralNotesRequest) Reset() {
+	*x = GetReferralNotesRequest{}
+	mi := &file_referral_referral_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReferralNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReferralNotesRequest) ProtoMessage() {}
+
+func (x *GetReferralNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReferralNotesRequest.ProtoReflect.Descriptor instead.
+func (*GetReferralNotesRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetReferralNotesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetReferralNotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notes         []*ReferralNote        `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReferralNotesResponse) Reset() {
+	*x = GetReferralNotesResponse{}
+	mi := &file_referral_referral_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReferralNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReferralNotesResponse) ProtoMessage() {}
+
+func (x *GetReferralNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReferralNotesResponse.ProtoReflect.Descriptor instead.
+func (*GetReferralNotesResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetReferralNotesResponse) GetNotes() []*ReferralNote {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+// conversion
+// ConversionEvent is something a referee did, matched to their referral by email or
+// phone and by attribution_token, the referral code of the referral link's cookie.
+// Events with an event_id already ingested are reported as duplicates.
+type ConversionEvent struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	EventId          *string                `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3,oneof" json:"event_id,omitempty"`
+	EventType        string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	AttributionToken *string                `protobuf:"bytes,3,opt,name=attribution_token,json=attributionToken,proto3,oneof" json:"attribution_token,omitempty"`
+	Email            *string                `protobuf:"bytes,4,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	Phone            *string                `protobuf:"bytes,5,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	// narrows email and phone matches to one program.
+	ProgramId   *string `protobuf:"bytes,6,opt,name=program_id,json=programId,proto3,oneof" json:"program_id,omitempty"`
+	OrderAmount *int64  `protobuf:"varint,7,opt,name=order_amount,json=orderAmount,proto3,oneof" json:"order_amount,omitempty"`
+	// unix seconds, defaults to now.
+	OccurredAt    *int64 `protobuf:"varint,8,opt,name=occurred_at,json=occurredAt,proto3,oneof" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversionEvent) Reset() {
+	*x = ConversionEvent{}
+	mi := &file_referral_referral_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversionEvent) ProtoMessage() {}
+
+func (x *ConversionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversionEvent.ProtoReflect.Descriptor instead.
+func (*ConversionEvent) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ConversionEvent) GetEventId() string {
+	if x != nil && x.EventId != nil {
+		return *x.EventId
+	}
+	return ""
+}
+
+func (x *ConversionEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *ConversionEvent) GetAttributionToken() string {
+	if x != nil && x.AttributionToken != nil {
+		return *x.AttributionToken
+	}
+	return ""
+}
+
+func (x *ConversionEvent) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *ConversionEvent) GetPhone() string {
+	if x != nil && x.Phone != nil {
+		return *x.Phone
+	}
+	return ""
+}
+
+func (x *ConversionEvent) GetProgramId() string {
+	if x != nil && x.ProgramId != nil {
+		return *x.ProgramId
+	}
+	return ""
+}
+
+func (x *ConversionEvent) GetOrderAmount() int64 {
+	if x != nil && x.OrderAmount != nil {
+		return *x.OrderAmount
+	}
+	return 0
+}
+
+func (x *ConversionEvent) GetOccurredAt() int64 {
+	if x != nil && x.OccurredAt != nil {
+		return *x.OccurredAt
+	}
+	return 0
+}
+
+// ConversionResult says what ingesting an event did, reason why it qualified no referral.
+type ConversionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReferralId    string                 `protobuf:"bytes,2,opt,name=referral_id,json=referralId,proto3" json:"referral_id,omitempty"`
+	Qualified     bool                   `protobuf:"varint,3,opt,name=qualified,proto3" json:"qualified,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Duplicate     bool                   `protobuf:"varint,5,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversionResult) Reset() {
+	*x = ConversionResult{}
+	mi := &file_referral_referral_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversionResult) ProtoMessage() {}
+
+func (x *ConversionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversionResult.ProtoReflect.Descriptor instead.
+func (*ConversionResult) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ConversionResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetReferralId() string {
+	if x != nil {
+		return x.ReferralId
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetQualified() bool {
+	if x != nil {
+		return x.Qualified
+	}
+	return false
+}
+
+func (x *ConversionResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+type IngestConversionEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *ConversionEvent       `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestConversionEventRequest) Reset() {
+	*x = IngestConversionEventRequest{}
+	mi := &file_referral_referral_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestConversionEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestConversionEventRequest) ProtoMessage() {}
+
+func (x *IngestConversionEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestConversionEventRequest.ProtoReflect.Descriptor instead.
+func (*IngestConversionEventRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *IngestConversionEventRequest) GetEvent() *ConversionEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type IngestConversionEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *ConversionResult      `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestConversionEventResponse) Reset() {
+	*x = IngestConversionEventResponse{}
+	mi := &file_referral_referral_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestConversionEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestConversionEventResponse) ProtoMessage() {}
+
+func (x *IngestConversionEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestConversionEventResponse.ProtoReflect.Descriptor instead.
+func (*IngestConversionEventResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *IngestConversionEventResponse) GetResult() *ConversionResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type IngestConversionEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*ConversionEvent     `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestConversionEventsRequest) Reset() {
+	*x = IngestConversionEventsRequest{}
+	mi := &file_referral_referral_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestConversionEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestConversionEventsRequest) ProtoMessage() {}
+
+func (x *IngestConversionEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestConversionEventsRequest.ProtoReflect.Descriptor instead.
+func (*IngestConversionEventsRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *IngestConversionEventsRequest) GetEvents() []*ConversionEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type IngestConversionEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ConversionResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestConversionEventsResponse) Reset() {
+	*x = IngestConversionEventsResponse{}
+	mi := &file_referral_referral_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestConversionEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestConversionEventsResponse) ProtoMessage() {}
+
+func (x *IngestConversionEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestConversionEventsResponse.ProtoReflect.Descriptor instead.
+func (*IngestConversionEventsResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *IngestConversionEventsResponse) GetResults() []*ConversionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// reward
+type Reward struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,2,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	ProgramId     string                 `protobuf:"bytes,3,opt,name=program_id,json=programId,proto3" json:"program_id,omitempty"`
+	ReferralId    string                 `protobuf:"bytes,4,opt,name=referral_id,json=referralId,proto3" json:"referral_id,omitempty"`
+	EntryType     string                 `protobuf:"bytes,5,opt,name=entry_type,json=entryType,proto3" json:"entry_type,omitempty"`
+	Amount        int64                  `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	ReversalOf    string                 `protobuf:"bytes,8,opt,name=reversal_of,json=reversalOf,proto3" json:"reversal_of,omitempty"`
+	Reason        string                 `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,10,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Beneficiary   string                 `protobuf:"bytes,12,opt,name=beneficiary,proto3" json:"beneficiary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reward) Reset() {
+	*x = Reward{}
+	mi := &file_referral_referral_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reward) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reward) ProtoMessage() {}
+
+func (x *Reward) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reward.ProtoReflect.Descriptor instead.
+func (*Reward) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *Reward) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reward) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *Reward) GetProgramId() string {
+	if x != nil {
+		return x.ProgramId
+	}
+	return ""
+}
+
+func (x *Reward) GetReferralId() string {
+	if x != nil {
+		return x.ReferralId
+	}
+	return ""
+}
+
+func (x *Reward) GetEntryType() string {
+	if x != nil {
+		return x.EntryType
+	}
+	return ""
+}
+
+func (x *Reward) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Reward) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Reward) GetReversalOf() string {
+	if x != nil {
+		return x.ReversalOf
+	}
+	return ""
+}
+
+func (x *Reward) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Reward) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Reward) GetCreatedAt() int64 {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return 0
 }
 
-func (x *Referral) GetUpdatedAt() int64 {
+func (x *Reward) GetBeneficiary() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Beneficiary
 	}
-	return 0
+	return ""
 }
 
-type AddReferralRequest struct {
+type RewardBalance struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FirstName     *string                `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3,oneof" json:"first_name,omitempty"`
-	LastName      *string                `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3,oneof" json:"last_name,omitempty"`
-	Email         *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
-	Phone         *string                `protobuf:"bytes,4,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
-	ReferralCode  string                 `protobuf:"bytes,5,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddReferralRequest) Reset() {
-	*x = AddReferralRequest{}
-	mi := &file_referral_referral_proto_msgTypes[18]
+func (x *RewardBalance) Reset() {
+	*x = RewardBalance{}
+	mi := &file_referral_referral_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddReferralRequest) String() string {
+func (x *RewardBalance) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddReferralRequest) ProtoMessage() {}
+func (*RewardBalance) ProtoMessage() {}
 
-func (x *AddReferralRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[18]
+func (x *RewardBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1133,68 +4626,92 @@ func (x *AddReferralRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddReferralRequest.ProtoReflect.Descriptor instead.
-func (*AddReferralRequest) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use RewardBalance.ProtoReflect.Descriptor instead.
+func (*RewardBalance) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *AddReferralRequest) GetFirstName() string {
-	if x != nil && x.FirstName != nil {
-		return *x.FirstName
+func (x *RewardBalance) GetCurrency() string {
+	if x != nil {
+		return x.Currency
 	}
 	return ""
 }
 
-func (x *AddReferralRequest) GetLastName() string {
-	if x != nil && x.LastName != nil {
-		return *x.LastName
+func (x *RewardBalance) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
 	}
-	return ""
+	return 0
 }
 
-func (x *AddReferralRequest) GetEmail() string {
-	if x != nil && x.Email != nil {
-		return *x.Email
-	}
-	return ""
+type GetRewardBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MemberId      string                 `protobuf:"bytes,1,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddReferralRequest) GetPhone() string {
-	if x != nil && x.Phone != nil {
-		return *x.Phone
+func (x *GetRewardBalanceRequest) Reset() {
+	*x = GetRewardBalanceRequest{}
+	mi := &file_referral_referral_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRewardBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRewardBalanceRequest) ProtoMessage() {}
+
+func (x *GetRewardBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *AddReferralRequest) GetReferralCode() string {
+// Deprecated: Use GetRewardBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetRewardBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *GetRewardBalanceRequest) GetMemberId() string {
 	if x != nil {
-		return x.ReferralCode
+		return x.MemberId
 	}
 	return ""
 }
 
-type AddReferralResponse struct {
+type GetRewardBalanceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,1,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Balances      []*RewardBalance       `protobuf:"bytes,2,rep,name=balances,proto3" json:"balances,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddReferralResponse) Reset() {
-	*x = AddReferralResponse{}
-	mi := &file_referral_referral_proto_msgTypes[19]
+func (x *GetRewardBalanceResponse) Reset() {
+	*x = GetRewardBalanceResponse{}
+	mi := &file_referral_referral_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddReferralResponse) String() string {
+func (x *GetRewardBalanceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddReferralResponse) ProtoMessage() {}
+func (*GetRewardBalanceResponse) ProtoMessage() {}
 
-func (x *AddReferralResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[19]
+func (x *GetRewardBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1205,41 +4722,51 @@ func (x *AddReferralResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddReferralResponse.ProtoReflect.Descriptor instead.
-func (*AddReferralResponse) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use GetRewardBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetRewardBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *AddReferralResponse) GetId() string {
+func (x *GetRewardBalanceResponse) GetMemberId() string {
 	if x != nil {
-		return x.Id
+		return x.MemberId
 	}
 	return ""
 }
 
-type GetReferralsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          *int64                 `protobuf:"varint,1,opt,name=page,proto3,oneof" json:"page,omitempty"`
-	Size          *int64                 `protobuf:"varint,2,opt,name=size,proto3,oneof" json:"size,omitempty"`
+func (x *GetRewardBalanceResponse) GetBalances() []*RewardBalance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+type GetRewardsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MemberId string                 `protobuf:"bytes,1,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Page     *int64                 `protobuf:"varint,2,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	Size     *int64                 `protobuf:"varint,3,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	// next_page_token of the previous response, takes precedence over page.
+	PageToken     *string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetReferralsRequest) Reset() {
-	*x = GetReferralsRequest{}
-	mi := &file_referral_referral_proto_msgTypes[20]
+func (x *GetRewardsRequest) Reset() {
+	*x = GetRewardsRequest{}
+	mi := &file_referral_referral_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReferralsRequest) String() string {
+func (x *GetRewardsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReferralsRequest) ProtoMessage() {}
+func (*GetRewardsRequest) ProtoMessage() {}
 
-func (x *GetReferralsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[20]
+func (x *GetRewardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1250,47 +4777,116 @@ func (x *GetReferralsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReferralsRequest.ProtoReflect.Descriptor instead.
-func (*GetReferralsRequest) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use GetRewardsRequest.ProtoReflect.Descriptor instead.
+func (*GetRewardsRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *GetReferralsRequest) GetPage() int64 {
+func (x *GetRewardsRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *GetRewardsRequest) GetPage() int64 {
 	if x != nil && x.Page != nil {
 		return *x.Page
 	}
 	return 0
 }
 
-func (x *GetReferralsRequest) GetSize() int64 {
+func (x *GetRewardsRequest) GetSize() int64 {
 	if x != nil && x.Size != nil {
 		return *x.Size
 	}
 	return 0
 }
 
-type GetReferralsResponse struct {
+func (x *GetRewardsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+type GetRewardsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Rewards []*Reward              `protobuf:"bytes,1,rep,name=rewards,proto3" json:"rewards,omitempty"`
+	// empty when there are no more rewards.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRewardsResponse) Reset() {
+	*x = GetRewardsResponse{}
+	mi := &file_referral_referral_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRewardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRewardsResponse) ProtoMessage() {}
+
+func (x *GetRewardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRewardsResponse.ProtoReflect.Descriptor instead.
+func (*GetRewardsResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *GetRewardsResponse) GetRewards() []*Reward {
+	if x != nil {
+		return x.Rewards
+	}
+	return nil
+}
+
+func (x *GetRewardsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ReverseRewardRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Referrals     []*Referral            `protobuf:"bytes,1,rep,name=referrals,proto3" json:"referrals,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReversedBy    string                 `protobuf:"bytes,2,opt,name=reversed_by,json=reversedBy,proto3" json:"reversed_by,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetReferralsResponse) Reset() {
-	*x = GetReferralsResponse{}
-	mi := &file_referral_referral_proto_msgTypes[21]
+func (x *ReverseRewardRequest) Reset() {
+	*x = ReverseRewardRequest{}
+	mi := &file_referral_referral_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReferralsResponse) String() string {
+func (x *ReverseRewardRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReferralsResponse) ProtoMessage() {}
+func (*ReverseRewardRequest) ProtoMessage() {}
 
-func (x *GetReferralsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_referral_referral_proto_msgTypes[21]
+func (x *ReverseRewardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1301,14 +4897,72 @@ func (x *GetReferralsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReferralsResponse.ProtoReflect.Descriptor instead.
-func (*GetReferralsResponse) Descriptor() ([]byte, []int) {
-	return file_referral_referral_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ReverseRewardRequest.ProtoReflect.Descriptor instead.
+func (*ReverseRewardRequest) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *GetReferralsResponse) GetReferrals() []*Referral {
+func (x *ReverseRewardRequest) GetId() string {
 	if x != nil {
-		return x.Referrals
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReverseRewardRequest) GetReversedBy() string {
+	if x != nil {
+		return x.ReversedBy
+	}
+	return ""
+}
+
+func (x *ReverseRewardRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReverseRewardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reward        *Reward                `protobuf:"bytes,1,opt,name=reward,proto3" json:"reward,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseRewardResponse) Reset() {
+	*x = ReverseRewardResponse{}
+	mi := &file_referral_referral_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseRewardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseRewardResponse) ProtoMessage() {}
+
+func (x *ReverseRewardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_referral_referral_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseRewardResponse.ProtoReflect.Descriptor instead.
+func (*ReverseRewardResponse) Descriptor() ([]byte, []int) {
+	return file_referral_referral_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ReverseRewardResponse) GetReward() *Reward {
+	if x != nil {
+		return x.Reward
 	}
 	return nil
 }
@@ -1317,47 +4971,102 @@ var File_referral_referral_proto protoreflect.FileDescriptor
 
 const file_referral_referral_proto_rawDesc = "" +
 	"\n" +
-	"\x17referral/referral.proto\x12\breferral\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"3\n" +
+	"\x17referral/referral.proto\x12\breferral\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"f\n" +
 	"\x1bGenerateReferralLinkRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\"0\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\"\n" +
+	"\n" +
+	"program_id\x18\x02 \x01(\tH\x00R\tprogramId\x88\x01\x01B\r\n" +
+	"\v_program_id\"0\n" +
 	"\x1cGenerateReferralLinkResponse\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\"`\n" +
 	"\x13ReferralLinkWrapper\x12I\n" +
-	"\freferrallink\x18\x01 \x01(\v2%.referral.GenerateReferralLinkRequestR\freferrallink\"\x97\x01\n" +
+	"\freferrallink\x18\x01 \x01(\v2%.referral.GenerateReferralLinkRequestR\freferrallink\"y\n" +
+	"\x14GetClickStatsRequest\x12 \n" +
+	"\tmember_id\x18\x01 \x01(\tH\x00R\bmemberId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"program_id\x18\x02 \x01(\tH\x01R\tprogramId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_member_idB\r\n" +
+	"\v_program_id\"X\n" +
+	"\x15GetClickStatsResponse\x12\x16\n" +
+	"\x06clicks\x18\x01 \x01(\x03R\x06clicks\x12'\n" +
+	"\x0funique_visitors\x18\x02 \x01(\x03R\x0euniqueVisitors\"\xe2\x01\n" +
+	"\fRewardPolicy\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12'\n" +
+	"\x0freferrer_reward\x18\x03 \x01(\x03R\x0ereferrerReward\x12%\n" +
+	"\x0ereferee_reward\x18\x04 \x01(\x03R\rrefereeReward\x123\n" +
+	"\x16max_rewards_per_member\x18\x05 \x01(\x03R\x13maxRewardsPerMember\x12\x1d\n" +
+	"\n" +
+	"reward_cap\x18\x06 \x01(\x03R\trewardCap\"t\n" +
+	"\x11QualificationRule\x12\x14\n" +
+	"\x05event\x18\x01 \x01(\tR\x05event\x12(\n" +
+	"\x10min_order_amount\x18\x02 \x01(\x03R\x0eminOrderAmount\x12\x1f\n" +
+	"\vwithin_days\x18\x03 \x01(\x03R\n" +
+	"withinDays\"\x91\x03\n" +
 	"\aProgram\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
 	"\x05title\x18\x03 \x01(\tR\x05title\x12\x16\n" +
 	"\x06active\x18\x04 \x01(\bR\x06active\x12\x1c\n" +
 	"\tcreatedat\x18\x05 \x01(\x03R\tcreatedat\x12\x1c\n" +
-	"\tupdatedat\x18\x06 \x01(\x03R\tupdatedat\"U\n" +
+	"\tupdatedat\x18\x06 \x01(\x03R\tupdatedat\x12;\n" +
+	"\rreward_policy\x18\a \x01(\v2\x16.referral.RewardPolicyR\frewardPolicy\x12\x1f\n" +
+	"\vlanding_url\x18\b \x01(\tR\n" +
+	"landingUrl\x12\x1f\n" +
+	"\vcode_prefix\x18\t \x01(\tR\n" +
+	"codePrefix\x12A\n" +
+	"\rqualification\x18\n" +
+	" \x01(\v2\x1b.referral.QualificationRuleR\rqualification\x126\n" +
+	"\x17attribution_window_days\x18\v \x01(\x03R\x15attributionWindowDays\"\xcf\x02\n" +
 	"\x11AddProgramRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
-	"\x06active\x18\x03 \x01(\bR\x06active\"$\n" +
+	"\x06active\x18\x03 \x01(\bR\x06active\x12;\n" +
+	"\rreward_policy\x18\x04 \x01(\v2\x16.referral.RewardPolicyR\frewardPolicy\x12\x1f\n" +
+	"\vlanding_url\x18\x05 \x01(\tR\n" +
+	"landingUrl\x12\x1f\n" +
+	"\vcode_prefix\x18\x06 \x01(\tR\n" +
+	"codePrefix\x12A\n" +
+	"\rqualification\x18\a \x01(\v2\x1b.referral.QualificationRuleR\rqualification\x126\n" +
+	"\x17attribution_window_days\x18\b \x01(\x03R\x15attributionWindowDays\"$\n" +
 	"\x12AddProgramResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x95\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xda\x03\n" +
 	"\x14UpdateProgramRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x19\n" +
 	"\x05title\x18\x03 \x01(\tH\x01R\x05title\x88\x01\x01\x12\x1b\n" +
-	"\x06active\x18\x04 \x01(\bH\x02R\x06active\x88\x01\x01B\a\n" +
+	"\x06active\x18\x04 \x01(\bH\x02R\x06active\x88\x01\x01\x12;\n" +
+	"\rreward_policy\x18\x05 \x01(\v2\x16.referral.RewardPolicyR\frewardPolicy\x12$\n" +
+	"\vlanding_url\x18\x06 \x01(\tH\x03R\n" +
+	"landingUrl\x88\x01\x01\x12$\n" +
+	"\vcode_prefix\x18\a \x01(\tH\x04R\n" +
+	"codePrefix\x88\x01\x01\x12A\n" +
+	"\rqualification\x18\b \x01(\v2\x1b.referral.QualificationRuleR\rqualification\x12;\n" +
+	"\x17attribution_window_days\x18\t \x01(\x03H\x05R\x15attributionWindowDays\x88\x01\x01B\a\n" +
 	"\x05_nameB\b\n" +
 	"\x06_titleB\t\n" +
-	"\a_active\"E\n" +
+	"\a_activeB\x0e\n" +
+	"\f_landing_urlB\x0e\n" +
+	"\f_code_prefixB\x1a\n" +
+	"\x18_attribution_window_days\"E\n" +
 	"\x16UpdagteProgramResponse\x12+\n" +
-	"\aprogram\x18\x01 \x01(\v2\x11.referral.ProgramR\aprogram\"X\n" +
+	"\aprogram\x18\x01 \x01(\v2\x11.referral.ProgramR\aprogram\"\x8b\x01\n" +
 	"\x12GetProgramsRequest\x12\x17\n" +
 	"\x04page\x18\x01 \x01(\x03H\x00R\x04page\x88\x01\x01\x12\x17\n" +
-	"\x04size\x18\x02 \x01(\x03H\x01R\x04size\x88\x01\x01B\a\n" +
+	"\x04size\x18\x02 \x01(\x03H\x01R\x04size\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tH\x02R\tpageToken\x88\x01\x01B\a\n" +
 	"\x05_pageB\a\n" +
-	"\x05_size\"D\n" +
+	"\x05_sizeB\r\n" +
+	"\v_page_token\"l\n" +
 	"\x13GetProgramsResponse\x12-\n" +
-	"\bprograms\x18\x01 \x03(\v2\x11.referral.ProgramR\bprograms\"#\n" +
+	"\bprograms\x18\x01 \x03(\v2\x11.referral.ProgramR\bprograms\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"#\n" +
 	"\x11GetProgramRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"A\n" +
 	"\x12GetProgramResponse\x12+\n" +
-	"\aprogram\x18\x01 \x01(\v2\x11.referral.ProgramR\aprogram\"\x89\x02\n" +
+	"\aprogram\x18\x01 \x01(\v2\x11.referral.ProgramR\aprogram\"\xa6\x02\n" +
 	"\x06Member\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1371,14 +5080,104 @@ const file_referral_referral_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\x03R\tupdatedAt\"W\n" +
+	"updated_at\x18\t \x01(\x03R\tupdatedAt\x12\x1b\n" +
+	"\tperson_id\x18\n" +
+	" \x01(\tR\bpersonId\"\x92\x02\n" +
 	"\x11GetMembersRequest\x12\x17\n" +
 	"\x04page\x18\x01 \x01(\x03H\x00R\x04page\x88\x01\x01\x12\x17\n" +
-	"\x04size\x18\x02 \x01(\x03H\x01R\x04size\x88\x01\x01B\a\n" +
+	"\x04size\x18\x02 \x01(\x03H\x01R\x04size\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tH\x02R\tpageToken\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"program_id\x18\x04 \x01(\tH\x03R\tprogramId\x88\x01\x01\x12 \n" +
+	"\tperson_id\x18\x05 \x01(\tH\x04R\bpersonId\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x06 \x01(\tH\x05R\x05email\x88\x01\x01B\a\n" +
 	"\x05_pageB\a\n" +
-	"\x05_size\"@\n" +
+	"\x05_sizeB\r\n" +
+	"\v_page_tokenB\r\n" +
+	"\v_program_idB\f\n" +
+	"\n" +
+	"_person_idB\b\n" +
+	"\x06_email\"h\n" +
 	"\x12GetMembersResponse\x12*\n" +
-	"\amembers\x18\x01 \x03(\v2\x10.referral.MemberR\amembers\"\x82\x02\n" +
+	"\amembers\x18\x01 \x03(\v2\x10.referral.MemberR\amembers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x90\x02\n" +
+	"\x13UpdateMemberRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\"\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tH\x00R\tfirstName\x88\x01\x01\x12 \n" +
+	"\tlast_name\x18\x03 \x01(\tH\x01R\blastName\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x04 \x01(\tH\x02R\x05email\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"program_id\x18\x05 \x01(\tH\x03R\tprogramId\x88\x01\x01\x12 \n" +
+	"\tis_active\x18\x06 \x01(\bH\x04R\bisActive\x88\x01\x01B\r\n" +
+	"\v_first_nameB\f\n" +
+	"\n" +
+	"_last_nameB\b\n" +
+	"\x06_emailB\r\n" +
+	"\v_program_idB\f\n" +
+	"\n" +
+	"_is_active\"@\n" +
+	"\x14UpdateMemberResponse\x12(\n" +
+	"\x06member\x18\x01 \x01(\v2\x10.referral.MemberR\x06member\")\n" +
+	"\x17DeactivateMemberRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"D\n" +
+	"\x18DeactivateMemberResponse\x12(\n" +
+	"\x06member\x18\x01 \x01(\v2\x10.referral.MemberR\x06member\"M\n" +
+	"\x16ClaimVanityCodeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rreferral_code\x18\x02 \x01(\tR\freferralCode\"C\n" +
+	"\x17ClaimVanityCodeResponse\x12(\n" +
+	"\x06member\x18\x01 \x01(\v2\x10.referral.MemberR\x06member\"\xf2\x01\n" +
+	"\fReferralCode\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1b\n" +
+	"\tmember_id\x18\x02 \x01(\tR\bmemberId\x12\x18\n" +
+	"\achannel\x18\x03 \x01(\tR\achannel\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"retired_at\x18\x06 \x01(\x03R\tretiredAt\x12\x1d\n" +
+	"\n" +
+	"revoked_at\x18\a \x01(\x03R\trevokedAt\x12\x1b\n" +
+	"\tis_active\x18\b \x01(\bR\bisActive\"\xb5\x01\n" +
+	"\x16AddReferralCodeRequest\x12\x1b\n" +
+	"\tmember_id\x18\x01 \x01(\tR\bmemberId\x12\x17\n" +
+	"\x04code\x18\x02 \x01(\tH\x00R\x04code\x88\x01\x01\x12\x1d\n" +
+	"\achannel\x18\x03 \x01(\tH\x01R\achannel\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03H\x02R\texpiresAt\x88\x01\x01B\a\n" +
+	"\x05_codeB\n" +
+	"\n" +
+	"\b_channelB\r\n" +
+	"\v_expires_at\"V\n" +
+	"\x17AddReferralCodeResponse\x12;\n" +
+	"\rreferral_code\x18\x01 \x01(\v2\x16.referral.ReferralCodeR\freferralCode\"6\n" +
+	"\x17GetReferralCodesRequest\x12\x1b\n" +
+	"\tmember_id\x18\x01 \x01(\tR\bmemberId\"Y\n" +
+	"\x18GetReferralCodesResponse\x12=\n" +
+	"\x0ereferral_codes\x18\x01 \x03(\v2\x16.referral.ReferralCodeR\rreferralCodes\"L\n" +
+	"\x19RevokeReferralCodeRequest\x12\x1b\n" +
+	"\tmember_id\x18\x01 \x01(\tR\bmemberId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"Y\n" +
+	"\x1aRevokeReferralCodeResponse\x12;\n" +
+	"\rreferral_code\x18\x01 \x01(\v2\x16.referral.ReferralCodeR\freferralCode\"\"\n" +
+	"\x10GetMemberRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"=\n" +
+	"\x11GetMemberResponse\x12(\n" +
+	"\x06member\x18\x01 \x01(\v2\x10.referral.MemberR\x06member\"b\n" +
+	"\x17GetMemberByEmailRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\"\n" +
+	"\n" +
+	"program_id\x18\x02 \x01(\tH\x00R\tprogramId\x88\x01\x01B\r\n" +
+	"\v_program_id\"D\n" +
+	"\x18GetMemberByEmailResponse\x12(\n" +
+	"\x06member\x18\x01 \x01(\v2\x10.referral.MemberR\x06member\"E\n" +
+	"\x1eGetMemberByReferralCodeRequest\x12#\n" +
+	"\rreferral_code\x18\x01 \x01(\tR\freferralCode\"K\n" +
+	"\x1fGetMemberByReferralCodeResponse\x12(\n" +
+	"\x06member\x18\x01 \x01(\v2\x10.referral.MemberR\x06member\"\x82\x02\n" +
 	"\x10AddMemberRequest\x12\x1d\n" +
 	"\n" +
 	"first_name\x18\x01 \x01(\tR\tfirstName\x12 \n" +
@@ -1394,7 +5193,7 @@ const file_referral_referral_proto_rawDesc = "" +
 	"\n" +
 	"_is_active\"#\n" +
 	"\x11AddMemberResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xcc\x02\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xf2\x04\n" +
 	"\bReferral\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1411,7 +5210,20 @@ const file_referral_referral_proto_rawDesc = "" +
 	"created_at\x18\n" +
 	" \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\v \x01(\x03R\tupdatedAt\"\xe6\x01\n" +
+	"updated_at\x18\v \x01(\x03R\tupdatedAt\x12*\n" +
+	"\x11status_changed_by\x18\f \x01(\tR\x0fstatusChangedBy\x12#\n" +
+	"\rstatus_reason\x18\r \x01(\tR\fstatusReason\x12\x18\n" +
+	"\achannel\x18\x0e \x01(\tR\achannel\x12\x18\n" +
+	"\aflagged\x18\x0f \x01(\bR\aflagged\x12\x1f\n" +
+	"\vflag_reason\x18\x10 \x01(\tR\n" +
+	"flagReason\x12\x1d\n" +
+	"\n" +
+	"risk_score\x18\x11 \x01(\x03R\triskScore\x12\x1d\n" +
+	"\n" +
+	"claimed_by\x18\x12 \x01(\tR\tclaimedBy\x12\x1d\n" +
+	"\n" +
+	"claimed_at\x18\x13 \x01(\x03R\tclaimedAt\x12!\n" +
+	"\forder_amount\x18\x14 \x01(\x03R\vorderAmount\"\xe6\x01\n" +
 	"\x12AddReferralRequest\x12\"\n" +
 	"\n" +
 	"first_name\x18\x01 \x01(\tH\x00R\tfirstName\x88\x01\x01\x12 \n" +
@@ -1425,14 +5237,220 @@ const file_referral_referral_proto_rawDesc = "" +
 	"\x06_emailB\b\n" +
 	"\x06_phone\"%\n" +
 	"\x13AddReferralResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"Y\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa6\x05\n" +
 	"\x13GetReferralsRequest\x12\x17\n" +
 	"\x04page\x18\x01 \x01(\x03H\x00R\x04page\x88\x01\x01\x12\x17\n" +
-	"\x04size\x18\x02 \x01(\x03H\x01R\x04size\x88\x01\x01B\a\n" +
+	"\x04size\x18\x02 \x01(\x03H\x01R\x04size\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tH\x02R\tpageToken\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"program_id\x18\x04 \x01(\tH\x03R\tprogramId\x88\x01\x01\x12 \n" +
+	"\tmember_id\x18\x05 \x01(\tH\x04R\bmemberId\x88\x01\x01\x12(\n" +
+	"\rreferral_code\x18\x06 \x01(\tH\x05R\freferralCode\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\a \x01(\tH\x06R\x06status\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\b \x01(\tH\aR\x05email\x88\x01\x01\x12(\n" +
+	"\rcreated_after\x18\t \x01(\x03H\bR\fcreatedAfter\x88\x01\x01\x12*\n" +
+	"\x0ecreated_before\x18\n" +
+	" \x01(\x03H\tR\rcreatedBefore\x88\x01\x01\x12\x1c\n" +
+	"\asort_by\x18\v \x01(\tH\n" +
+	"R\x06sortBy\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"sort_order\x18\f \x01(\tH\vR\tsortOrder\x88\x01\x01\x12\x1d\n" +
+	"\achannel\x18\r \x01(\tH\fR\achannel\x88\x01\x01\x12\x1d\n" +
+	"\aflagged\x18\x0e \x01(\bH\rR\aflagged\x88\x01\x01B\a\n" +
 	"\x05_pageB\a\n" +
-	"\x05_size\"H\n" +
+	"\x05_sizeB\r\n" +
+	"\v_page_tokenB\r\n" +
+	"\v_program_idB\f\n" +
+	"\n" +
+	"_member_idB\x10\n" +
+	"\x0e_referral_codeB\t\n" +
+	"\a_statusB\b\n" +
+	"\x06_emailB\x10\n" +
+	"\x0e_created_afterB\x11\n" +
+	"\x0f_created_beforeB\n" +
+	"\n" +
+	"\b_sort_byB\r\n" +
+	"\v_sort_orderB\n" +
+	"\n" +
+	"\b_channelB\n" +
+	"\n" +
+	"\b_flagged\"p\n" +
 	"\x14GetReferralsResponse\x120\n" +
-	"\treferrals\x18\x01 \x03(\v2\x12.referral.ReferralR\treferrals2\xd7\x06\n" +
+	"\treferrals\x18\x01 \x03(\v2\x12.referral.ReferralR\treferrals\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"_\n" +
+	"\x16QualifyReferralRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x02 \x01(\tR\tchangedBy\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"I\n" +
+	"\x17QualifyReferralResponse\x12.\n" +
+	"\breferral\x18\x01 \x01(\v2\x12.referral.ReferralR\breferral\"\x98\x01\n" +
+	"\x16ApproveReferralRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x02 \x01(\tR\tchangedBy\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12&\n" +
+	"\forder_amount\x18\x04 \x01(\x03H\x00R\vorderAmount\x88\x01\x01B\x0f\n" +
+	"\r_order_amount\"I\n" +
+	"\x17ApproveReferralResponse\x12.\n" +
+	"\breferral\x18\x01 \x01(\v2\x12.referral.ReferralR\breferral\"\\\n" +
+	"\x13DenyReferralRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x02 \x01(\tR\tchangedBy\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"F\n" +
+	"\x14DenyReferralResponse\x12.\n" +
+	"\breferral\x18\x01 \x01(\v2\x12.referral.ReferralR\breferral\"y\n" +
+	"\x16ReviewReferralsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x03 \x01(\tR\tchangedBy\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"K\n" +
+	"\x17ReviewReferralsResponse\x120\n" +
+	"\treferrals\x18\x01 \x03(\v2\x12.referral.ReferralR\treferrals\"\xfc\x02\n" +
+	"\x15GetReviewQueueRequest\x12\x17\n" +
+	"\x04page\x18\x01 \x01(\x03H\x00R\x04page\x88\x01\x01\x12\x17\n" +
+	"\x04size\x18\x02 \x01(\x03H\x01R\x04size\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tH\x02R\tpageToken\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"program_id\x18\x04 \x01(\tH\x03R\tprogramId\x88\x01\x01\x12\x1d\n" +
+	"\aflagged\x18\x05 \x01(\bH\x04R\aflagged\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"claimed_by\x18\x06 \x01(\tH\x05R\tclaimedBy\x88\x01\x01\x12\x1c\n" +
+	"\asort_by\x18\a \x01(\tH\x06R\x06sortBy\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"sort_order\x18\b \x01(\tH\aR\tsortOrder\x88\x01\x01B\a\n" +
+	"\x05_pageB\a\n" +
+	"\x05_sizeB\r\n" +
+	"\v_page_tokenB\r\n" +
+	"\v_program_idB\n" +
+	"\n" +
+	"\b_flaggedB\r\n" +
+	"\v_claimed_byB\n" +
+	"\n" +
+	"\b_sort_byB\r\n" +
+	"\v_sort_order\"r\n" +
+	"\x16GetReviewQueueResponse\x120\n" +
+	"\treferrals\x18\x01 \x03(\v2\x12.referral.ReferralR\treferrals\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"B\n" +
+	"\x14ClaimReferralRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\breviewer\x18\x02 \x01(\tR\breviewer\"G\n" +
+	"\x15ClaimReferralResponse\x12.\n" +
+	"\breferral\x18\x01 \x01(\v2\x12.referral.ReferralR\breferral\"D\n" +
+	"\x16ReleaseReferralRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\breviewer\x18\x02 \x01(\tR\breviewer\"I\n" +
+	"\x17ReleaseReferralResponse\x12.\n" +
+	"\breferral\x18\x01 \x01(\v2\x12.referral.ReferralR\breferral\"W\n" +
+	"\x13FlagReferralRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aflagged\x18\x02 \x01(\bR\aflagged\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"F\n" +
+	"\x14FlagReferralResponse\x12.\n" +
+	"\breferral\x18\x01 \x01(\v2\x12.referral.ReferralR\breferral\"\x8a\x01\n" +
+	"\fReferralNote\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vreferral_id\x18\x02 \x01(\tR\n" +
+	"referralId\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"T\n" +
+	"\x16AddReferralNoteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\")\n" +
+	"\x17AddReferralNoteResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\")\n" +
+	"\x17GetReferralNotesRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x18GetReferralNotesResponse\x12,\n" +
+	"\x05notes\x18\x01 \x03(\v2\x16.referral.ReferralNoteR\x05notes\"\x91\x03\n" +
+	"\x0fConversionEvent\x12\x1e\n" +
+	"\bevent_id\x18\x01 \x01(\tH\x00R\aeventId\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x120\n" +
+	"\x11attribution_token\x18\x03 \x01(\tH\x01R\x10attributionToken\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x04 \x01(\tH\x02R\x05email\x88\x01\x01\x12\x19\n" +
+	"\x05phone\x18\x05 \x01(\tH\x03R\x05phone\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"program_id\x18\x06 \x01(\tH\x04R\tprogramId\x88\x01\x01\x12&\n" +
+	"\forder_amount\x18\a \x01(\x03H\x05R\vorderAmount\x88\x01\x01\x12$\n" +
+	"\voccurred_at\x18\b \x01(\x03H\x06R\n" +
+	"occurredAt\x88\x01\x01B\v\n" +
+	"\t_event_idB\x14\n" +
+	"\x12_attribution_tokenB\b\n" +
+	"\x06_emailB\b\n" +
+	"\x06_phoneB\r\n" +
+	"\v_program_idB\x0f\n" +
+	"\r_order_amountB\x0e\n" +
+	"\f_occurred_at\"\x97\x01\n" +
+	"\x10ConversionResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vreferral_id\x18\x02 \x01(\tR\n" +
+	"referralId\x12\x1c\n" +
+	"\tqualified\x18\x03 \x01(\bR\tqualified\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1c\n" +
+	"\tduplicate\x18\x05 \x01(\bR\tduplicate\"O\n" +
+	"\x1cIngestConversionEventRequest\x12/\n" +
+	"\x05event\x18\x01 \x01(\v2\x19.referral.ConversionEventR\x05event\"S\n" +
+	"\x1dIngestConversionEventResponse\x122\n" +
+	"\x06result\x18\x01 \x01(\v2\x1a.referral.ConversionResultR\x06result\"R\n" +
+	"\x1dIngestConversionEventsRequest\x121\n" +
+	"\x06events\x18\x01 \x03(\v2\x19.referral.ConversionEventR\x06events\"V\n" +
+	"\x1eIngestConversionEventsResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.referral.ConversionResultR\aresults\"\xe1\x02\n" +
+	"\x06Reward\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tmember_id\x18\x02 \x01(\tR\bmemberId\x12\x1d\n" +
+	"\n" +
+	"program_id\x18\x03 \x01(\tR\tprogramId\x12\x1f\n" +
+	"\vreferral_id\x18\x04 \x01(\tR\n" +
+	"referralId\x12\x1d\n" +
+	"\n" +
+	"entry_type\x18\x05 \x01(\tR\tentryType\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12\x1f\n" +
+	"\vreversal_of\x18\b \x01(\tR\n" +
+	"reversalOf\x12\x16\n" +
+	"\x06reason\x18\t \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\n" +
+	" \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12 \n" +
+	"\vbeneficiary\x18\f \x01(\tR\vbeneficiary\"C\n" +
+	"\rRewardBalance\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"6\n" +
+	"\x17GetRewardBalanceRequest\x12\x1b\n" +
+	"\tmember_id\x18\x01 \x01(\tR\bmemberId\"l\n" +
+	"\x18GetRewardBalanceResponse\x12\x1b\n" +
+	"\tmember_id\x18\x01 \x01(\tR\bmemberId\x123\n" +
+	"\bbalances\x18\x02 \x03(\v2\x17.referral.RewardBalanceR\bbalances\"\xa7\x01\n" +
+	"\x11GetRewardsRequest\x12\x1b\n" +
+	"\tmember_id\x18\x01 \x01(\tR\bmemberId\x12\x17\n" +
+	"\x04page\x18\x02 \x01(\x03H\x00R\x04page\x88\x01\x01\x12\x17\n" +
+	"\x04size\x18\x03 \x01(\x03H\x01R\x04size\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tH\x02R\tpageToken\x88\x01\x01B\a\n" +
+	"\x05_pageB\a\n" +
+	"\x05_sizeB\r\n" +
+	"\v_page_token\"h\n" +
+	"\x12GetRewardsResponse\x12*\n" +
+	"\arewards\x18\x01 \x03(\v2\x10.referral.RewardR\arewards\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"_\n" +
+	"\x14ReverseRewardRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vreversed_by\x18\x02 \x01(\tR\n" +
+	"reversedBy\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"A\n" +
+	"\x15ReverseRewardResponse\x12(\n" +
+	"\x06reward\x18\x01 \x01(\v2\x10.referral.RewardR\x06reward2\xb2!\n" +
 	"\x10referral_service\x12d\n" +
 	"\vGetPrograms\x12\x1c.referral.GetProgramsRequest\x1a\x1d.referral.GetProgramsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/programs\x12o\n" +
 	"\n" +
@@ -1441,10 +5459,37 @@ const file_referral_referral_proto_rawDesc = "" +
 	"AddProgram\x12\x1b.referral.AddProgramRequest\x1a\x1c.referral.AddProgramResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/api/v1/programs\x12n\n" +
 	"\rUpdateProgram\x12\x1e.referral.UpdateProgramRequest\x1a .referral.UpdagteProgramResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\x1a\x10/api/v1/programs\x12`\n" +
 	"\n" +
-	"GetMembers\x12\x1b.referral.GetMembersRequest\x1a\x1c.referral.GetMembersResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/api/v1/members\x12`\n" +
-	"\tAddMember\x12\x1a.referral.AddMemberRequest\x1a\x1b.referral.AddMemberResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/api/v1/members\x12h\n" +
+	"GetMembers\x12\x1b.referral.GetMembersRequest\x1a\x1c.referral.GetMembersResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/api/v1/members\x12b\n" +
+	"\tGetMember\x12\x1a.referral.GetMemberRequest\x1a\x1b.referral.GetMemberResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/members/{id}\x12\x80\x01\n" +
+	"\x10GetMemberByEmail\x12!.referral.GetMemberByEmailRequest\x1a\".referral.GetMemberByEmailResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/api/v1/members/email/{email}\x12\xa5\x01\n" +
+	"\x17GetMemberByReferralCode\x12(.referral.GetMemberByReferralCodeRequest\x1a).referral.GetMemberByReferralCodeResponse\"5\x82\xd3\xe4\x93\x02/\x12-/api/v1/members/referral-code/{referral_code}\x12`\n" +
+	"\tAddMember\x12\x1a.referral.AddMemberRequest\x1a\x1b.referral.AddMemberResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/api/v1/members\x12i\n" +
+	"\fUpdateMember\x12\x1d.referral.UpdateMemberRequest\x1a\x1e.referral.UpdateMemberResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\x1a\x0f/api/v1/members\x12\x85\x01\n" +
+	"\x10DeactivateMember\x12!.referral.DeactivateMemberRequest\x1a\".referral.DeactivateMemberResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/members/{id}/deactivate\x12\x85\x01\n" +
+	"\x0fClaimVanityCode\x12 .referral.ClaimVanityCodeRequest\x1a!.referral.ClaimVanityCodeResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/members/{id}/referral-code\x12\x8d\x01\n" +
+	"\x0fAddReferralCode\x12 .referral.AddReferralCodeRequest\x1a!.referral.AddReferralCodeResponse\"5\x82\xd3\xe4\x93\x02/:\x01*\"*/api/v1/members/{member_id}/referral-codes\x12\x8d\x01\n" +
+	"\x10GetReferralCodes\x12!.referral.GetReferralCodesRequest\x1a\".referral.GetReferralCodesResponse\"2\x82\xd3\xe4\x93\x02,\x12*/api/v1/members/{member_id}/referral-codes\x12\xa4\x01\n" +
+	"\x12RevokeReferralCode\x12#.referral.RevokeReferralCodeRequest\x1a$.referral.RevokeReferralCodeResponse\"C\x82\xd3\xe4\x93\x02=:\x01*\"8/api/v1/members/{member_id}/referral-codes/{code}/revoke\x12\x8b\x01\n" +
+	"\x14GenerateReferralLink\x12\x1d.referral.ReferralLinkWrapper\x1a&.referral.GenerateReferralLinkResponse\",\x82\xd3\xe4\x93\x02&:\freferrallink\"\x16/api/v1/referral-links\x12n\n" +
+	"\rGetClickStats\x12\x1e.referral.GetClickStatsRequest\x1a\x1f.referral.GetClickStatsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/clicks/stats\x12h\n" +
 	"\fGetReferrals\x12\x1d.referral.GetReferralsRequest\x1a\x1e.referral.GetReferralsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/referrals\x12h\n" +
-	"\vAddReferral\x12\x1c.referral.AddReferralRequest\x1a\x1d.referral.AddReferralResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/referralsB\xfc\x01\x92A\xd7\x01\x12q\n" +
+	"\vAddReferral\x12\x1c.referral.AddReferralRequest\x1a\x1d.referral.AddReferralResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/referrals\x12\x81\x01\n" +
+	"\x0fQualifyReferral\x12 .referral.QualifyReferralRequest\x1a!.referral.QualifyReferralResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/api/v1/referrals/{id}/qualify\x12\x81\x01\n" +
+	"\x0fApproveReferral\x12 .referral.ApproveReferralRequest\x1a!.referral.ApproveReferralResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/api/v1/referrals/{id}/approve\x12u\n" +
+	"\fDenyReferral\x12\x1d.referral.DenyReferralRequest\x1a\x1e.referral.DenyReferralResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/referrals/{id}/deny\x12{\n" +
+	"\x0fReviewReferrals\x12 .referral.ReviewReferralsRequest\x1a!.referral.ReviewReferralsResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/referrals/review\x12q\n" +
+	"\x0eGetReviewQueue\x12\x1f.referral.GetReviewQueueRequest\x1a .referral.GetReviewQueueResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/review-queue\x12y\n" +
+	"\rClaimReferral\x12\x1e.referral.ClaimReferralRequest\x1a\x1f.referral.ClaimReferralResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/referrals/{id}/claim\x12\x81\x01\n" +
+	"\x0fReleaseReferral\x12 .referral.ReleaseReferralRequest\x1a!.referral.ReleaseReferralResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/api/v1/referrals/{id}/release\x12u\n" +
+	"\fFlagReferral\x12\x1d.referral.FlagReferralRequest\x1a\x1e.referral.FlagReferralResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/referrals/{id}/flag\x12\x7f\n" +
+	"\x0fAddReferralNote\x12 .referral.AddReferralNoteRequest\x1a!.referral.AddReferralNoteResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/referrals/{id}/notes\x12\x7f\n" +
+	"\x10GetReferralNotes\x12!.referral.GetReferralNotesRequest\x1a\".referral.GetReferralNotesResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/referrals/{id}/notes\x12\x8c\x01\n" +
+	"\x15IngestConversionEvent\x12&.referral.IngestConversionEventRequest\x1a'.referral.IngestConversionEventResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x05event\"\x13/api/v1/conversions\x12\x91\x01\n" +
+	"\x16IngestConversionEvents\x12'.referral.IngestConversionEventsRequest\x1a(.referral.IngestConversionEventsResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/conversions/batch\x12\x8e\x01\n" +
+	"\x10GetRewardBalance\x12!.referral.GetRewardBalanceRequest\x1a\".referral.GetRewardBalanceResponse\"3\x82\xd3\xe4\x93\x02-\x12+/api/v1/members/{member_id}/rewards/balance\x12t\n" +
+	"\n" +
+	"GetRewards\x12\x1b.referral.GetRewardsRequest\x1a\x1c.referral.GetRewardsResponse\"+\x82\xd3\xe4\x93\x02%\x12#/api/v1/members/{member_id}/rewards\x12y\n" +
+	"\rReverseReward\x12\x1e.referral.ReverseRewardRequest\x1a\x1f.referral.ReverseRewardResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/rewards/{id}/reverseB\xfc\x01\x92A\xd7\x01\x12q\n" +
 	"\x0fReferralService\x12#Referral Service openapi definition\"4\n" +
 	"\x0egrpc-with-rest\x12\"https://github.com/ReferralService2\x031.0*\x01\x022\x10application/json:\x10application/jsonR;\n" +
 	"\x03404\x124\n" +
@@ -1463,59 +5508,200 @@ func file_referral_referral_proto_rawDescGZIP() []byte {
 	return file_referral_referral_proto_rawDescData
 }
 
-var file_referral_referral_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_referral_referral_proto_msgTypes = make([]protoimpl.MessageInfo, 80)
 var file_referral_referral_proto_goTypes = []any{
-	(*GenerateReferralLinkRequest)(nil),  // 0: referral.GenerateReferralLinkRequest
-	(*GenerateReferralLinkResponse)(nil), // 1: referral.GenerateReferralLinkResponse
-	(*ReferralLinkWrapper)(nil),          // 2: referral.ReferralLinkWrapper
-	(*Program)(nil),                      // 3: referral.Program
-	(*AddProgramRequest)(nil),            // 4: referral.AddProgramRequest
-	(*AddProgramResponse)(nil),           // 5: referral.AddProgramResponse
-	(*UpdateProgramRequest)(nil),         // 6: referral.UpdateProgramRequest
-	(*UpdagteProgramResponse)(nil),       // 7: referral.UpdagteProgramResponse
-	(*GetProgramsRequest)(nil),           // 8: referral.GetProgramsRequest
-	(*GetProgramsResponse)(nil),          // 9: referral.GetProgramsResponse
-	(*GetProgramRequest)(nil),            // 10: referral.GetProgramRequest
-	(*GetProgramResponse)(nil),           // 11: referral.GetProgramResponse
-	(*Member)(nil),                       // 12: referral.Member
-	(*GetMembersRequest)(nil),            // 13: referral.GetMembersRequest
-	(*GetMembersResponse)(nil),           // 14: referral.GetMembersResponse
-	(*AddMemberRequest)(nil),             // 15: referral.AddMemberRequest
-	(*AddMemberResponse)(nil),            // 16: referral.AddMemberResponse
-	(*Referral)(nil),                     // 17: referral.Referral
-	(*AddReferralRequest)(nil),           // 18: referral.AddReferralRequest
-	(*AddReferralResponse)(nil),          // 19: referral.AddReferralResponse
-	(*GetReferralsRequest)(nil),          // 20: referral.GetReferralsRequest
-	(*GetReferralsResponse)(nil),         // 21: referral.GetReferralsResponse
+	(*GenerateReferralLinkRequest)(nil),     // 0: referral.GenerateReferralLinkRequest
+	(*GenerateReferralLinkResponse)(nil),    // 1: referral.GenerateReferralLinkResponse
+	(*ReferralLinkWrapper)(nil),             // 2: referral.ReferralLinkWrapper
+	(*GetClickStatsRequest)(nil),            // 3: referral.GetClickStatsRequest
+	(*GetClickStatsResponse)(nil),           // 4: referral.GetClickStatsResponse
+	(*RewardPolicy)(nil),                    // 5: referral.RewardPolicy
+	(*QualificationRule)(nil),               // 6: referral.QualificationRule
+	(*Program)(nil),                         // 7: referral.Program
+	(*AddProgramRequest)(nil),               // 8: referral.AddProgramRequest
+	(*AddProgramResponse)(nil),              // 9: referral.AddProgramResponse
+	(*UpdateProgramRequest)(nil),            // 10: referral.UpdateProgramRequest
+	(*UpdagteProgramResponse)(nil),          // 11: referral.UpdagteProgramResponse
+	(*GetProgramsRequest)(nil),              // 12: referral.GetProgramsRequest
+	(*GetProgramsResponse)(nil),             // 13: referral.GetProgramsResponse
+	(*GetProgramRequest)(nil),               // 14: referral.GetProgramRequest
+	(*GetProgramResponse)(nil),              // 15: referral.GetProgramResponse
+	(*Member)(nil),                          // 16: referral.Member
+	(*GetMembersRequest)(nil),               // 17: referral.GetMembersRequest
+	(*GetMembersResponse)(nil),              // 18: referral.GetMembersResponse
+	(*UpdateMemberRequest)(nil),             // 19: referral.UpdateMemberRequest
+	(*UpdateMemberResponse)(nil),            // 20: referral.UpdateMemberResponse
+	(*DeactivateMemberRequest)(nil),         // 21: referral.DeactivateMemberRequest
+	(*DeactivateMemberResponse)(nil),        // 22: referral.DeactivateMemberResponse
+	(*ClaimVanityCodeRequest)(nil),          // 23: referral.ClaimVanityCodeRequest
+	(*ClaimVanityCodeResponse)(nil),         // 24: referral.ClaimVanityCodeResponse
+	(*ReferralCode)(nil),                    // 25: referral.ReferralCode
+	(*AddReferralCodeRequest)(nil),          // 26: referral.AddReferralCodeRequest
+	(*AddReferralCodeResponse)(nil),         // 27: referral.AddReferralCodeResponse
+	(*GetReferralCodesRequest)(nil),         // 28: referral.GetReferralCodesRequest
+	(*GetReferralCodesResponse)(nil),        // 29: referral.GetReferralCodesResponse
+	(*RevokeReferralCodeRequest)(nil),       // 30: referral.RevokeReferralCodeRequest
+	(*RevokeReferralCodeResponse)(nil),      // 31: referral.RevokeReferralCodeResponse
+	(*GetMemberRequest)(nil),                // 32: referral.GetMemberRequest
+	(*GetMemberResponse)(nil),               // 33: referral.GetMemberResponse
+	(*GetMemberByEmailRequest)(nil),         // 34: referral.GetMemberByEmailRequest
+	(*GetMemberByEmailResponse)(nil),        // 35: referral.GetMemberByEmailResponse
+	(*GetMemberByReferralCodeRequest)(nil),  // 36: referral.GetMemberByReferralCodeRequest
+	(*GetMemberByReferralCodeResponse)(nil), // 37: referral.GetMemberByReferralCodeResponse
+	(*AddMemberRequest)(nil),                // 38: referral.AddMemberRequest
+	(*AddMemberResponse)(nil),               // 39: referral.AddMemberResponse
+	(*Referral)(nil),                        // 40: referral.Referral
+	(*AddReferralRequest)(nil),              // 41: referral.AddReferralRequest
+	(*AddReferralResponse)(nil),             // 42: referral.AddReferralResponse
+	(*GetReferralsRequest)(nil),             // 43: referral.GetReferralsRequest
+	(*GetReferralsResponse)(nil),            // 44: referral.GetReferralsResponse
+	(*QualifyReferralRequest)(nil),          // 45: referral.QualifyReferralRequest
+	(*QualifyReferralResponse)(nil),         // 46: referral.QualifyReferralResponse
+	(*ApproveReferralRequest)(nil),          // 47: referral.ApproveReferralRequest
+	(*ApproveReferralResponse)(nil),         // 48: referral.ApproveReferralResponse
+	(*DenyReferralRequest)(nil),             // 49: referral.DenyReferralRequest
+	(*DenyReferralResponse)(nil),            // 50: referral.DenyReferralResponse
+	(*ReviewReferralsRequest)(nil),          // 51: referral.ReviewReferralsRequest
+	(*ReviewReferralsResponse)(nil),         // 52: referral.ReviewReferralsResponse
+	(*GetReviewQueueRequest)(nil),           // 53: referral.GetReviewQueueRequest
+	(*GetReviewQueueResponse)(nil),          // 54: referral.GetReviewQueueResponse
+	(*ClaimReferralRequest)(nil),            // 55: referral.ClaimReferralRequest
+	(*ClaimReferralResponse)(nil),           // 56: referral.ClaimReferralResponse
+	(*ReleaseReferralRequest)(nil),          // 57: referral.ReleaseReferralRequest
+	(*ReleaseReferralResponse)(nil),         // 58: referral.ReleaseReferralResponse
+	(*FlagReferralRequest)(nil),             // 59: referral.FlagReferralRequest
+	(*FlagReferralResponse)(nil),            // 60: referral.FlagReferralResponse
+	(*ReferralNote)(nil),                    // 61: referral.ReferralNote
+	(*AddReferralNoteRequest)(nil),          // 62: referral.AddReferralNoteRequest
+	(*AddReferralNoteResponse)(nil),         // 63: referral.AddReferralNoteResponse
+	(*GetReferralNotesRequest)(nil),         // 64: referral.GetReferralNotesRequest
+	(*GetReferralNotesResponse)(nil),        // 65: referral.GetReferralNotesResponse
+	(*ConversionEvent)(nil),                 // 66: referral.ConversionEvent
+	(*ConversionResult)(nil),                // 67: referral.ConversionResult
+	(*IngestConversionEventRequest)(nil),    // 68: referral.IngestConversionEventRequest
+	(*IngestConversionEventResponse)(nil),   // 69: referral.IngestConversionEventResponse
+	(*IngestConversionEventsRequest)(nil),   // 70: referral.IngestConversionEventsRequest
+	(*IngestConversionEventsResponse)(nil),  // 71: referral.IngestConversionEventsResponse
+	(*Reward)(nil),                          // 72: referral.Reward
+	(*RewardBalance)(nil),                   // 73: referral.RewardBalance
+	(*GetRewardBalanceRequest)(nil),         // 74: referral.GetRewardBalanceRequest
+	(*GetRewardBalanceResponse)(nil),        // 75: referral.GetRewardBalanceResponse
+	(*GetRewardsRequest)(nil),               // 76: referral.GetRewardsRequest
+	(*GetRewardsResponse)(nil),              // 77: referral.GetRewardsResponse
+	(*ReverseRewardRequest)(nil),            // 78: referral.ReverseRewardRequest
+	(*ReverseRewardResponse)(nil),           // 79: referral.ReverseRewardResponse
 }
 var file_referral_referral_proto_depIdxs = []int32{
 	0,  // 0: referral.ReferralLinkWrapper.referrallink:type_name -> referral.GenerateReferralLinkRequest
-	3,  // 1: referral.UpdagteProgramResponse.program:type_name -> referral.Program
-	3,  // 2: referral.GetProgramsResponse.programs:type_name -> referral.Program
-	3,  // 3: referral.GetProgramResponse.program:type_name -> referral.Program
-	12, // 4: referral.GetMembersResponse.members:type_name -> referral.Member
-	17, // 5: referral.GetReferralsResponse.referrals:type_name -> referral.Referral
-	8,  // 6: referral.referral_service.GetPrograms:input_type -> referral.GetProgramsRequest
-	10, // 7: referral.referral_service.GetProgram:input_type -> referral.GetProgramRequest
-	4,  // 8: referral.referral_service.AddProgram:input_type -> referral.AddProgramRequest
-	6,  // 9: referral.referral_service.UpdateProgram:input_type -> referral.UpdateProgramRequest
-	13, // 10: referral.referral_service.GetMembers:input_type -> referral.GetMembersRequest
-	15, // 11: referral.referral_service.AddMember:input_type -> referral.AddMemberRequest
-	20, // 12: referral.referral_service.GetReferrals:input_type -> referral.GetReferralsRequest
-	18, // 13: referral.referral_service.AddReferral:input_type -> referral.AddReferralRequest
-	9,  // 14: referral.referral_service.GetPrograms:output_type -> referral.GetProgramsResponse
-	11, // 15: referral.referral_service.GetProgram:output_type -> referral.GetProgramResponse
-	5,  // 16: referral.referral_service.AddProgram:output_type -> referral.AddProgramResponse
-	7,  // 17: referral.referral_service.UpdateProgram:output_type -> referral.UpdagteProgramResponse
-	14, // 18: referral.referral_service.GetMembers:output_type -> referral.GetMembersResponse
-	16, // 19: referral.referral_service.AddMember:output_type -> referral.AddMemberResponse
-	21, // 20: referral.referral_service.GetReferrals:output_type -> referral.GetReferralsResponse
-	19, // 21: referral.referral_service.AddReferral:output_type -> referral.AddReferralResponse
-	14, // [14:22] is the sub-list for method output_type
-	6,  // [6:14] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	5,  // 1: referral.Program.reward_policy:type_name -> referral.RewardPolicy
+	6,  // 2: referral.Program.qualification:type_name -> referral.QualificationRule
+	5,  // 3: referral.AddProgramRequest.reward_policy:type_name -> referral.RewardPolicy
+	6,  // 4: referral.AddProgramRequest.qualification:type_name -> referral.QualificationRule
+	5,  // 5: referral.UpdateProgramRequest.reward_policy:type_name -> referral.RewardPolicy
+	6,  // 6: referral.UpdateProgramRequest.qualification:type_name -> referral.QualificationRule
+	7,  // 7: referral.UpdagteProgramResponse.program:type_name -> referral.Program
+	7,  // 8: referral.GetProgramsResponse.programs:type_name -> referral.Program
+	7,  // 9: referral.GetProgramResponse.program:type_name -> referral.Program
+	16, // 10: referral.GetMembersResponse.members:type_name -> referral.Member
+	16, // 11: referral.UpdateMemberResponse.member:type_name -> referral.Member
+	16, // 12: referral.DeactivateMemberResponse.member:type_name -> referral.Member
+	16, // 13: referral.ClaimVanityCodeResponse.member:type_name -> referral.Member
+	25, // 14: referral.AddReferralCodeResponse.referral_code:type_name -> referral.ReferralCode
+	25, // 15: referral.GetReferralCodesResponse.referral_codes:type_name -> referral.ReferralCode
+	25, // 16: referral.RevokeReferralCodeResponse.referral_code:type_name -> referral.ReferralCode
+	16, // 17: referral.GetMemberResponse.member:type_name -> referral.Member
+	16, // 18: referral.GetMemberByEmailResponse.member:type_name -> referral.Member
+	16, // 19: referral.GetMemberByReferralCodeResponse.member:type_name -> referral.Member
+	40, // 20: referral.GetReferralsResponse.referrals:type_name -> referral.Referral
+	40, // 21: referral.QualifyReferralResponse.referral:type_name -> referral.Referral
+	40, // 22: referral.ApproveReferralResponse.referral:type_name -> referral.Referral
+	40, // 23: referral.DenyReferralResponse.referral:type_name -> referral.Referral
+	40, // 24: referral.ReviewReferralsResponse.referrals:type_name -> referral.Referral
+	40, // 25: referral.GetReviewQueueResponse.referrals:type_name -> referral.Referral
+	40, // 26: referral.ClaimReferralResponse.referral:type_name -> referral.Referral
+	40, // 27: referral.ReleaseReferralResponse.referral:type_name -> referral.Referral
+	40, // 28: referral.FlagReferralResponse.referral:type_name -> referral.Referral
+	61, // 29: referral.GetReferralNotesResponse.notes:type_name -> referral.ReferralNote
+	66, // 30: referral.IngestConversionEventRequest.event:type_name -> referral.ConversionEvent
+	67, // 31: referral.IngestConversionEventResponse.result:type_name -> referral.ConversionResult
+	66, // 32: referral.IngestConversionEventsRequest.events:type_name -> referral.ConversionEvent
+	67, // 33: referral.IngestConversionEventsResponse.results:type_name -> referral.ConversionResult
+	73, // 34: referral.GetRewardBalanceResponse.balances:type_name -> referral.RewardBalance
+	72, // 35: referral.GetRewardsResponse.rewards:type_name -> referral.Reward
+	72, // 36: referral.ReverseRewardResponse.reward:type_name -> referral.Reward
+	12, // 37: referral.referral_service.GetPrograms:input_type -> referral.GetProgramsRequest
+	14, // 38: referral.referral_service.GetProgram:input_type -> referral.GetProgramRequest
+	8,  // 39: referral.referral_service.AddProgram:input_type -> referral.AddProgramRequest
+	10, // 40: referral.referral_service.UpdateProgram:input_type -> referral.UpdateProgramRequest
+	17, // 41: referral.referral_service.GetMembers:input_type -> referral.GetMembersRequest
+	32, // 42: referral.referral_service.GetMember:input_type -> referral.GetMemberRequest
+	34, // 43: referral.referral_service.GetMemberByEmail:input_type -> referral.GetMemberByEmailRequest
+	36, // 44: referral.referral_service.GetMemberByReferralCode:input_type -> referral.GetMemberByReferralCodeRequest
+	38, // 45: referral.referral_service.AddMember:input_type -> referral.AddMemberRequest
+	19, // 46: referral.referral_service.UpdateMember:input_type -> referral.UpdateMemberRequest
+	21, // 47: referral.referral_service.DeactivateMember:input_type -> referral.DeactivateMemberRequest
+	23, // 48: referral.referral_service.ClaimVanityCode:input_type -> referral.ClaimVanityCodeRequest
+	26, // 49: referral.referral_service.AddReferralCode:input_type -> referral.AddReferralCodeRequest
+	28, // 50: referral.referral_service.GetReferralCodes:input_type -> referral.GetReferralCodesRequest
+	30, // 51: referral.referral_service.RevokeReferralCode:input_type -> referral.RevokeReferralCodeRequest
+	2,  // 52: referral.referral_service.GenerateReferralLink:input_type -> referral.ReferralLinkWrapper
+	3,  // 53: referral.referral_service.GetClickStats:input_type -> referral.GetClickStatsRequest
+	43, // 54: referral.referral_service.GetReferrals:input_type -> referral.GetReferralsRequest
+	41, // 55: referral.referral_service.AddReferral:input_type -> referral.AddReferralRequest
+	45, // 56: referral.referral_service.QualifyReferral:input_type -> referral.QualifyReferralRequest
+	47, // 57: referral.referral_service.ApproveReferral:input_type -> referral.ApproveReferralRequest
+	49, // 58: referral.referral_service.DenyReferral:input_type -> referral.DenyReferralRequest
+	51, // 59: referral.referral_service.ReviewReferrals:input_type -> referral.ReviewReferralsRequest
+	53, // 60: referral.referral_service.GetReviewQueue:input_type -> referral.GetReviewQueueRequest
+	55, // 61: referral.referral_service.ClaimReferral:input_type -> referral.ClaimReferralRequest
+	57, // 62: referral.referral_service.ReleaseReferral:input_type -> referral.ReleaseReferralRequest
+	59, // 63: referral.referral_service.FlagReferral:input_type -> referral.FlagReferralRequest
+	62, // 64: referral.referral_service.AddReferralNote:input_type -> referral.AddReferralNoteRequest
+	64, // 65: referral.referral_service.GetReferralNotes:input_type -> referral.GetReferralNotesRequest
+	68, // 66: referral.referral_service.IngestConversionEvent:input_type -> referral.IngestConversionEventRequest
+	70, // 67: referral.referral_service.IngestConversionEvents:input_type -> referral.IngestConversionEventsRequest
+	74, // 68: referral.referral_service.GetRewardBalance:input_type -> referral.GetRewardBalanceRequest
+	76, // 69: referral.referral_service.GetRewards:input_type -> referral.GetRewardsRequest
+	78, // 70: referral.referral_service.ReverseReward:input_type -> referral.ReverseRewardRequest
+	13, // 71: referral.referral_service.GetPrograms:output_type -> referral.GetProgramsResponse
+	15, // 72: referral.referral_service.GetProgram:output_type -> referral.GetProgramResponse
+	9,  // 73: referral.referral_service.AddProgram:output_type -> referral.AddProgramResponse
+	11, // 74: referral.referral_service.UpdateProgram:output_type -> referral.UpdagteProgramResponse
+	18, // 75: referral.referral_service.GetMembers:output_type -> referral.GetMembersResponse
+	33, // 76: referral.referral_service.GetMember:output_type -> referral.GetMemberResponse
+	35, // 77: referral.referral_service.GetMemberByEmail:output_type -> referral.GetMemberByEmailResponse
+	37, // 78: referral.referral_service.GetMemberByReferralCode:output_type -> referral.GetMemberByReferralCodeResponse
+	39, // 79: referral.referral_service.AddMember:output_type -> referral.AddMemberResponse
+	20, // 80: referral.referral_service.UpdateMember:output_type -> referral.UpdateMemberResponse
+	22, // 81: referral.referral_service.DeactivateMember:output_type -> referral.DeactivateMemberResponse
+	24, // 82: referral.referral_service.ClaimVanityCode:output_type -> referral.ClaimVanityCodeResponse
+	27, // 83: referral.referral_service.AddReferralCode:output_type -> referral.AddReferralCodeResponse
+	29, // 84: referral.referral_service.GetReferralCodes:output_type -> referral.GetReferralCodesResponse
+	31, // 85: referral.referral_service.RevokeReferralCode:output_type -> referral.RevokeReferralCodeResponse
+	1,  // 86: referral.referral_service.GenerateReferralLink:output_type -> referral.GenerateReferralLinkResponse
+	4,  // 87: referral.referral_service.GetClickStats:output_type -> referral.GetClickStatsResponse
+	44, // 88: referral.referral_service.GetReferrals:output_type -> referral.GetReferralsResponse
+	42, // 89: referral.referral_service.AddReferral:output_type -> referral.AddReferralResponse
+	46, // 90: referral.referral_service.QualifyReferral:output_type -> referral.QualifyReferralResponse
+	48, // 91: referral.referral_service.ApproveReferral:output_type -> referral.ApproveReferralResponse
+	50, // 92: referral.referral_service.DenyReferral:output_type -> referral.DenyReferralResponse
+	52, // 93: referral.referral_service.ReviewReferrals:output_type -> referral.ReviewReferralsResponse
+	54, // 94: referral.referral_service.GetReviewQueue:output_type -> referral.GetReviewQueueResponse
+	56, // 95: referral.referral_service.ClaimReferral:output_type -> referral.ClaimReferralResponse
+	58, // 96: referral.referral_service.ReleaseReferral:output_type -> referral.ReleaseReferralResponse
+	60, // 97: referral.referral_service.FlagReferral:output_type -> referral.FlagReferralResponse
+	63, // 98: referral.referral_service.AddReferralNote:output_type -> referral.AddReferralNoteResponse
+	65, // 99: referral.referral_service.GetReferralNotes:output_type -> referral.GetReferralNotesResponse
+	69, // 100: referral.referral_service.IngestConversionEvent:output_type -> referral.IngestConversionEventResponse
+	71, // 101: referral.referral_service.IngestConversionEvents:output_type -> referral.IngestConversionEventsResponse
+	75, // 102: referral.referral_service.GetRewardBalance:output_type -> referral.GetRewardBalanceResponse
+	77, // 103: referral.referral_service.GetRewards:output_type -> referral.GetRewardsResponse
+	79, // 104: referral.referral_service.ReverseReward:output_type -> referral.ReverseRewardResponse
+	71, // [71:105] is the sub-list for method output_type
+	37, // [37:71] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_referral_referral_proto_init() }
@@ -1523,19 +5709,28 @@ func file_referral_referral_proto_init() {
 	if File_referral_referral_proto != nil {
 		return
 	}
-	file_referral_referral_proto_msgTypes[6].OneofWrappers = []any{}
-	file_referral_referral_proto_msgTypes[8].OneofWrappers = []any{}
-	file_referral_referral_proto_msgTypes[13].OneofWrappers = []any{}
-	file_referral_referral_proto_msgTypes[15].OneofWrappers = []any{}
-	file_referral_referral_proto_msgTypes[18].OneofWrappers = []any{}
-	file_referral_referral_proto_msgTypes[20].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[0].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[3].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[10].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[12].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[17].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[19].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[26].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[34].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[38].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[41].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[43].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[47].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[53].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[66].OneofWrappers = []any{}
+	file_referral_referral_proto_msgTypes[76].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_referral_referral_proto_rawDesc), len(file_referral_referral_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   22,
+			NumMessages:   80,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
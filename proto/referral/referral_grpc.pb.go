@@ -19,14 +19,40 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ReferralService_GetPrograms_FullMethodName   = "/referral.referral_service/GetPrograms"
-	ReferralService_GetProgram_FullMethodName    = "/referral.referral_service/GetProgram"
-	ReferralService_AddProgram_FullMethodName    = "/referral.referral_service/AddProgram"
-	ReferralService_UpdateProgram_FullMethodName = "/referral.referral_service/UpdateProgram"
-	ReferralService_GetMembers_FullMethodName    = "/referral.referral_service/GetMembers"
-	ReferralService_AddMember_FullMethodName     = "/referral.referral_service/AddMember"
-	ReferralService_GetReferrals_FullMethodName  = "/referral.referral_service/GetReferrals"
-	ReferralService_AddReferral_FullMethodName   = "/referral.referral_service/AddReferral"
+	ReferralService_GetPrograms_FullMethodName             = "/referral.referral_service/GetPrograms"
+	ReferralService_GetProgram_FullMethodName              = "/referral.referral_service/GetProgram"
+	ReferralService_AddProgram_FullMethodName              = "/referral.referral_service/AddProgram"
+	ReferralService_UpdateProgram_FullMethodName           = "/referral.referral_service/UpdateProgram"
+	ReferralService_GetMembers_FullMethodName              = "/referral.referral_service/GetMembers"
+	ReferralService_GetMember_FullMethodName               = "/referral.referral_service/GetMember"
+	ReferralService_GetMemberByEmail_FullMethodName        = "/referral.referral_service/GetMemberByEmail"
+	ReferralService_GetMemberByReferralCode_FullMethodName = "/referral.referral_service/GetMemberByReferralCode"
+	ReferralService_AddMember_FullMethodName               = "/referral.referral_service/AddMember"
+	ReferralService_UpdateMember_FullMethodName            = "/referral.referral_service/UpdateMember"
+	ReferralService_DeactivateMember_FullMethodName        = "/referral.referral_service/DeactivateMember"
+	ReferralService_ClaimVanityCode_FullMethodName         = "/referral.referral_service/ClaimVanityCode"
+	ReferralService_AddReferralCode_FullMethodName         = "/referral.referral_service/AddReferralCode"
+	ReferralService_GetReferralCodes_FullMethodName        = "/referral.referral_service/GetReferralCodes"
+	ReferralService_RevokeReferralCode_FullMethodName      = "/referral.referral_service/RevokeReferralCode"
+	ReferralService_GenerateReferralLink_FullMethodName    = "/referral.referral_service/GenerateReferralLink"
+	ReferralService_GetClickStats_FullMethodName           = "/referral.referral_service/GetClickStats"
+	ReferralService_GetReferrals_FullMethodName            = "/referral.referral_service/GetReferrals"
+	ReferralService_AddReferral_FullMethodName             = "/referral.referral_service/AddReferral"
+	ReferralService_QualifyReferral_FullMethodName         = "/referral.referral_service/QualifyReferral"
+	ReferralService_ApproveReferral_FullMethodName         = "/referral.referral_service/ApproveReferral"
+	ReferralService_DenyReferral_FullMethodName            = "/referral.referral_service/DenyReferral"
+	ReferralService_ReviewReferrals_FullMethodName         = "/referral.referral_service/ReviewReferrals"
+	ReferralService_GetReviewQueue_FullMethodName          = "/referral.referral_service/GetReviewQueue"
+	ReferralService_ClaimReferral_FullMethodName           = "/referral.referral_service/ClaimReferral"
+	ReferralService_ReleaseReferral_FullMethodName         = "/referral.referral_service/ReleaseReferral"
+	ReferralService_FlagReferral_FullMethodName            = "/referral.referral_service/FlagReferral"
+	ReferralService_AddReferralNote_FullMethodName         = "/referral.referral_service/AddReferralNote"
+	ReferralService_GetReferralNotes_FullMethodName        = "/referral.referral_service/GetReferralNotes"
+	ReferralService_IngestConversionEvent_FullMethodName   = "/referral.referral_service/IngestConversionEvent"
+	ReferralService_IngestConversionEvents_FullMethodName  = "/referral.referral_service/IngestConversionEvents"
+	ReferralService_GetRewardBalance_FullMethodName        = "/referral.referral_service/GetRewardBalance"
+	ReferralService_GetRewards_FullMethodName              = "/referral.referral_service/GetRewards"
+	ReferralService_ReverseReward_FullMethodName           = "/referral.referral_service/ReverseReward"
 )
 
 // ReferralServiceClient is the client API for ReferralService service.
@@ -40,10 +66,43 @@ type ReferralServiceClient interface {
 	UpdateProgram(ctx context.Context, in *UpdateProgramRequest, opts ...grpc.CallOption) (*UpdagteProgramResponse, error)
 	// Program Membership apis
 	GetMembers(ctx context.Context, in *GetMembersRequest, opts ...grpc.CallOption) (*GetMembersResponse, error)
+	GetMember(ctx context.Context, in *GetMemberRequest, opts ...grpc.CallOption) (*GetMemberResponse, error)
+	GetMemberByEmail(ctx context.Context, in *GetMemberByEmailRequest, opts ...grpc.CallOption) (*GetMemberByEmailResponse, error)
+	GetMemberByReferralCode(ctx context.Context, in *GetMemberByReferralCodeRequest, opts ...grpc.CallOption) (*GetMemberByReferralCodeResponse, error)
 	AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error)
+	UpdateMember(ctx context.Context, in *UpdateMemberRequest, opts ...grpc.CallOption) (*UpdateMemberResponse, error)
+	DeactivateMember(ctx context.Context, in *DeactivateMemberRequest, opts ...grpc.CallOption) (*DeactivateMemberResponse, error)
+	ClaimVanityCode(ctx context.Context, in *ClaimVanityCodeRequest, opts ...grpc.CallOption) (*ClaimVanityCodeResponse, error)
+	// Member referral code apis
+	AddReferralCode(ctx context.Context, in *AddReferralCodeRequest, opts ...grpc.CallOption) (*AddReferralCodeResponse, error)
+	GetReferralCodes(ctx context.Context, in *GetReferralCodesRequest, opts ...grpc.CallOption) (*GetReferralCodesResponse, error)
+	RevokeReferralCode(ctx context.Context, in *RevokeReferralCodeRequest, opts ...grpc.CallOption) (*RevokeReferralCodeResponse, error)
+	// Member referral link apis
+	GenerateReferralLink(ctx context.Context, in *ReferralLinkWrapper, opts ...grpc.CallOption) (*GenerateReferralLinkResponse, error)
+	GetClickStats(ctx context.Context, in *GetClickStatsRequest, opts ...grpc.CallOption) (*GetClickStatsResponse, error)
 	// Member referrals apis
 	GetReferrals(ctx context.Context, in *GetReferralsRequest, opts ...grpc.CallOption) (*GetReferralsResponse, error)
 	AddReferral(ctx context.Context, in *AddReferralRequest, opts ...grpc.CallOption) (*AddReferralResponse, error)
+	// Referral status lifecycle apis
+	QualifyReferral(ctx context.Context, in *QualifyReferralRequest, opts ...grpc.CallOption) (*QualifyReferralResponse, error)
+	ApproveReferral(ctx context.Context, in *ApproveReferralRequest, opts ...grpc.CallOption) (*ApproveReferralResponse, error)
+	DenyReferral(ctx context.Context, in *DenyReferralRequest, opts ...grpc.CallOption) (*DenyReferralResponse, error)
+	// approves or denies several referrals, all or none of them.
+	ReviewReferrals(ctx context.Context, in *ReviewReferralsRequest, opts ...grpc.CallOption) (*ReviewReferralsResponse, error)
+	// Manual review apis
+	GetReviewQueue(ctx context.Context, in *GetReviewQueueRequest, opts ...grpc.CallOption) (*GetReviewQueueResponse, error)
+	ClaimReferral(ctx context.Context, in *ClaimReferralRequest, opts ...grpc.CallOption) (*ClaimReferralResponse, error)
+	ReleaseReferral(ctx context.Context, in *ReleaseReferralRequest, opts ...grpc.CallOption) (*ReleaseReferralResponse, error)
+	FlagReferral(ctx context.Context, in *FlagReferralRequest, opts ...grpc.CallOption) (*FlagReferralResponse, error)
+	AddReferralNote(ctx context.Context, in *AddReferralNoteRequest, opts ...grpc.CallOption) (*AddReferralNoteResponse, error)
+	GetReferralNotes(ctx context.Context, in *GetReferralNotesRequest, opts ...grpc.CallOption) (*GetReferralNotesResponse, error)
+	// Conversion event apis
+	IngestConversionEvent(ctx context.Context, in *IngestConversionEventRequest, opts ...grpc.CallOption) (*IngestConversionEventResponse, error)
+	IngestConversionEvents(ctx context.Context, in *IngestConversionEventsRequest, opts ...grpc.CallOption) (*IngestConversionEventsResponse, error)
+	// Member reward ledger apis
+	GetRewardBalance(ctx context.Context, in *GetRewardBalanceRequest, opts ...grpc.CallOption) (*GetRewardBalanceResponse, error)
+	GetRewards(ctx context.Context, in *GetRewardsRequest, opts ...grpc.CallOption) (*GetRewardsResponse, error)
+	ReverseReward(ctx context.Context, in *ReverseRewardRequest, opts ...grpc.CallOption) (*ReverseRewardResponse, error)
 }
 
 type referralServiceClient struct {
@@ -104,6 +163,36 @@ func (c *referralServiceClient) GetMembers(ctx context.Context, in *GetMembersRe
 	return out, nil
 }
 
+func (c *referralServiceClient) GetMember(ctx context.Context, in *GetMemberRequest, opts ...grpc.CallOption) (*GetMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMemberResponse)
+	err := c.cc.Invoke(ctx, ReferralService_GetMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) GetMemberByEmail(ctx context.Context, in *GetMemberByEmailRequest, opts ...grpc.CallOption) (*GetMemberByEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMemberByEmailResponse)
+	err := c.cc.Invoke(ctx, ReferralService_GetMemberByEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) GetMemberByReferralCode(ctx context.Context, in *GetMemberByReferralCodeRequest, opts ...grpc.CallOption) (*GetMemberByReferralCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMemberByReferralCodeResponse)
+	err := c.cc.Invoke(ctx, ReferralService_GetMemberByReferralCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *referralServiceClient) AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddMemberResponse)
@@ -114,6 +203,86 @@ func (c *referralServiceClient) AddMember(ctx context.Context, in *AddMemberRequ
 	return out, nil
 }
 
+func (c *referralServiceClient) UpdateMember(ctx context.Context, in *UpdateMemberRequest, opts ...grpc.CallOption) (*UpdateMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateMemberResponse)
+	err := c.cc.Invoke(ctx, ReferralService_UpdateMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) DeactivateMember(ctx context.Context, in *DeactivateMemberRequest, opts ...grpc.CallOption) (*DeactivateMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeactivateMemberResponse)
+	err := c.cc.Invoke(ctx, ReferralService_DeactivateMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) ClaimVanityCode(ctx context.Context, in *ClaimVanityCodeRequest, opts ...grpc.CallOption) (*ClaimVanityCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimVanityCodeResponse)
+	err := c.cc.Invoke(ctx, ReferralService_ClaimVanityCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) AddReferralCode(ctx context.Context, in *AddReferralCodeRequest, opts ...grpc.CallOption) (*AddReferralCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddReferralCodeResponse)
+	err := c.cc.Invoke(ctx, ReferralService_AddReferralCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) GetReferralCodes(ctx context.Context, in *GetReferralCodesRequest, opts ...grpc.CallOption) (*GetReferralCodesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReferralCodesResponse)
+	err := c.cc.Invoke(ctx, ReferralService_GetReferralCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) RevokeReferralCode(ctx context.Context, in *RevokeReferralCodeRequest, opts ...grpc.CallOption) (*RevokeReferralCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeReferralCodeResponse)
+	err := c.cc.Invoke(ctx, ReferralService_RevokeReferralCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) GenerateReferralLink(ctx context.Context, in *ReferralLinkWrapper, opts ...grpc.CallOption) (*GenerateReferralLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateReferralLinkResponse)
+	err := c.cc.Invoke(ctx, ReferralService_GenerateReferralLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) GetClickStats(ctx context.Context, in *GetClickStatsRequest, opts ...grpc.CallOption) (*GetClickStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetClickStatsResponse)
+	err := c.cc.Invoke(ctx, ReferralService_GetClickStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *referralServiceClient) GetReferrals(ctx context.Context, in *GetReferralsRequest, opts ...grpc.CallOption) (*GetReferralsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetReferralsResponse)
@@ -134,6 +303,156 @@ func (c *referralServiceClient) AddReferral(ctx context.Context, in *AddReferral
 	return out, nil
 }
 
+func (c *referralServiceClient) QualifyReferral(ctx context.Context, in *QualifyReferralRequest, opts ...grpc.CallOption) (*QualifyReferralResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QualifyReferralResponse)
+	err := c.cc.Invoke(ctx, ReferralService_QualifyReferral_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) ApproveReferral(ctx context.Context, in *ApproveReferralRequest, opts ...grpc.CallOption) (*ApproveReferralResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveReferralResponse)
+	err := c.cc.Invoke(ctx, ReferralService_ApproveReferral_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) DenyReferral(ctx context.Context, in *DenyReferralRequest, opts ...grpc.CallOption) (*DenyReferralResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DenyReferralResponse)
+	err := c.cc.Invoke(ctx, ReferralService_DenyReferral_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) ReviewReferrals(ctx context.Context, in *ReviewReferralsRequest, opts ...grpc.CallOption) (*ReviewReferralsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewReferralsResponse)
+	err := c.cc.Invoke(ctx, ReferralService_ReviewReferrals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) GetReviewQueue(ctx context.Context, in *GetReviewQueueRequest, opts ...grpc.CallOption) (*GetReviewQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReviewQueueResponse)
+	err := c.cc.Invoke(ctx, ReferralService_GetReviewQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) ClaimReferral(ctx context.Context, in *ClaimReferralRequest, opts ...grpc.CallOption) (*ClaimReferralResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimReferralResponse)
+	err := c.cc.Invoke(ctx, ReferralService_ClaimReferral_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) ReleaseReferral(ctx context.Context, in *ReleaseReferralRequest, opts ...grpc.CallOption) (*ReleaseReferralResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseReferralResponse)
+	err := c.cc.Invoke(ctx, ReferralService_ReleaseReferral_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) FlagReferral(ctx context.Context, in *FlagReferralRequest, opts ...grpc.CallOption) (*FlagReferralResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlagReferralResponse)
+	err := c.cc.Invoke(ctx, ReferralService_FlagReferral_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) AddReferralNote(ctx context.Context, in *AddReferralNoteRequest, opts ...grpc.CallOption) (*AddReferralNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddReferralNoteResponse)
+	err := c.cc.Invoke(ctx, ReferralService_AddReferralNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) GetReferralNotes(ctx context.Context, in *GetReferralNotesRequest, opts ...grpc.CallOption) (*GetReferralNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReferralNotesResponse)
+	err := c.cc.Invoke(ctx, ReferralService_GetReferralNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) IngestConversionEvent(ctx context.Context, in *IngestConversionEventRequest, opts ...grpc.CallOption) (*IngestConversionEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestConversionEventResponse)
+	err := c.cc.Invoke(ctx, ReferralService_IngestConversionEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) IngestConversionEvents(ctx context.Context, in *IngestConversionEventsRequest, opts ...grpc.CallOption) (*IngestConversionEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestConversionEventsResponse)
+	err := c.cc.Invoke(ctx, ReferralService_IngestConversionEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) GetRewardBalance(ctx context.Context, in *GetRewardBalanceRequest, opts ...grpc.CallOption) (*GetRewardBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRewardBalanceResponse)
+	err := c.cc.Invoke(ctx, ReferralService_GetRewardBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) GetRewards(ctx context.Context, in *GetRewardsRequest, opts ...grpc.CallOption) (*GetRewardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRewardsResponse)
+	err := c.cc.Invoke(ctx, ReferralService_GetRewards_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralServiceClient) ReverseReward(ctx context.Context, in *ReverseRewardRequest, opts ...grpc.CallOption) (*ReverseRewardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReverseRewardResponse)
+	err := c.cc.Invoke(ctx, ReferralService_ReverseReward_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReferralServiceServer is the server API for ReferralService service.
 // All implementations must embed UnimplementedReferralServiceServer
 // for forward compatibility.
@@ -145,10 +464,43 @@ type ReferralServiceServer interface {
 	UpdateProgram(context.Context, *UpdateProgramRequest) (*UpdagteProgramResponse, error)
 	// Program Membership apis
 	GetMembers(context.Context, *GetMembersRequest) (*GetMembersResponse, error)
+	GetMember(context.Context, *GetMemberRequest) (*GetMemberResponse, error)
+	GetMemberByEmail(context.Context, *GetMemberByEmailRequest) (*GetMemberByEmailResponse, error)
+	GetMemberByReferralCode(context.Context, *GetMemberByReferralCodeRequest) (*GetMemberByReferralCodeResponse, error)
 	AddMember(context.Context, *AddMemberRequest) (*AddMemberResponse, error)
+	UpdateMember(context.Context, *UpdateMemberRequest) (*UpdateMemberResponse, error)
+	DeactivateMember(context.Context, *DeactivateMemberRequest) (*DeactivateMemberResponse, error)
+	ClaimVanityCode(context.Context, *ClaimVanityCodeRequest) (*ClaimVanityCodeResponse, error)
+	// Member referral code apis
+	AddReferralCode(context.Context, *AddReferralCodeRequest) (*AddReferralCodeResponse, error)
+	GetReferralCodes(context.Context, *GetReferralCodesRequest) (*GetReferralCodesResponse, error)
+	RevokeReferralCode(context.Context, *RevokeReferralCodeRequest) (*RevokeReferralCodeResponse, error)
+	// Member referral link apis
+	GenerateReferralLink(context.Context, *ReferralLinkWrapper) (*GenerateReferralLinkResponse, error)
+	GetClickStats(context.Context, *GetClickStatsRequest) (*GetClickStatsResponse, error)
 	// Member referrals apis
 	GetReferrals(context.Context, *GetReferralsRequest) (*GetReferralsResponse, error)
 	AddReferral(context.Context, *AddReferralRequest) (*AddReferralResponse, error)
+	// Referral status lifecycle apis
+	QualifyReferral(context.Context, *QualifyReferralRequest) (*QualifyReferralResponse, error)
+	ApproveReferral(context.Context, *ApproveReferralRequest) (*ApproveReferralResponse, error)
+	DenyReferral(context.Context, *DenyReferralRequest) (*DenyReferralResponse, error)
+	// approves or denies several referrals, all or none of them.
+	ReviewReferrals(context.Context, *ReviewReferralsRequest) (*ReviewReferralsResponse, error)
+	// Manual review apis
+	GetReviewQueue(context.Context, *GetReviewQueueRequest) (*GetReviewQueueResponse, error)
+	ClaimReferral(context.Context, *ClaimReferralRequest) (*ClaimReferralResponse, error)
+	ReleaseReferral(context.Context, *ReleaseReferralRequest) (*ReleaseReferralResponse, error)
+	FlagReferral(context.Context, *FlagReferralRequest) (*FlagReferralResponse, error)
+	AddReferralNote(context.Context, *AddReferralNoteRequest) (*AddReferralNoteResponse, error)
+	GetReferralNotes(context.Context, *GetReferralNotesRequest) (*GetReferralNotesResponse, error)
+	// Conversion event apis
+	IngestConversionEvent(context.Context, *IngestConversionEventRequest) (*IngestConversionEventResponse, error)
+	IngestConversionEvents(context.Context, *IngestConversionEventsRequest) (*IngestConversionEventsResponse, error)
+	// Member reward ledger apis
+	GetRewardBalance(context.Context, *GetRewardBalanceRequest) (*GetRewardBalanceResponse, error)
+	GetRewards(context.Context, *GetRewardsRequest) (*GetRewardsResponse, error)
+	ReverseReward(context.Context, *ReverseRewardRequest) (*ReverseRewardResponse, error)
 	mustEmbedUnimplementedReferralServiceServer()
 }
 
@@ -174,15 +526,93 @@ func (UnimplementedReferralServiceServer) UpdateProgram(context.Context, *Update
 func (UnimplementedReferralServiceServer) GetMembers(context.Context, *GetMembersRequest) (*GetMembersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMembers not implemented")
 }
+func (UnimplementedReferralServiceServer) GetMember(context.Context, *GetMemberRequest) (*GetMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMember not implemented")
+}
+func (UnimplementedReferralServiceServer) GetMemberByEmail(context.Context, *GetMemberByEmailRequest) (*GetMemberByEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMemberByEmail not implemented")
+}
+func (UnimplementedReferralServiceServer) GetMemberByReferralCode(context.Context, *GetMemberByReferralCodeRequest) (*GetMemberByReferralCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMemberByReferralCode not implemented")
+}
 func (UnimplementedReferralServiceServer) AddMember(context.Context, *AddMemberRequest) (*AddMemberResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
 }
+func (UnimplementedReferralServiceServer) UpdateMember(context.Context, *UpdateMemberRequest) (*UpdateMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMember not implemented")
+}
+func (UnimplementedReferralServiceServer) DeactivateMember(context.Context, *DeactivateMemberRequest) (*DeactivateMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivateMember not implemented")
+}
+func (UnimplementedReferralServiceServer) ClaimVanityCode(context.Context, *ClaimVanityCodeRequest) (*ClaimVanityCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimVanityCode not implemented")
+}
+func (UnimplementedReferralServiceServer) AddReferralCode(context.Context, *AddReferralCodeRequest) (*AddReferralCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReferralCode not implemented")
+}
+func (UnimplementedReferralServiceServer) GetReferralCodes(context.Context, *GetReferralCodesRequest) (*GetReferralCodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReferralCodes not implemented")
+}
+func (UnimplementedReferralServiceServer) RevokeReferralCode(context.Context, *RevokeReferralCodeRequest) (*RevokeReferralCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeReferralCode not implemented")
+}
+func (UnimplementedReferralServiceServer) GenerateReferralLink(context.Context, *ReferralLinkWrapper) (*GenerateReferralLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateReferralLink not implemented")
+}
+func (UnimplementedReferralServiceServer) GetClickStats(context.Context, *GetClickStatsRequest) (*GetClickStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClickStats not implemented")
+}
 func (UnimplementedReferralServiceServer) GetReferrals(context.Context, *GetReferralsRequest) (*GetReferralsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetReferrals not implemented")
 }
 func (UnimplementedReferralServiceServer) AddReferral(context.Context, *AddReferralRequest) (*AddReferralResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddReferral not implemented")
 }
+func (UnimplementedReferralServiceServer) QualifyReferral(context.Context, *QualifyReferralRequest) (*QualifyReferralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QualifyReferral not implemented")
+}
+func (UnimplementedReferralServiceServer) ApproveReferral(context.Context, *ApproveReferralRequest) (*ApproveReferralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveReferral not implemented")
+}
+func (UnimplementedReferralServiceServer) DenyReferral(context.Context, *DenyReferralRequest) (*DenyReferralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyReferral not implemented")
+}
+func (UnimplementedReferralServiceServer) ReviewReferrals(context.Context, *ReviewReferralsRequest) (*ReviewReferralsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewReferrals not implemented")
+}
+func (UnimplementedReferralServiceServer) GetReviewQueue(context.Context, *GetReviewQueueRequest) (*GetReviewQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReviewQueue not implemented")
+}
+func (UnimplementedReferralServiceServer) ClaimReferral(context.Context, *ClaimReferralRequest) (*ClaimReferralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimReferral not implemented")
+}
+func (UnimplementedReferralServiceServer) ReleaseReferral(context.Context, *ReleaseReferralRequest) (*ReleaseReferralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReferral not implemented")
+}
+func (UnimplementedReferralServiceServer) FlagReferral(context.Context, *FlagReferralRequest) (*FlagReferralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlagReferral not implemented")
+}
+func (UnimplementedReferralServiceServer) AddReferralNote(context.Context, *AddReferralNoteRequest) (*AddReferralNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReferralNote not implemented")
+}
+func (UnimplementedReferralServiceServer) GetReferralNotes(context.Context, *GetReferralNotesRequest) (*GetReferralNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReferralNotes not implemented")
+}
+func (UnimplementedReferralServiceServer) IngestConversionEvent(context.Context, *IngestConversionEventRequest) (*IngestConversionEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestConversionEvent not implemented")
+}
+func (UnimplementedReferralServiceServer) IngestConversionEvents(context.Context, *IngestConversionEventsRequest) (*IngestConversionEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestConversionEvents not implemented")
+}
+func (UnimplementedReferralServiceServer) GetRewardBalance(context.Context, *GetRewardBalanceRequest) (*GetRewardBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRewardBalance not implemented")
+}
+func (UnimplementedReferralServiceServer) GetRewards(context.Context, *GetRewardsRequest) (*GetRewardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRewards not implemented")
+}
+func (UnimplementedReferralServiceServer) ReverseReward(context.Context, *ReverseRewardRequest) (*ReverseRewardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseReward not implemented")
+}
 func (UnimplementedReferralServiceServer) mustEmbedUnimplementedReferralServiceServer() {}
 func (UnimplementedReferralServiceServer) testEmbeddedByValue()                         {}
 
@@ -294,6 +724,60 @@ func _ReferralService_GetMembers_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReferralService_GetMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).GetMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_GetMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).GetMember(ctx, req.(*GetMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_GetMemberByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMemberByEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).GetMemberByEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_GetMemberByEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).GetMemberByEmail(ctx, req.(*GetMemberByEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_GetMemberByReferralCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMemberByReferralCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).GetMemberByReferralCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_GetMemberByReferralCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).GetMemberByReferralCode(ctx, req.(*GetMemberByReferralCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ReferralService_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddMemberRequest)
 	if err := dec(in); err != nil {
@@ -312,35 +796,179 @@ func _ReferralService_AddMember_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReferralService_GetReferrals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetReferralsRequest)
+func _ReferralService_UpdateMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReferralServiceServer).GetReferrals(ctx, in)
+		return srv.(ReferralServiceServer).UpdateMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ReferralService_GetReferrals_FullMethodName,
+		FullMethod: ReferralService_UpdateMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReferralServiceServer).GetReferrals(ctx, req.(*GetReferralsRequest))
+		return srv.(ReferralServiceServer).UpdateMember(ctx, req.(*UpdateMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReferralService_AddReferral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddReferralRequest)
+func _ReferralService_DeactivateMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivateMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReferralServiceServer).AddReferral(ctx, in)
+		return srv.(ReferralServiceServer).DeactivateMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ReferralService_AddReferral_FullMethodName,
+		FullMethod: ReferralService_DeactivateMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).DeactivateMember(ctx, req.(*DeactivateMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_ClaimVanityCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimVanityCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).ClaimVanityCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_ClaimVanityCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).ClaimVanityCode(ctx, req.(*ClaimVanityCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_AddReferralCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReferralCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).AddReferralCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_AddReferralCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).AddReferralCode(ctx, req.(*AddReferralCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_GetReferralCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReferralCodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).GetReferralCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_GetReferralCodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).GetReferralCodes(ctx, req.(*GetReferralCodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_RevokeReferralCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeReferralCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).RevokeReferralCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_RevokeReferralCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).RevokeReferralCode(ctx, req.(*RevokeReferralCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_GenerateReferralLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReferralLinkWrapper)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).GenerateReferralLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_GenerateReferralLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).GenerateReferralLink(ctx, req.(*ReferralLinkWrapper))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_GetClickStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClickStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).GetClickStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_GetClickStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).GetClickStats(ctx, req.(*GetClickStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_GetReferrals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReferralsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).GetReferrals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_GetReferrals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).GetReferrals(ctx, req.(*GetReferralsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_AddReferral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReferralRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).AddReferral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_AddReferral_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ReferralServiceServer).AddReferral(ctx, req.(*AddReferralRequest))
@@ -348,6 +976,276 @@ func _ReferralService_AddReferral_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReferralService_QualifyReferral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QualifyReferralRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).QualifyReferral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_QualifyReferral_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).QualifyReferral(ctx, req.(*QualifyReferralRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_ApproveReferral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveReferralRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).ApproveReferral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_ApproveReferral_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).ApproveReferral(ctx, req.(*ApproveReferralRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_DenyReferral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyReferralRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).DenyReferral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_DenyReferral_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).DenyReferral(ctx, req.(*DenyReferralRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_ReviewReferrals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewReferralsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).ReviewReferrals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_ReviewReferrals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).ReviewReferrals(ctx, req.(*ReviewReferralsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_GetReviewQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReviewQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).GetReviewQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_GetReviewQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).GetReviewQueue(ctx, req.(*GetReviewQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_ClaimReferral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimReferralRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).ClaimReferral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_ClaimReferral_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).ClaimReferral(ctx, req.(*ClaimReferralRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_ReleaseReferral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseReferralRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).ReleaseReferral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_ReleaseReferral_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).ReleaseReferral(ctx, req.(*ReleaseReferralRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_FlagReferral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlagReferralRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).FlagReferral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_FlagReferral_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).FlagReferral(ctx, req.(*FlagReferralRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_AddReferralNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReferralNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).AddReferralNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_AddReferralNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).AddReferralNote(ctx, req.(*AddReferralNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_GetReferralNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReferralNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).GetReferralNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_GetReferralNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).GetReferralNotes(ctx, req.(*GetReferralNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_IngestConversionEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestConversionEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).IngestConversionEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_IngestConversionEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).IngestConversionEvent(ctx, req.(*IngestConversionEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_IngestConversionEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestConversionEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).IngestConversionEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_IngestConversionEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).IngestConversionEvents(ctx, req.(*IngestConversionEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_GetRewardBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRewardBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).GetRewardBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_GetRewardBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).GetRewardBalance(ctx, req.(*GetRewardBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_GetRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRewardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).GetRewards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_GetRewards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).GetRewards(ctx, req.(*GetRewardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralService_ReverseReward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseRewardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralServiceServer).ReverseReward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralService_ReverseReward_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralServiceServer).ReverseReward(ctx, req.(*ReverseRewardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ReferralService_ServiceDesc is the grpc.ServiceDesc for ReferralService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -375,10 +1273,54 @@ var ReferralService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMembers",
 			Handler:    _ReferralService_GetMembers_Handler,
 		},
+		{
+			MethodName: "GetMember",
+			Handler:    _ReferralService_GetMember_Handler,
+		},
+		{
+			MethodName: "GetMemberByEmail",
+			Handler:    _ReferralService_GetMemberByEmail_Handler,
+		},
+		{
+			MethodName: "GetMemberByReferralCode",
+			Handler:    _ReferralService_GetMemberByReferralCode_Handler,
+		},
 		{
 			MethodName: "AddMember",
 			Handler:    _ReferralService_AddMember_Handler,
 		},
+		{
+			MethodName: "UpdateMember",
+			Handler:    _ReferralService_UpdateMember_Handler,
+		},
+		{
+			MethodName: "DeactivateMember",
+			Handler:    _ReferralService_DeactivateMember_Handler,
+		},
+		{
+			MethodName: "ClaimVanityCode",
+			Handler:    _ReferralService_ClaimVanityCode_Handler,
+		},
+		{
+			MethodName: "AddReferralCode",
+			Handler:    _ReferralService_AddReferralCode_Handler,
+		},
+		{
+			MethodName: "GetReferralCodes",
+			Handler:    _ReferralService_GetReferralCodes_Handler,
+		},
+		{
+			MethodName: "RevokeReferralCode",
+			Handler:    _ReferralService_RevokeReferralCode_Handler,
+		},
+		{
+			MethodName: "GenerateReferralLink",
+			Handler:    _ReferralService_GenerateReferralLink_Handler,
+		},
+		{
+			MethodName: "GetClickStats",
+			Handler:    _ReferralService_GetClickStats_Handler,
+		},
 		{
 			MethodName: "GetReferrals",
 			Handler:    _ReferralService_GetReferrals_Handler,
@@ -387,6 +1329,66 @@ var ReferralService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddReferral",
 			Handler:    _ReferralService_AddReferral_Handler,
 		},
+		{
+			MethodName: "QualifyReferral",
+			Handler:    _ReferralService_QualifyReferral_Handler,
+		},
+		{
+			MethodName: "ApproveReferral",
+			Handler:    _ReferralService_ApproveReferral_Handler,
+		},
+		{
+			MethodName: "DenyReferral",
+			Handler:    _ReferralService_DenyReferral_Handler,
+		},
+		{
+			MethodName: "ReviewReferrals",
+			Handler:    _ReferralService_ReviewReferrals_Handler,
+		},
+		{
+			MethodName: "GetReviewQueue",
+			Handler:    _ReferralService_GetReviewQueue_Handler,
+		},
+		{
+			MethodName: "ClaimReferral",
+			Handler:    _ReferralService_ClaimReferral_Handler,
+		},
+		{
+			MethodName: "ReleaseReferral",
+			Handler:    _ReferralService_ReleaseReferral_Handler,
+		},
+		{
+			MethodName: "FlagReferral",
+			Handler:    _ReferralService_FlagReferral_Handler,
+		},
+		{
+			MethodName: "AddReferralNote",
+			Handler:    _ReferralService_AddReferralNote_Handler,
+		},
+		{
+			MethodName: "GetReferralNotes",
+			Handler:    _ReferralService_GetReferralNotes_Handler,
+		},
+		{
+			MethodName: "IngestConversionEvent",
+			Handler:    _ReferralService_IngestConversionEvent_Handler,
+		},
+		{
+			MethodName: "IngestConversionEvents",
+			Handler:    _ReferralService_IngestConversionEvents_Handler,
+		},
+		{
+			MethodName: "GetRewardBalance",
+			Handler:    _ReferralService_GetRewardBalance_Handler,
+		},
+		{
+			MethodName: "GetRewards",
+			Handler:    _ReferralService_GetRewards_Handler,
+		},
+		{
+			MethodName: "ReverseReward",
+			Handler:    _ReferralService_ReverseReward_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "referral/referral.proto",
@@ -0,0 +1,144 @@
+// Package referralcode generates referral codes.
+package referralcode
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	mrand "math/rand/v2"
+	"strings"
+)
+
+// Generator produces new referral codes. Codes only need to be unlikely to
+// collide, callers generate another one when a code is already taken.
+type Generator interface {
+	// Generate returns a new code, starting with prefix followed by a dash when prefix isn't empty.
+	Generate(prefix string) (string, error)
+	// Mistyped reports whether code fails its check character, never when codes carry none.
+	Mistyped(code string) bool
+}
+
+const (
+	SourceCrypto = "crypto"
+	SourceMath   = "math"
+)
+
+// Options configure the default generator.
+type Options struct {
+	// Length is the number of random characters, not counting prefix and check character.
+	Length int `yaml:"length"`
+	// Alphabet is the set of lowercase letters and digits codes are made of.
+	Alphabet string `yaml:"alphabet"`
+	// Checksum appends a check character that catches any single mistyped character and,
+	// for alphabets of odd size, two swapped neighbours.
+	Checksum bool `yaml:"checksum"`
+	// Source is SourceCrypto or SourceMath, which is faster but predictable.
+	Source string `yaml:"source"`
+}
+
+// DefaultOptions leave out characters that are easily confused: 0 and o, 1, i and l.
+var DefaultOptions = Options{
+	Length:   7,
+	Alphabet: "abcdefghjkmnpqrstuvwxyz23456789",
+	Source:   SourceCrypto,
+}
+
+type generator struct {
+	opts Options
+	intn func(n int) (int, error)
+}
+
+// NewGenerator returns a generator of codes drawn uniformly from opts.Alphabet.
+func NewGenerator(opts Options) (Generator, error) {
+	if opts.Length < 4 || opts.Length > 32 {
+		return nil, fmt.Errorf("referral code length %d must be between 4 and 32", opts.Length)
+	}
+	if len(opts.Alphabet) < 2 {
+		return nil, fmt.Errorf("referral code alphabet needs at least 2 characters")
+	}
+	for i := 0; i < len(opts.Alphabet); i++ {
+		c := opts.Alphabet[i]
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+			return nil, fmt.Errorf("referral code alphabet %q may only contain lowercase letters and digits", opts.Alphabet)
+		}
+		if strings.IndexByte(opts.Alphabet, c) != i {
+			return nil, fmt.Errorf("referral code alphabet %q repeats %q", opts.Alphabet, c)
+		}
+	}
+
+	g := &generator{opts: opts}
+	switch opts.Source {
+	case SourceCrypto, "":
+		g.intn = cryptoIntn
+	case SourceMath:
+		g.intn = func(n int) (int, error) { return mrand.IntN(n), nil }
+	default:
+		return nil, fmt.Errorf("unknown referral code source %q", opts.Source)
+	}
+	return g, nil
+}
+
+func (g *generator) Generate(prefix string) (string, error) {
+	alphabet := g.opts.Alphabet
+	b := make([]byte, g.opts.Length)
+	for i := range b {
+		idx, err := g.intn(len(alphabet))
+		if err != nil {
+			return "", fmt.Errorf("referral code random %w", err)
+		}
+		b[i] = alphabet[idx]
+	}
+
+	code := string(b)
+	if g.opts.Checksum {
+		code += string(checkChar(alphabet, code))
+	}
+	if prefix != "" {
+		code = prefix + "-" + code
+	}
+	return code, nil
+}
+
+func (g *generator) Mistyped(code string) bool {
+	return g.opts.Checksum && !ValidChecksum(g.opts.Alphabet, code)
+}
+
+// ValidChecksum reports whether code, without its prefix, ends in the check character of the rest.
+func ValidChecksum(alphabet string, code string) bool {
+	if i := strings.LastIndexByte(code, '-'); i >= 0 {
+		code = code[i+1:]
+	}
+	if len(code) < 2 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if strings.IndexByte(alphabet, code[i]) < 0 {
+			return false
+		}
+	}
+	return checkChar(alphabet, code[:len(code)-1]) == code[len(code)-1]
+}
+
+// checkChar is the weighted sum of the positions of s's characters in alphabet, modulo its size.
+// Every weight is coprime to the size, so changing any one character changes the check character;
+// for odd sizes the weights alternate 1 and 2, which also catches swapped neighbours.
+func checkChar(alphabet string, s string) byte {
+	n := len(alphabet)
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		weight := 1
+		if n%2 == 1 && i%2 == 1 {
+			weight = 2
+		}
+		sum += weight * strings.IndexByte(alphabet, s[i])
+	}
+	return alphabet[sum%n]
+}
+
+func cryptoIntn(n int) (int, error) {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(v.Int64()), nil
+}
@@ -0,0 +1,92 @@
+package referralcode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, source := range []string{SourceCrypto, SourceMath} {
+		opts := DefaultOptions
+		opts.Source = source
+		g, err := NewGenerator(opts)
+		if err != nil {
+			t.Fatalf("NewGenerator(%s): %v", source, err)
+		}
+		seen := map[string]bool{}
+		for i := 0; i < 1000; i++ {
+			code, err := g.Generate("")
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+			if len(code) != opts.Length || strings.Trim(code, opts.Alphabet) != "" {
+				t.Fatalf("Generate = %q, want %d characters of %q", code, opts.Length, opts.Alphabet)
+			}
+			seen[code] = true
+		}
+		if len(seen) < 990 {
+			t.Fatalf("%s source produced only %d distinct codes out of 1000", source, len(seen))
+		}
+	}
+}
+
+func TestGeneratePrefixAndChecksum(t *testing.T) {
+	opts := DefaultOptions
+	opts.Checksum = true
+	g, err := NewGenerator(opts)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+	code, err := g.Generate("spring")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if !strings.HasPrefix(code, "spring-") || len(code) != len("spring-")+opts.Length+1 {
+		t.Fatalf("Generate = %q, want spring- and %d characters", code, opts.Length+1)
+	}
+	if !ValidChecksum(opts.Alphabet, code) {
+		t.Fatalf("ValidChecksum(%q) = false", code)
+	}
+
+	// any single substituted character is detected.
+	body := []byte(strings.TrimPrefix(code, "spring-"))
+	for i := range body {
+		for j := 0; j < len(opts.Alphabet); j++ {
+			mistyped := append([]byte{}, body...)
+			if mistyped[i] == opts.Alphabet[j] {
+				continue
+			}
+			mistyped[i] = opts.Alphabet[j]
+			if ValidChecksum(opts.Alphabet, string(mistyped)) {
+				t.Fatalf("ValidChecksum(%q) = true for a mistyped %q", mistyped, body)
+			}
+		}
+	}
+
+	// so is swapping two neighbours before the check character, the default alphabet has an odd size.
+	for i := 0; i+2 < len(body); i++ {
+		if body[i] == body[i+1] {
+			continue
+		}
+		swapped := append([]byte{}, body...)
+		swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+		if ValidChecksum(opts.Alphabet, string(swapped)) {
+			t.Fatalf("ValidChecksum(%q) = true for a swapped %q", swapped, body)
+		}
+	}
+}
+
+func TestNewGeneratorRejectsBadOptions(t *testing.T) {
+	tests := []Options{
+		{Length: 3, Alphabet: "abc"},
+		{Length: 8, Alphabet: "a"},
+		{Length: 8, Alphabet: "abcA"},
+		{Length: 8, Alphabet: "abca"},
+		{Length: 8, Alphabet: "abc", Source: "dice"},
+	}
+	for _, opts := range tests {
+		if _, err := NewGenerator(opts); err == nil {
+			t.Errorf("NewGenerator(%+v) succeeded, want an error", opts)
+		}
+	}
+}
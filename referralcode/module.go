@@ -0,0 +1,40 @@
+package referralcode
+
+import (
+	"fmt"
+
+	"go.uber.org/config"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"referralcode",
+	fx.Provide(New, NewVanity),
+)
+
+type Params struct {
+	fx.In
+
+	Cfg config.Provider
+}
+
+// New provides the generator configured under referral_codes, DefaultOptions for anything unset.
+func New(p Params) (Generator, error) {
+	opts := DefaultOptions
+	if err := p.Cfg.Get("referral_codes").Populate(&opts); err != nil {
+		return nil, fmt.Errorf("referral_codes config populate %w", err)
+	}
+	return NewGenerator(opts)
+}
+
+// NewVanity provides the rules configured under vanity_codes, DefaultVanityRules for anything unset.
+func NewVanity(p Params) (VanityRules, error) {
+	rules := DefaultVanityRules
+	if err := p.Cfg.Get("vanity_codes").Populate(&rules); err != nil {
+		return VanityRules{}, fmt.Errorf("vanity_codes config populate %w", err)
+	}
+	if rules.MinLength < 1 || rules.MaxLength < rules.MinLength {
+		return VanityRules{}, fmt.Errorf("vanity code lengths %d to %d are invalid", rules.MinLength, rules.MaxLength)
+	}
+	return rules, nil
+}
@@ -0,0 +1,63 @@
+package referralcode
+
+import (
+	"strings"
+
+	"referral-service/domain"
+)
+
+// VanityRules decide which codes members may pick for themselves.
+type VanityRules struct {
+	MinLength int `yaml:"min_length"`
+	MaxLength int `yaml:"max_length"`
+	// Blocklist words are rejected anywhere in a code, also when spelled with
+	// digits for letters or split up by dashes.
+	Blocklist []string `yaml:"blocklist"`
+	// Reserved codes are rejected as a whole code only.
+	Reserved []string `yaml:"reserved"`
+}
+
+var DefaultVanityRules = VanityRules{
+	MinLength: 4,
+	MaxLength: 20,
+}
+
+// leet maps the digits commonly used in place of letters back to the letters.
+var leet = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "-", "")
+
+// Normalize returns code the way it is stored, codes are case insensitive.
+func Normalize(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+// Check reports why the normalized code can't be claimed, nil if it can.
+// Codes are lowercase letters, digits and inner dashes.
+func (r VanityRules) Check(code string) error {
+	if len(code) < r.MinLength || len(code) > r.MaxLength {
+		return domain.NewError(domain.ErrInvalidArgument,
+			"referral code must be between %d and %d characters", r.MinLength, r.MaxLength)
+	}
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+			return domain.NewError(domain.ErrInvalidArgument,
+				"referral code may only contain letters, digits and dashes")
+		}
+	}
+	if code[0] == '-' || code[len(code)-1] == '-' {
+		return domain.NewError(domain.ErrInvalidArgument, "referral code can't start or end with a dash")
+	}
+
+	for _, reserved := range r.Reserved {
+		if code == Normalize(reserved) {
+			return domain.NewError(domain.ErrInvalidArgument, "referral code %s is reserved", code)
+		}
+	}
+	plain := leet.Replace(code)
+	for _, word := range r.Blocklist {
+		if word = leet.Replace(Normalize(word)); word != "" && strings.Contains(plain, word) {
+			return domain.NewError(domain.ErrInvalidArgument, "referral code is not allowed")
+		}
+	}
+	return nil
+}
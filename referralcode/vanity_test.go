@@ -0,0 +1,39 @@
+package referralcode
+
+import (
+	"errors"
+	"testing"
+
+	"referral-service/domain"
+)
+
+func TestVanityCheck(t *testing.T) {
+	rules := VanityRules{
+		MinLength: 4,
+		MaxLength: 12,
+		Blocklist: []string{"Badword"},
+		Reserved:  []string{"Admin"},
+	}
+	for code, ok := range map[string]bool{
+		"alice":          true,
+		"alice-2024":     true,
+		"abc":            false,
+		"averylongcode1": false,
+		"alice_1":        false,
+		"-alice":         false,
+		"alice-":         false,
+		"admin":          false,
+		"admin-jo":       true,
+		"mybadword":      false,
+		"b4dw0rd":        false,
+		"bad-word":       false,
+	} {
+		err := rules.Check(code)
+		if ok && err != nil {
+			t.Errorf("Check(%q) = %v, want nil", code, err)
+		}
+		if !ok && !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Errorf("Check(%q) = %v, want invalid argument", code, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"referral-service/domain"
+
+	"github.com/lib/pq"
+)
+
+// constraintErrors are the client safe messages for constraint violations, by constraint name.
+var constraintErrors = map[string]string{
+	"members_email_program_id_key":        "email is already enrolled in the program",
+	"members_person_id_program_id_key":    "email is already enrolled in the program",
+	"fk_program":                          "program does not exist",
+	"fk_person":                           "person does not exist",
+	"fk_member":                           "member does not exist",
+	"fk_referral":                         "referral does not exist",
+	"fk_referral_code":                    "referral code does not exist",
+	"rewards_credit_referral_beneficiary": "referral is already rewarded",
+	"rewards_reversal_of":                 "reward is already reversed",
+	"rewards_balance_of":                  "reward is already balanced",
+	"rewards_account_check":               "unknown reward account",
+	"referrals_status_check":              "unknown referral status",
+	"programs_reward_type_check":          "unknown reward type",
+	"conversion_events_external_id_key":   "conversion event was already ingested",
+}
+
+// constraintSentinels are the errors returned for violations callers need to tell apart, by constraint name.
+var constraintSentinels = map[string]error{
+	"members_referral_code_key": domain.ErrReferralCodeTaken,
+	"referral_codes_pkey":       domain.ErrReferralCodeTaken,
+}
+
+// notFound reports a missing row as a domain not found error.
+func notFound(err error, what string, id string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.NewError(domain.ErrNotFound, "%s %s not found", what, id)
+	}
+	return err
+}
+
+// onlyMember returns the single member found by email, reporting none as not found
+// and several as ambiguous.
+func onlyMember(members []domain.Member, email string) (domain.Member, error) {
+	switch len(members) {
+	case 0:
+		return domain.Member{}, domain.NewError(domain.ErrNotFound, "member with email %s not found", email)
+	case 1:
+		return members[0], nil
+	}
+	return domain.Member{}, domain.ErrAmbiguousEmail
+}
+
+// writeError translates a failed write into a domain error so that postgres
+// messages don't reach clients; anything else is wrapped with op.
+func writeError(err error, op string) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if sentinel, ok := constraintSentinels[pqErr.Constraint]; ok {
+		return sentinel
+	}
+	msg := constraintMessage(pqErr.Constraint)
+	switch pqErr.Code.Name() {
+	case "unique_violation":
+		return domain.NewError(domain.ErrAlreadyExists, "%s", msg)
+	case "foreign_key_violation", "check_violation":
+		return domain.NewError(domain.ErrInvalidArgument, "%s", msg)
+	case "not_null_violation":
+		return domain.NewError(domain.ErrInvalidArgument, "%s is required", pqErr.Column)
+	}
+	return fmt.Errorf("%s %w", op, err)
+}
+
+// constraintMessage is the client safe message for a violation of constraint.
+func constraintMessage(constraint string) string {
+	msg, ok := constraintErrors[constraint]
+	if !ok {
+		msg = "constraint " + constraint + " violated"
+	}
+	return msg
+}
+
+// constraintError reports a violation of constraint the way writeError does, for
+// repositories that enforce the schema constraints themselves.
+func constraintError(kind error, constraint string) error {
+	if sentinel, ok := constraintSentinels[constraint]; ok {
+		return sentinel
+	}
+	return domain.NewError(kind, "%s", constraintMessage(constraint))
+}
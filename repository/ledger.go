@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"referral-service/domain"
+
+	"github.com/google/uuid"
+)
+
+// postings returns reward as an entry on its beneficiary's account, followed by the
+// opposite entry on its program's account that balances it.
+func postings(reward domain.Reward, now int64) []domain.Reward {
+	reward.ID = uuid.New().String()
+	reward.Account = domain.RewardAccountBeneficiary
+	reward.BalanceOf = ""
+	reward.CreatedAt = now
+
+	balance := domain.Reward{
+		ID:          uuid.New().String(),
+		Account:     domain.RewardAccountProgram,
+		Beneficiary: reward.Beneficiary,
+		ProgramId:   reward.ProgramId,
+		ReferralId:  reward.ReferralId,
+		EntryType:   domain.RewardEntryDebit,
+		Amount:      reward.Amount,
+		Currency:    reward.Currency,
+		BalanceOf:   reward.ID,
+		Reason:      reward.Reason,
+		CreatedBy:   reward.CreatedBy,
+		CreatedAt:   now,
+	}
+	if reward.EntryType == domain.RewardEntryDebit {
+		balance.EntryType = domain.RewardEntryCredit
+	}
+	return []domain.Reward{reward, balance}
+}
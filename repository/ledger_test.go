@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"referral-service/domain"
+
+	"go.uber.org/zap"
+)
+
+func TestMemoryLedgerBalances(t *testing.T) {
+	ctx := context.Background()
+	r := NewMemory(zap.NewNop()).(*memRepository)
+	programId, err := r.AddProgram(ctx, "friends", "Friends", true, "", "", domain.RewardPolicy{}, domain.QualificationRule{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	memberId, err := r.AddMember(ctx, "Ada", nil, "ada@example.com", programId, "ada01", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	referralId, err := r.AddReferral(ctx, domain.Referral{Email: "cy@example.com", ContactEmail: "cy@example.com", ReferralCode: "ada01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credit := func(beneficiary string, memberId string, amount int64, currency string) domain.Reward {
+		return domain.Reward{
+			Beneficiary: beneficiary,
+			MemberId:    memberId,
+			ProgramId:   programId,
+			ReferralId:  referralId,
+			EntryType:   domain.RewardEntryCredit,
+			Amount:      amount,
+			Currency:    currency,
+		}
+	}
+	err = r.UpdateReferralStatus(ctx, domain.ReferralStatusChange{
+		ReferralId: referralId,
+		FromStatus: domain.ReferralStatusPending,
+		ToStatus:   domain.ReferralStatusApproved,
+		ChangedBy:  "ann",
+	}, []domain.Reward{
+		credit(domain.RewardBeneficiaryReferrer, memberId, 1000, "USD"),
+		credit(domain.RewardBeneficiaryReferee, "", 700, "EUR"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rewards, err := r.GetRewards(ctx, memberId, domain.Page{Number: 1, Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rewards) != 1 {
+		t.Fatalf("member history lists %d entries, want the member's credit only", len(rewards))
+	}
+	reversal := rewards[0]
+	reversal.EntryType = domain.RewardEntryDebit
+	reversal.ReversalOf = reversal.ID
+	if _, err := r.AddReward(ctx, reversal); err != nil {
+		t.Fatal(err)
+	}
+
+	net := map[string]int64{}
+	accounts := map[string]int{}
+	for _, entry := range r.rewards {
+		net[entry.Currency] += entry.SignedAmount()
+		accounts[entry.Account]++
+		if entry.Account != domain.RewardAccountProgram {
+			continue
+		}
+		balanced, ok := r.rewards[entry.BalanceOf]
+		if !ok || balanced.Account != domain.RewardAccountBeneficiary ||
+			balanced.Amount != entry.Amount || balanced.Currency != entry.Currency || balanced.EntryType == entry.EntryType {
+			t.Fatalf("program entry %+v doesn't balance %+v", entry, balanced)
+		}
+	}
+	for currency, amount := range net {
+		if amount != 0 {
+			t.Fatalf("%s entries net to %d, want 0", currency, amount)
+		}
+	}
+	if accounts[domain.RewardAccountBeneficiary] != 3 || accounts[domain.RewardAccountProgram] != 3 {
+		t.Fatalf("entries by account = %v, want 3 each", accounts)
+	}
+
+	// the member's side still reads as before.
+	total, err := r.SumProgramRewards(ctx, programId, "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 0 {
+		t.Fatalf("SumProgramRewards after the reversal = %d, want 0", total)
+	}
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"referral-service/domain"
+
+	"go.uber.org/zap"
+)
+
+// applyLimits flags referral for every limit count finds it exceeds, or rejects it
+// when a rejecting limit is exceeded. Limits count from the referral's CreatedAt.
+func applyLimits(referral *domain.Referral, limits []domain.ReferralLimit, count func(filter domain.ReferralFilter) (int64, error)) error {
+	var rejects []string
+	for _, limit := range limits {
+		filter := limit.Filter
+		since := referral.CreatedAt - limit.WindowMinutes*60
+		filter.CreatedAfter = &since
+		n, err := count(filter)
+		if err != nil {
+			return fmt.Errorf("referral limit count %w", err)
+		}
+		switch {
+		case n < limit.Max:
+		case limit.Reject:
+			rejects = append(rejects, limit.Reason)
+		default:
+			referral.Flagged = true
+			referral.FlagReason = strings.Join(nonEmpty(referral.FlagReason, limit.Reason), "; ")
+		}
+	}
+	if len(rejects) > 0 {
+		return domain.NewError(domain.ErrConflict, "referral rejected, %s", strings.Join(rejects, "; "))
+	}
+	return nil
+}
+
+// nonEmpty returns the values that aren't empty.
+func nonEmpty(values ...string) []string {
+	var kept []string
+	for _, v := range values {
+		if v != "" {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// memberProgram keys the referrer credits a member earned in a program.
+type memberProgram struct {
+	memberId  string
+	programId string
+}
+
+// limitRewards splits rewards, in order, into those within the limits of their program and
+// those left out. count and sum read the stored referrer credits of a member and the net
+// rewards of a program in a currency; rewards kept earlier count towards the later ones.
+func limitRewards(rewards []domain.Reward, limits []domain.RewardLimit,
+	count func(memberId string, programId string) (int64, error),
+	sum func(programId string, currency string) (int64, error)) (kept []domain.Reward, skipped []domain.Reward, err error) {
+	byProgram := map[string]domain.RewardLimit{}
+	for _, limit := range limits {
+		byProgram[limit.ProgramId] = limit
+	}
+	earned := map[memberProgram]int64{}
+	issued := map[string]int64{}
+	for _, reward := range rewards {
+		limit, ok := byProgram[reward.ProgramId]
+		if !ok || reward.EntryType != domain.RewardEntryCredit {
+			kept = append(kept, reward)
+			continue
+		}
+
+		key := memberProgram{reward.MemberId, reward.ProgramId}
+		limitsMember := reward.Beneficiary == domain.RewardBeneficiaryReferrer && limit.MaxRewardsPerMember > 0
+		if limitsMember {
+			if _, ok := earned[key]; !ok {
+				if earned[key], err = count(key.memberId, key.programId); err != nil {
+					return nil, nil, fmt.Errorf("member rewards count %w", err)
+				}
+			}
+			if earned[key] >= limit.MaxRewardsPerMember {
+				skipped = append(skipped, reward)
+				continue
+			}
+		}
+		limitsProgram := limit.RewardCap > 0 && reward.Currency == limit.Currency
+		if limitsProgram {
+			if _, ok := issued[reward.ProgramId]; !ok {
+				if issued[reward.ProgramId], err = sum(reward.ProgramId, limit.Currency); err != nil {
+					return nil, nil, fmt.Errorf("program rewards sum %w", err)
+				}
+			}
+			if issued[reward.ProgramId]+reward.Amount > limit.RewardCap {
+				skipped = append(skipped, reward)
+				continue
+			}
+			issued[reward.ProgramId] += reward.Amount
+		}
+		if limitsMember {
+			earned[key]++
+		}
+		kept = append(kept, reward)
+	}
+	return kept, skipped, nil
+}
+
+// logSkippedRewards logs the rewards limitRewards left out.
+func logSkippedRewards(log *zap.Logger, skipped []domain.Reward) {
+	for _, reward := range skipped {
+		log.Info("reward limit reached, skipping reward",
+			zap.String("program_id", reward.ProgramId),
+			zap.String("referral_id", reward.ReferralId),
+			zap.String("beneficiary", reward.Beneficiary),
+		)
+	}
+}
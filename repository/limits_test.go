@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"referral-service/domain"
+)
+
+func TestApplyLimits(t *testing.T) {
+	code := "ada01"
+	limits := []domain.ReferralLimit{
+		{Filter: domain.ReferralFilter{ReferralCode: &code}, Max: 3, WindowMinutes: 60, Reason: "code"},
+		{Filter: domain.ReferralFilter{ReferralCode: &code}, Max: 1, WindowMinutes: 10, Reason: "burst"},
+	}
+	var since []int64
+	count := func(filter domain.ReferralFilter) (int64, error) {
+		if filter.ReferralCode == nil || *filter.ReferralCode != code {
+			t.Fatalf("count filter = %+v", filter)
+		}
+		since = append(since, *filter.CreatedAfter)
+		return 2, nil
+	}
+
+	referral := domain.Referral{CreatedAt: 10_000, FlagReason: "risk score 70"}
+	if err := applyLimits(&referral, limits, count); err != nil {
+		t.Fatal(err)
+	}
+	// limits count the referrals made in their window before the new one.
+	if len(since) != 2 || since[0] != 10_000-3600 || since[1] != 10_000-600 {
+		t.Fatalf("counted since %v", since)
+	}
+	if !referral.Flagged || referral.FlagReason != "risk score 70; burst" {
+		t.Fatalf("referral = %+v, want flagged for the burst", referral)
+	}
+
+	limits[1].Reject = true
+	err := applyLimits(&domain.Referral{CreatedAt: 10_000}, limits, count)
+	if !errors.Is(err, domain.ErrConflict) || err.Error() != "referral rejected, burst" {
+		t.Fatalf("applyLimits over a rejecting limit = %v", err)
+	}
+}
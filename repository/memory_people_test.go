@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"referral-service/domain"
+
+	"go.uber.org/zap"
+)
+
+func TestMemoryRejectedMembersAddNoPerson(t *testing.T) {
+	ctx := context.Background()
+	r := NewMemory(zap.NewNop()).(*memRepository)
+	programId, err := r.AddProgram(ctx, "friends", "Friends", true, "", "", domain.RewardPolicy{}, domain.QualificationRule{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adaId, err := r.AddMember(ctx, "Ada", nil, "ada@example.com", programId, "ada01", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.AddMember(ctx, "Bob", nil, "bob@example.com", programId, "bob01", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.AddMember(ctx, "Eve", nil, "eve@example.com", "missing", "eve01", nil); err == nil {
+		t.Fatal("AddMember to a missing program succeeded")
+	}
+	if _, err := r.AddMember(ctx, "Cy", nil, "cy@example.com", programId, "ada01", nil); err == nil {
+		t.Fatal("AddMember with a taken code succeeded")
+	}
+	email := "bob@example.com"
+	if err := r.UpdateMember(ctx, adaId, nil, nil, &email, nil, nil); err == nil {
+		t.Fatal("UpdateMember to an enrolled email succeeded")
+	}
+	email = "dee@example.com"
+	inactive := false
+	if err := r.UpdateMember(ctx, adaId, nil, nil, &email, &programId, &inactive); err != nil {
+		t.Fatal(err)
+	}
+
+	var emails []string
+	for _, person := range r.people {
+		emails = append(emails, person.Email)
+	}
+	// ada's person stays, enrolments don't delete people.
+	if len(r.people) != 3 {
+		t.Fatalf("people = %v, want ada, bob and dee", emails)
+	}
+}
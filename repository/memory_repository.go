@@ -0,0 +1,1052 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"referral-service/domain"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// memRepository keeps everything in process memory. It enforces the same
+// unique, foreign key and check constraints as the postgres schema and
+// reports violations with the same errors, so it can stand in for postgres
+// in tests and local development. Data is lost on restart.
+type memRepository struct {
+	log *zap.Logger
+
+	mu            sync.RWMutex
+	programs      map[string]domain.Program
+	people        map[string]domain.Person
+	members       map[string]domain.Member
+	codes         map[string]domain.ReferralCode
+	referrals     map[string]domain.Referral
+	statusChanges []domain.ReferralStatusChange
+	notes         []domain.ReferralNote
+	conversions   []domain.ConversionEvent
+	rewards       map[string]domain.Reward
+	clicks        []domain.Click
+}
+
+// NewMemory returns an empty in-memory repository.
+func NewMemory(log *zap.Logger) Repository {
+	return &memRepository{
+		log:       log,
+		programs:  map[string]domain.Program{},
+		people:    map[string]domain.Person{},
+		members:   map[string]domain.Member{},
+		codes:     map[string]domain.ReferralCode{},
+		referrals: map[string]domain.Referral{},
+		rewards:   map[string]domain.Reward{},
+	}
+}
+
+// program
+
+func (r *memRepository) AddProgram(ctx context.Context,
+	name string,
+	title string,
+	active bool,
+	landingUrl string,
+	codePrefix string,
+	policy domain.RewardPolicy,
+	rule domain.QualificationRule,
+	attributionWindowDays int64) (string, error) {
+	if err := checkRewardType(policy.RewardType); err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC().Unix()
+	program := domain.Program{
+		ID:                    uuid.New().String(),
+		Name:                  name,
+		Title:                 title,
+		IsActive:              active,
+		LandingUrl:            landingUrl,
+		CodePrefix:            codePrefix,
+		AttributionWindowDays: attributionWindowDays,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+		RewardPolicy:          policy,
+		QualificationRule:     rule,
+	}
+	r.programs[program.ID] = program
+	return program.ID, nil
+}
+
+func (r *memRepository) UpdateProgram(ctx context.Context,
+	id string,
+	name *string,
+	title *string,
+	active *bool,
+	landingUrl *string,
+	codePrefix *string,
+	policy *domain.RewardPolicy,
+	rule *domain.QualificationRule,
+	attributionWindowDays *int64) error {
+	if policy != nil {
+		if err := checkRewardType(policy.RewardType); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	program, ok := r.programs[id]
+	if !ok {
+		return nil
+	}
+	if name != nil {
+		program.Name = *name
+	}
+	if title != nil {
+		program.Title = *title
+	}
+	if active != nil {
+		program.IsActive = *active
+	}
+	if landingUrl != nil {
+		program.LandingUrl = *landingUrl
+	}
+	if codePrefix != nil {
+		program.CodePrefix = *codePrefix
+	}
+	if attributionWindowDays != nil {
+		program.AttributionWindowDays = *attributionWindowDays
+	}
+	if policy != nil {
+		program.RewardPolicy = *policy
+	}
+	if rule != nil {
+		program.QualificationRule = *rule
+	}
+	program.UpdatedAt = time.Now().UTC().Unix()
+	r.programs[id] = program
+	return nil
+}
+
+func (r *memRepository) GetPrograms(ctx context.Context, page domain.Page) ([]domain.Program, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	programs := make([]domain.Program, 0, len(r.programs))
+	for _, program := range r.programs {
+		programs = append(programs, program)
+	}
+	return paged(programs, page, func(p domain.Program) domain.Cursor {
+		return domain.Cursor{Key: p.CreatedAt, ID: p.ID}
+	}, false), nil
+}
+
+func (r *memRepository) GetProgram(ctx context.Context, programId string) (domain.Program, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	program, ok := r.programs[programId]
+	if !ok {
+		return domain.Program{}, domain.NewError(domain.ErrNotFound, "program %s not found", programId)
+	}
+	return program, nil
+}
+
+// member
+
+func (r *memRepository) AddMember(ctx context.Context,
+	first_name string,
+	last_name *string,
+	email string,
+	program_id string,
+	referral_code string,
+	is_active *bool) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC().Unix()
+	member := domain.Member{
+		ID:           uuid.New().String(),
+		PersonId:     r.existingPersonId(email),
+		FirstName:    first_name,
+		LastName:     valueOrEmpty(last_name),
+		Email:        email,
+		ContactEmail: domain.NormalizeEmail(email),
+		ProgramId:    program_id,
+		ReferralCode: referral_code,
+		IsActive:     is_active == nil || *is_active,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := r.checkMember(member); err != nil {
+		return "", err
+	}
+	if _, ok := r.codes[referral_code]; ok {
+		return "", constraintError(domain.ErrAlreadyExists, "referral_codes_pkey")
+	}
+	member.PersonId = r.personId(email, now)
+	r.members[member.ID] = member
+	r.codes[referral_code] = domain.ReferralCode{Code: referral_code, MemberId: member.ID, CreatedAt: now}
+	return member.ID, nil
+}
+
+func (r *memRepository) UpdateMember(ctx context.Context,
+	id string,
+	first_name *string,
+	last_name *string,
+	email *string,
+	program_id *string,
+	is_active *bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	member, ok := r.members[id]
+	if !ok {
+		return nil
+	}
+	if program_id != nil && *program_id != member.ProgramId {
+		return domain.ErrMemberProgramChange
+	}
+	if first_name != nil {
+		member.FirstName = *first_name
+	}
+	if last_name != nil {
+		member.LastName = *last_name
+	}
+	if email != nil {
+		member.Email = *email
+		member.ContactEmail = domain.NormalizeEmail(*email)
+		member.PersonId = r.existingPersonId(*email)
+	}
+	if is_active != nil {
+		member.IsActive = *is_active
+	}
+	member.UpdatedAt = time.Now().UTC().Unix()
+	if err := r.checkMember(member); err != nil {
+		return err
+	}
+	if email != nil {
+		member.PersonId = r.personId(*email, member.UpdatedAt)
+	}
+	r.members[id] = member
+	return nil
+}
+
+// personId returns the id of the person with email, adding the person if there is none.
+// Callers check the member first so that rejected members leave no person behind.
+func (r *memRepository) personId(email string, now int64) string {
+	if id := r.existingPersonId(email); id != "" {
+		return id
+	}
+	person := domain.Person{ID: uuid.New().String(), Email: email, CreatedAt: now}
+	r.people[person.ID] = person
+	return person.ID
+}
+
+func (r *memRepository) GetMembers(ctx context.Context, filter domain.MemberFilter, page domain.Page) ([]domain.Member, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	members := make([]domain.Member, 0, len(r.members))
+	for _, member := range r.members {
+		if filter.ProgramId != nil && member.ProgramId != *filter.ProgramId ||
+			filter.PersonId != nil && member.PersonId != *filter.PersonId ||
+			filter.Email != nil && !strings.EqualFold(member.Email, *filter.Email) ||
+			filter.ContactEmail != nil && member.ContactEmail != *filter.ContactEmail {
+			continue
+		}
+		members = append(members, member)
+	}
+	return paged(members, page, func(m domain.Member) domain.Cursor {
+		return domain.Cursor{Key: m.CreatedAt, ID: m.ID}
+	}, false), nil
+}
+
+func (r *memRepository) GetMember(ctx context.Context, memberId string) (domain.Member, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	member, ok := r.members[memberId]
+	if !ok {
+		return domain.Member{}, domain.NewError(domain.ErrNotFound, "member %s not found", memberId)
+	}
+	return member, nil
+}
+
+func (r *memRepository) GetMemberByEmail(ctx context.Context, email string, programId *string) (domain.Member, error) {
+	members, err := r.GetMembers(ctx, domain.MemberFilter{Email: &email, ProgramId: programId}, domain.Page{Number: 1, Size: 2})
+	if err != nil {
+		return domain.Member{}, err
+	}
+	return onlyMember(members, email)
+}
+
+func (r *memRepository) GetMemberByReferralCode(ctx context.Context, referralCode string) (domain.Member, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	member, ok := r.memberByCode(referralCode)
+	if !ok {
+		return domain.Member{}, domain.NewError(domain.ErrNotFound, "referral code %s not found", referralCode)
+	}
+	return member, nil
+}
+
+func (r *memRepository) ChangeReferralCode(ctx context.Context, memberId string, code string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	member, ok := r.members[memberId]
+	if !ok {
+		return domain.NewError(domain.ErrNotFound, "member %s not found", memberId)
+	}
+	if member.ReferralCode == code {
+		return nil
+	}
+	now := time.Now().UTC().Unix()
+	claimed, ok := r.codes[code]
+	if ok && claimed.MemberId != memberId {
+		return domain.ErrReferralCodeTaken
+	}
+	if !ok {
+		claimed = domain.ReferralCode{Code: code, MemberId: memberId, CreatedAt: now}
+	}
+	claimed.RetiredAt, claimed.RevokedAt, claimed.ExpiresAt = 0, 0, 0
+	r.codes[code] = claimed
+
+	previous := r.codes[member.ReferralCode]
+	previous.RetiredAt = now
+	r.codes[member.ReferralCode] = previous
+	member.ReferralCode = code
+	member.UpdatedAt = now
+	r.members[memberId] = member
+	return nil
+}
+
+// existingPersonId returns the id of the person with email in any case, "" if there is none.
+func (r *memRepository) existingPersonId(email string) string {
+	for _, person := range r.people {
+		if strings.EqualFold(person.Email, email) {
+			return person.ID
+		}
+	}
+	return ""
+}
+
+// checkMember enforces the members table constraints for member, which replaces any row with the same id.
+func (r *memRepository) checkMember(member domain.Member) error {
+	if _, ok := r.programs[member.ProgramId]; !ok {
+		return constraintError(domain.ErrInvalidArgument, "fk_program")
+	}
+	for _, other := range r.members {
+		if other.ID == member.ID {
+			continue
+		}
+		sameProgram := other.ProgramId == member.ProgramId
+		switch {
+		case sameProgram && strings.EqualFold(other.Email, member.Email):
+			return constraintError(domain.ErrAlreadyExists, "members_email_program_id_key")
+		case sameProgram && member.PersonId != "" && other.PersonId == member.PersonId:
+			return constraintError(domain.ErrAlreadyExists, "members_person_id_program_id_key")
+		case other.ReferralCode == member.ReferralCode:
+			return constraintError(domain.ErrAlreadyExists, "members_referral_code_key")
+		}
+	}
+	return nil
+}
+
+// memberByCode finds the member owning referralCode, current or retired, the join
+// referrals make on referral_codes and members.
+func (r *memRepository) memberByCode(referralCode string) (domain.Member, bool) {
+	code, ok := r.codes[referralCode]
+	if !ok {
+		return domain.Member{}, false
+	}
+	member, ok := r.members[code.MemberId]
+	return member, ok
+}
+
+// referral code
+
+func (r *memRepository) AddReferralCode(ctx context.Context, code domain.ReferralCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.members[code.MemberId]; !ok {
+		return constraintError(domain.ErrInvalidArgument, "fk_member")
+	}
+	if _, ok := r.codes[code.Code]; ok {
+		return constraintError(domain.ErrAlreadyExists, "referral_codes_pkey")
+	}
+	code.CreatedAt = time.Now().UTC().Unix()
+	code.RetiredAt = 0
+	code.RevokedAt = 0
+	r.codes[code.Code] = code
+	return nil
+}
+
+func (r *memRepository) GetReferralCode(ctx context.Context, code string) (domain.ReferralCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	referralCode, ok := r.codes[code]
+	if !ok {
+		return domain.ReferralCode{}, domain.NewError(domain.ErrNotFound, "referral code %s not found", code)
+	}
+	return referralCode, nil
+}
+
+func (r *memRepository) GetReferralCodes(ctx context.Context, memberId string) ([]domain.ReferralCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	codes := []domain.ReferralCode{}
+	for _, code := range r.codes {
+		if code.MemberId == memberId {
+			codes = append(codes, code)
+		}
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i].CreatedAt != codes[j].CreatedAt {
+			return codes[i].CreatedAt < codes[j].CreatedAt
+		}
+		return codes[i].Code < codes[j].Code
+	})
+	return codes, nil
+}
+
+func (r *memRepository) RevokeReferralCode(ctx context.Context, code string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	referralCode, ok := r.codes[code]
+	if !ok || referralCode.RevokedAt != 0 {
+		return nil
+	}
+	referralCode.RevokedAt = time.Now().UTC().Unix()
+	r.codes[code] = referralCode
+	return nil
+}
+
+// referral
+
+func (r *memRepository) AddReferral(ctx context.Context, referral domain.Referral, limits ...domain.ReferralLimit) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.memberByCode(referral.ReferralCode); !ok {
+		return "", constraintError(domain.ErrInvalidArgument, "fk_referral_code")
+	}
+
+	referral.ID = uuid.New().String()
+	referral.Status = domain.ReferralStatusPending
+	referral.CreatedAt = time.Now().UTC().Unix()
+	referral.UpdatedAt = referral.CreatedAt
+	err := applyLimits(&referral, limits, func(filter domain.ReferralFilter) (int64, error) {
+		return r.countReferrals(filter), nil
+	})
+	if err != nil {
+		return "", err
+	}
+	r.referrals[referral.ID] = referral
+	return referral.ID, nil
+}
+
+func (r *memRepository) CountReferralsByContact(ctx context.Context, programId string, contactEmail string, contactPhone string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, referral := range r.referrals {
+		referral, ok := r.joinMember(referral)
+		if !ok || referral.ProgramId != programId {
+			continue
+		}
+		if contactEmail != "" && referral.ContactEmail == contactEmail ||
+			contactPhone != "" && referral.ContactPhone == contactPhone {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memRepository) GetReferrals(ctx context.Context,
+	filter domain.ReferralFilter,
+	page domain.Page) ([]domain.Referral, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	referrals := []domain.Referral{}
+	for _, referral := range r.referrals {
+		referral, ok := r.joinMember(referral)
+		if !ok || !matchesReferralFilter(referral, filter) {
+			continue
+		}
+		referrals = append(referrals, referral)
+	}
+	return paged(referrals, page, func(ref domain.Referral) domain.Cursor {
+		return domain.Cursor{Key: filter.SortKey(ref), ID: ref.ID}
+	}, filter.Descending), nil
+}
+
+func (r *memRepository) CountReferrals(ctx context.Context, filter domain.ReferralFilter) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.countReferrals(filter), nil
+}
+
+func (r *memRepository) countReferrals(filter domain.ReferralFilter) int64 {
+	var count int64
+	for _, referral := range r.referrals {
+		if referral, ok := r.joinMember(referral); ok && matchesReferralFilter(referral, filter) {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *memRepository) GetReferral(ctx context.Context, referralId string) (domain.Referral, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	referral, ok := r.referrals[referralId]
+	if ok {
+		referral, ok = r.joinMember(referral)
+	}
+	if !ok {
+		return domain.Referral{}, domain.NewError(domain.ErrNotFound, "referral %s not found", referralId)
+	}
+	return referral, nil
+}
+
+// UpdateReferralStatus moves a referral from change.FromStatus to change.ToStatus, records the
+// change and appends rewards; nothing is applied unless every step succeeds.
+func (r *memRepository) UpdateReferralStatus(ctx context.Context,
+	change domain.ReferralStatusChange,
+	rewards []domain.Reward,
+	limits ...domain.RewardLimit) error {
+	return r.UpdateReferralStatuses(ctx, []domain.ReferralStatusChange{change}, rewards, limits...)
+}
+
+func (r *memRepository) UpdateReferralStatuses(ctx context.Context,
+	changes []domain.ReferralStatusChange,
+	rewards []domain.Reward,
+	limits ...domain.RewardLimit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC().Unix()
+	// changes apply to copies so a failing change or reward leaves every referral as it was.
+	updated := map[string]domain.Referral{}
+	for _, change := range changes {
+		referral, ok := updated[change.ReferralId]
+		if !ok {
+			referral = r.referrals[change.ReferralId]
+		}
+		referral, err := changeStatus(referral, change, now)
+		if err != nil {
+			return err
+		}
+		updated[referral.ID] = referral
+	}
+
+	// limits count under the same lock that adds the rewards.
+	kept, skipped, err := limitRewards(rewards, limits, func(memberId string, programId string) (int64, error) {
+		return r.countMemberRewards(memberId, programId), nil
+	}, func(programId string, currency string) (int64, error) {
+		return r.sumProgramRewards(programId, currency), nil
+	})
+	if err != nil {
+		return err
+	}
+	var added []domain.Reward
+	for _, reward := range kept {
+		if err := r.checkReward(reward, added); err != nil {
+			return err
+		}
+		added = append(added, postings(reward, now)...)
+	}
+
+	for _, referral := range updated {
+		r.referrals[referral.ID] = referral
+	}
+	for _, change := range changes {
+		change.ID = uuid.New().String()
+		change.CreatedAt = now
+		r.statusChanges = append(r.statusChanges, change)
+	}
+	for _, reward := range added {
+		r.rewards[reward.ID] = reward
+	}
+	logSkippedRewards(r.log, skipped)
+	return nil
+}
+
+// changeStatus returns referral moved by change, releasing its claim. A missing referral is the zero value.
+func changeStatus(referral domain.Referral, change domain.ReferralStatusChange, now int64) (domain.Referral, error) {
+	if referral.ID == "" || referral.Status != change.FromStatus {
+		return referral, fmt.Errorf("referral %s is no longer %s %w", change.ReferralId, change.FromStatus, domain.ErrInvalidStatusTransition)
+	}
+	if change.StaleClaimsBefore > 0 && claimedByOther(referral, change.ChangedBy, change.StaleClaimsBefore) {
+		return referral, domain.ErrReferralClaimed
+	}
+	if !knownReferralStatus(change.ToStatus) {
+		return referral, constraintError(domain.ErrInvalidArgument, "referrals_status_check")
+	}
+	referral.Status = change.ToStatus
+	referral.StatusChangedBy = change.ChangedBy
+	referral.StatusReason = change.Reason
+	referral.ClaimedBy = ""
+	referral.ClaimedAt = 0
+	referral.UpdatedAt = now
+	return referral, nil
+}
+
+func (r *memRepository) ClaimReferral(ctx context.Context, referralId string, reviewer string, staleBefore int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	referral, ok := r.referrals[referralId]
+	if !ok {
+		return domain.NewError(domain.ErrNotFound, "referral %s not found", referralId)
+	}
+	if err := claimable(referral, reviewer, staleBefore); err != nil {
+		return err
+	}
+	referral.ClaimedBy = reviewer
+	referral.ClaimedAt = time.Now().UTC().Unix()
+	r.referrals[referralId] = referral
+	return nil
+}
+
+func (r *memRepository) ReleaseReferral(ctx context.Context, referralId string, reviewer string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	referral, ok := r.referrals[referralId]
+	if !ok {
+		return domain.NewError(domain.ErrNotFound, "referral %s not found", referralId)
+	}
+	if referral.ClaimedBy != "" && referral.ClaimedBy != reviewer {
+		return domain.ErrReferralClaimed
+	}
+	referral.ClaimedBy = ""
+	referral.ClaimedAt = 0
+	r.referrals[referralId] = referral
+	return nil
+}
+
+func (r *memRepository) SetReferralFlag(ctx context.Context, referralId string, flagged bool, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	referral, ok := r.referrals[referralId]
+	if !ok {
+		return domain.NewError(domain.ErrNotFound, "referral %s not found", referralId)
+	}
+	referral.Flagged = flagged
+	referral.FlagReason = reason
+	referral.UpdatedAt = time.Now().UTC().Unix()
+	r.referrals[referralId] = referral
+	return nil
+}
+
+func (r *memRepository) AddReferralNote(ctx context.Context, note domain.ReferralNote) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.referrals[note.ReferralId]; !ok {
+		return "", constraintError(domain.ErrInvalidArgument, "fk_referral")
+	}
+	note.ID = uuid.New().String()
+	note.CreatedAt = time.Now().UTC().Unix()
+	r.notes = append(r.notes, note)
+	return note.ID, nil
+}
+
+func (r *memRepository) GetReferralNotes(ctx context.Context, referralId string) ([]domain.ReferralNote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notes := []domain.ReferralNote{}
+	for _, note := range r.notes {
+		if note.ReferralId == referralId {
+			notes = append(notes, note)
+		}
+	}
+	return notes, nil
+}
+
+// conversion event
+
+func (r *memRepository) AddConversionEvent(ctx context.Context, event domain.ConversionEvent, change *domain.ReferralStatusChange) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC().Unix()
+	if event.ReferralId != "" {
+		if _, ok := r.referrals[event.ReferralId]; !ok {
+			return "", constraintError(domain.ErrInvalidArgument, "fk_referral")
+		}
+	}
+	for _, other := range r.conversions {
+		if event.ExternalId != "" && other.ExternalId == event.ExternalId {
+			return "", constraintError(domain.ErrAlreadyExists, "conversion_events_external_id_key")
+		}
+	}
+
+	if change != nil {
+		referral, err := changeStatus(r.referrals[change.ReferralId], *change, now)
+		if err != nil {
+			return "", err
+		}
+		referral.OrderAmount = event.OrderAmount
+		r.referrals[referral.ID] = referral
+
+		statusChange := *change
+		statusChange.ID = uuid.New().String()
+		statusChange.CreatedAt = now
+		r.statusChanges = append(r.statusChanges, statusChange)
+	}
+
+	event.ID = uuid.New().String()
+	event.CreatedAt = now
+	r.conversions = append(r.conversions, event)
+	return event.ID, nil
+}
+
+func (r *memRepository) GetConversionEvent(ctx context.Context, externalId string) (domain.ConversionEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, event := range r.conversions {
+		if externalId != "" && event.ExternalId == externalId {
+			return event, nil
+		}
+	}
+	return domain.ConversionEvent{}, domain.NewError(domain.ErrNotFound, "conversion event %s not found", externalId)
+}
+
+func (r *memRepository) ExpireReferrals(ctx context.Context, now int64, limit int64, changedBy string, reason string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stale []domain.Referral
+	for _, referral := range r.referrals {
+		if referral.Status != domain.ReferralStatusPending {
+			continue
+		}
+		joined, ok := r.joinMember(referral)
+		if !ok {
+			continue
+		}
+		if r.programs[joined.ProgramId].Expired(joined, now) {
+			stale = append(stale, referral)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		if stale[i].CreatedAt != stale[j].CreatedAt {
+			return stale[i].CreatedAt < stale[j].CreatedAt
+		}
+		return stale[i].ID < stale[j].ID
+	})
+	if int64(len(stale)) > limit {
+		stale = stale[:limit]
+	}
+
+	expired := make([]string, 0, len(stale))
+	for _, referral := range stale {
+		change := domain.ReferralStatusChange{
+			ID:         uuid.New().String(),
+			ReferralId: referral.ID,
+			FromStatus: domain.ReferralStatusPending,
+			ToStatus:   domain.ReferralStatusExpired,
+			ChangedBy:  changedBy,
+			Reason:     reason,
+			CreatedAt:  now,
+		}
+		referral, err := changeStatus(referral, change, now)
+		if err != nil {
+			return nil, err
+		}
+		r.referrals[referral.ID] = referral
+		r.statusChanges = append(r.statusChanges, change)
+		expired = append(expired, referral.ID)
+	}
+	return expired, nil
+}
+
+// joinMember fills in the program, member and channel of the code, dropping referrals without one like the postgres join does.
+func (r *memRepository) joinMember(referral domain.Referral) (domain.Referral, bool) {
+	member, ok := r.memberByCode(referral.ReferralCode)
+	if !ok {
+		return referral, false
+	}
+	referral.ProgramId = member.ProgramId
+	referral.MemberId = member.ID
+	referral.Channel = r.codes[referral.ReferralCode].Channel
+	return referral, true
+}
+
+func matchesReferralFilter(referral domain.Referral, filter domain.ReferralFilter) bool {
+	switch {
+	case filter.ProgramId != nil && referral.ProgramId != *filter.ProgramId,
+		filter.MemberId != nil && referral.MemberId != *filter.MemberId,
+		filter.ReferralCode != nil && referral.ReferralCode != *filter.ReferralCode,
+		filter.Channel != nil && referral.Channel != *filter.Channel,
+		filter.Status != nil && referral.Status != *filter.Status,
+		filter.Flagged != nil && referral.Flagged != *filter.Flagged,
+		filter.Email != nil && strings.ToLower(referral.Email) != strings.ToLower(*filter.Email),
+		filter.ContactEmail != nil && referral.ContactEmail != *filter.ContactEmail,
+		filter.ContactPhone != nil && referral.ContactPhone != *filter.ContactPhone,
+		filter.IpHash != nil && referral.IpHash != *filter.IpHash,
+		filter.ClaimedBy != nil && referral.ClaimedBy != *filter.ClaimedBy,
+		filter.CreatedAfter != nil && referral.CreatedAt < *filter.CreatedAfter,
+		filter.CreatedBefore != nil && referral.CreatedAt >= *filter.CreatedBefore:
+		return false
+	}
+	return true
+}
+
+func knownReferralStatus(status string) bool {
+	switch status {
+	case domain.ReferralStatusPending, domain.ReferralStatusQualified, domain.ReferralStatusApproved, domain.ReferralStatusDenied, domain.ReferralStatusExpired:
+		return true
+	}
+	return false
+}
+
+// reward
+
+func (r *memRepository) AddReward(ctx context.Context, reward domain.Reward) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkReward(reward, nil); err != nil {
+		return "", err
+	}
+	entries := postings(reward, time.Now().UTC().Unix())
+	for _, entry := range entries {
+		r.rewards[entry.ID] = entry
+	}
+	return entries[0].ID, nil
+}
+
+func (r *memRepository) GetReward(ctx context.Context, rewardId string) (domain.Reward, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reward, ok := r.rewards[rewardId]
+	if !ok {
+		return domain.Reward{}, domain.NewError(domain.ErrNotFound, "reward %s not found", rewardId)
+	}
+	return reward, nil
+}
+
+func (r *memRepository) GetRewards(ctx context.Context, memberId string, page domain.Page) ([]domain.Reward, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rewards := []domain.Reward{}
+	for _, reward := range r.rewards {
+		if reward.MemberId == memberId {
+			rewards = append(rewards, reward)
+		}
+	}
+	return paged(rewards, page, func(rw domain.Reward) domain.Cursor {
+		return domain.Cursor{Key: rw.CreatedAt, ID: rw.ID}
+	}, false), nil
+}
+
+// GetRewardBalances nets credits against debits per currency for a member.
+func (r *memRepository) GetRewardBalances(ctx context.Context, memberId string) ([]domain.RewardBalance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	totals := map[string]int64{}
+	for _, reward := range r.rewards {
+		if reward.MemberId == memberId {
+			totals[reward.Currency] += reward.SignedAmount()
+		}
+	}
+	balances := make([]domain.RewardBalance, 0, len(totals))
+	for currency, amount := range totals {
+		balances = append(balances, domain.RewardBalance{MemberId: memberId, Currency: currency, Amount: amount})
+	}
+	sort.Slice(balances, func(i, j int) bool { return balances[i].Currency < balances[j].Currency })
+	return balances, nil
+}
+
+// CountMemberRewards counts the referrer credits a member has earned in a program, ignoring reversed ones.
+func (r *memRepository) CountMemberRewards(ctx context.Context, memberId string, programId string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.countMemberRewards(memberId, programId), nil
+}
+
+// countMemberRewards is CountMemberRewards for callers holding the lock.
+func (r *memRepository) countMemberRewards(memberId string, programId string) int64 {
+	reversed := map[string]bool{}
+	for _, reward := range r.rewards {
+		if reward.ReversalOf != "" {
+			reversed[reward.ReversalOf] = true
+		}
+	}
+	var count int64
+	for _, reward := range r.rewards {
+		if reward.EntryType == domain.RewardEntryCredit &&
+			reward.Beneficiary == domain.RewardBeneficiaryReferrer &&
+			reward.MemberId == memberId &&
+			reward.ProgramId == programId &&
+			!reversed[reward.ID] {
+			count++
+		}
+	}
+	return count
+}
+
+// SumProgramRewards nets every credit and debit a program has issued in currency.
+func (r *memRepository) SumProgramRewards(ctx context.Context, programId string, currency string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sumProgramRewards(programId, currency), nil
+}
+
+// sumProgramRewards is SumProgramRewards for callers holding the lock.
+func (r *memRepository) sumProgramRewards(programId string, currency string) int64 {
+	var total int64
+	for _, reward := range r.rewards {
+		if reward.Account == domain.RewardAccountBeneficiary && reward.ProgramId == programId && reward.Currency == currency {
+			total += reward.SignedAmount()
+		}
+	}
+	return total
+}
+
+// checkReward enforces the rewards table constraints for reward against the stored
+// rewards and pending ones about to be stored with it.
+func (r *memRepository) checkReward(reward domain.Reward, pending []domain.Reward) error {
+	switch {
+	case reward.Beneficiary != domain.RewardBeneficiaryReferrer && reward.Beneficiary != domain.RewardBeneficiaryReferee:
+		return constraintError(domain.ErrInvalidArgument, "rewards_beneficiary_check")
+	case reward.EntryType != domain.RewardEntryCredit && reward.EntryType != domain.RewardEntryDebit:
+		return constraintError(domain.ErrInvalidArgument, "rewards_entry_type_check")
+	case reward.Amount <= 0:
+		return constraintError(domain.ErrInvalidArgument, "rewards_amount_check")
+	}
+	if _, ok := r.referrals[reward.ReferralId]; !ok {
+		return constraintError(domain.ErrInvalidArgument, "fk_referral")
+	}
+
+	check := func(other domain.Reward) error {
+		// the program's entries mirror the beneficiaries' and aren't constrained themselves.
+		if other.Account != domain.RewardAccountBeneficiary {
+			return nil
+		}
+		if reward.EntryType == domain.RewardEntryCredit && other.EntryType == domain.RewardEntryCredit &&
+			other.ReferralId == reward.ReferralId && other.Beneficiary == reward.Beneficiary {
+			return constraintError(domain.ErrAlreadyExists, "rewards_credit_referral_beneficiary")
+		}
+		if reward.ReversalOf != "" && other.ReversalOf == reward.ReversalOf {
+			return constraintError(domain.ErrAlreadyExists, "rewards_reversal_of")
+		}
+		return nil
+	}
+	for _, other := range r.rewards {
+		if err := check(other); err != nil {
+			return err
+		}
+	}
+	for _, other := range pending {
+		if err := check(other); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkRewardType(rewardType string) error {
+	switch rewardType {
+	case "", domain.RewardTypeFixed, domain.RewardTypePercentage:
+		return nil
+	}
+	return constraintError(domain.ErrInvalidArgument, "programs_reward_type_check")
+}
+
+// click
+
+func (r *memRepository) AddClick(ctx context.Context, click domain.Click) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.members[click.MemberId]; !ok {
+		return "", constraintError(domain.ErrInvalidArgument, "fk_member")
+	}
+	click.ID = uuid.New().String()
+	click.CreatedAt = time.Now().UTC().Unix()
+	r.clicks = append(r.clicks, click)
+	return click.ID, nil
+}
+
+// GetClickStats counts clicks, optionally narrowed to a member and/or a program.
+func (r *memRepository) GetClickStats(ctx context.Context, memberId *string, programId *string) (domain.ClickStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := domain.ClickStats{}
+	visitors := map[string]bool{}
+	for _, click := range r.clicks {
+		if memberId != nil && click.MemberId != *memberId || programId != nil && click.ProgramId != *programId {
+			continue
+		}
+		stats.Clicks++
+		visitors[click.IpHash] = true
+	}
+	stats.UniqueVisitors = int64(len(visitors))
+	return stats, nil
+}
+
+// paged orders rows by their (key, id) cursor and cuts out page, the way pagedQuery does in SQL.
+func paged[T any](rows []T, page domain.Page, cursor func(T) domain.Cursor, desc bool) []T {
+	less := func(a, b domain.Cursor) bool {
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		return a.ID < b.ID
+	}
+	before := func(a, b domain.Cursor) bool {
+		if desc {
+			return less(b, a)
+		}
+		return less(a, b)
+	}
+	sort.Slice(rows, func(i, j int) bool { return before(cursor(rows[i]), cursor(rows[j])) })
+
+	if page.After != nil {
+		after := *page.After
+		rows = rows[sort.Search(len(rows), func(i int) bool { return before(after, cursor(rows[i])) }):]
+	} else {
+		offset := (page.Number - 1) * page.Size
+		if offset < 0 {
+			offset = 0
+		}
+		if offset > len(rows) {
+			offset = len(rows)
+		}
+		rows = rows[offset:]
+	}
+	if page.Size >= 0 && page.Size < len(rows) {
+		rows = rows[:page.Size]
+	}
+	return rows
+}
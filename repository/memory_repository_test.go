@@ -0,0 +1,16 @@
+package repository_test
+
+import (
+	"testing"
+
+	"referral-service/repository"
+	"referral-service/repository/repotest"
+
+	"go.uber.org/zap"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		return repository.NewMemory(zap.NewNop())
+	})
+}
@@ -0,0 +1,222 @@
+// Package migrate applies the versioned postgres schema migrations embedded in sql/.
+//
+// Migrations are named NNNN_description.up.sql and NNNN_description.down.sql, are
+// numbered from 0001 without gaps and are applied in order, each in a transaction
+// of its own that also records it in schema_migrations. Up migrations must be
+// idempotent so they can run against databases created before migrations existed.
+package migrate
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+const migrationsSchema = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version int PRIMARY KEY,
+    name text NOT NULL,
+    applied_at int NOT NULL
+);
+`
+
+// lockKey serializes migrators across service instances, see pg_advisory_xact_lock.
+const lockKey = 0x726566 // "ref"
+
+var fileName = regexp.MustCompile(`^(\d{4})_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one schema version.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration and when it was applied, zero if it is pending.
+type Status struct {
+	Migration
+	AppliedAt int64
+}
+
+type Migrator struct {
+	log        *zap.Logger
+	db         *sqlx.DB
+	migrations []Migration
+}
+
+// New returns a migrator for db using the embedded migrations.
+func New(log *zap.Logger, db *sqlx.DB) (*Migrator, error) {
+	migrations, err := load(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{log: log, db: db, migrations: migrations}, nil
+}
+
+// Up applies every pending migration and returns the ones it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	for _, migration := range m.migrations {
+		ok, err := m.apply(ctx, migration, true)
+		if err != nil {
+			return applied, err
+		}
+		if ok {
+			applied = append(applied, migration)
+		}
+	}
+	return applied, nil
+}
+
+// Down rolls back the last steps applied migrations and returns the ones it rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rolledBack []Migration
+	for i := len(statuses) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+		if statuses[i].AppliedAt == 0 {
+			continue
+		}
+		ok, err := m.apply(ctx, statuses[i].Migration, false)
+		if err != nil {
+			return rolledBack, err
+		}
+		if ok {
+			rolledBack = append(rolledBack, statuses[i].Migration)
+		}
+	}
+	return rolledBack, nil
+}
+
+// Status lists every known migration in order with when it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if _, err := m.db.ExecContext(ctx, migrationsSchema); err != nil {
+		return nil, fmt.Errorf("schema_migrations create %w", err)
+	}
+	rows := []struct {
+		Version   int   `db:"version"`
+		AppliedAt int64 `db:"applied_at"`
+	}{}
+	if err := m.db.SelectContext(ctx, &rows, "SELECT version, applied_at FROM schema_migrations"); err != nil {
+		return nil, fmt.Errorf("schema_migrations select %w", err)
+	}
+	appliedAt := map[int]int64{}
+	for _, row := range rows {
+		appliedAt[row.Version] = row.AppliedAt
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		statuses = append(statuses, Status{Migration: migration, AppliedAt: appliedAt[migration.Version]})
+	}
+	return statuses, nil
+}
+
+// apply runs migration up or down unless another migrator already did, and reports whether it ran.
+func (m *Migrator) apply(ctx context.Context, migration Migration, up bool) (bool, error) {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("migration transaction begin %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", lockKey); err != nil {
+		return false, fmt.Errorf("migration lock %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, migrationsSchema); err != nil {
+		return false, fmt.Errorf("schema_migrations create %w", err)
+	}
+	var count int
+	err = tx.GetContext(ctx, &count, "SELECT COUNT(*) FROM schema_migrations WHERE version=$1", migration.Version)
+	if err != nil {
+		return false, fmt.Errorf("schema_migrations select %w", err)
+	}
+	if applied := count > 0; applied == up {
+		return false, nil
+	}
+
+	script, record, args := migration.Up, "INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)",
+		[]interface{}{migration.Version, migration.Name, time.Now().UTC().Unix()}
+	if !up {
+		script, record, args = migration.Down, "DELETE FROM schema_migrations WHERE version=$1", []interface{}{migration.Version}
+	}
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return false, fmt.Errorf("migration %04d_%s exec %w", migration.Version, migration.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return false, fmt.Errorf("schema_migrations record %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit transaction %w", err)
+	}
+
+	direction := "applied"
+	if !up {
+		direction = "rolled back"
+	}
+	m.log.Info("migration "+direction, zap.Int("version", migration.Version), zap.String("name", migration.Name))
+	return true, nil
+}
+
+// load reads the migrations in fsys, checking every version has both scripts and none is missing.
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("migrations read %w", err)
+	}
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %s is not named NNNN_name.up.sql or NNNN_name.down.sql", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		script, err := fs.ReadFile(fsys, "sql/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("migration read %w", err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %04d has two names %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(script)
+		} else {
+			migration.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			return nil, fmt.Errorf("migration %04d is missing", i+1)
+		}
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both an up and a down script", migration.Version, migration.Name)
+		}
+	}
+	return migrations, nil
+}
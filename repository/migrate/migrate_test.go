@@ -0,0 +1,37 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadEmbedded(t *testing.T) {
+	migrations, err := load(files)
+	if err != nil {
+		t.Fatalf("load embedded migrations: %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != 1 || migrations[0].Name != "init" {
+		t.Fatalf("load embedded migrations = %+v", migrations)
+	}
+}
+
+func TestLoadRejectsBrokenSets(t *testing.T) {
+	file := &fstest.MapFile{Data: []byte("SELECT 1;")}
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{"bad name", fstest.MapFS{"sql/init.up.sql": file}},
+		{"missing down", fstest.MapFS{"sql/0001_init.up.sql": file}},
+		{"gap", fstest.MapFS{
+			"sql/0001_init.up.sql": file, "sql/0001_init.down.sql": file,
+			"sql/0003_next.up.sql": file, "sql/0003_next.down.sql": file,
+		}},
+		{"two names", fstest.MapFS{"sql/0001_init.up.sql": file, "sql/0001_other.down.sql": file}},
+	}
+	for _, tt := range tests {
+		if _, err := load(tt.files); err == nil {
+			t.Errorf("load(%s) succeeded, want an error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS referrals;
+DROP TABLE IF EXISTS members;
+DROP TABLE IF EXISTS programs;
@@ -35,4 +35,4 @@ CREATE TABLE IF NOT EXISTS referrals (
     CONSTRAINT fk_member FOREIGN KEY (referral_code) REFERENCES members(referral_code)
         ON DELETE CASCADE
         ON UPDATE CASCADE
-);
\ No newline at end of file
+);
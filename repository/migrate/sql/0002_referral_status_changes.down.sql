@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS referral_status_changes;
+
+ALTER TABLE referrals DROP COLUMN IF EXISTS status_reason;
+ALTER TABLE referrals DROP COLUMN IF EXISTS status_changed_by;
@@ -0,0 +1,15 @@
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS status_changed_by text NOT NULL DEFAULT '';
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS status_reason text NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS referral_status_changes (
+    id text PRIMARY KEY,
+    referral_id text NOT NULL,
+    from_status text NOT NULL,
+    to_status text NOT NULL,
+    changed_by text NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    created_at int,
+    CONSTRAINT fk_referral FOREIGN KEY (referral_id) REFERENCES referrals(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
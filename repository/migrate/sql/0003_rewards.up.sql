@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS rewards (
+    id text PRIMARY KEY,
+    member_id text NOT NULL,
+    program_id text NOT NULL,
+    referral_id text NOT NULL,
+    entry_type text CHECK (entry_type IN ('credit', 'debit')),
+    amount bigint NOT NULL CHECK (amount > 0),
+    currency text NOT NULL,
+    reversal_of text NOT NULL DEFAULT '',
+    reason text NOT NULL DEFAULT '',
+    created_by text NOT NULL DEFAULT '',
+    created_at int,
+    CONSTRAINT fk_member FOREIGN KEY (member_id) REFERENCES members(id),
+    CONSTRAINT fk_referral FOREIGN KEY (referral_id) REFERENCES referrals(id)
+);
+
+-- a referral credits a member at most once and a credit is reversed at most once.
+CREATE UNIQUE INDEX IF NOT EXISTS rewards_credit_referral_member ON rewards (referral_id, member_id) WHERE entry_type = 'credit';
+CREATE UNIQUE INDEX IF NOT EXISTS rewards_reversal_of ON rewards (reversal_of) WHERE reversal_of <> '';
@@ -0,0 +1,17 @@
+-- referee entries have no member and can't be kept once rewards reference members again.
+DELETE FROM rewards WHERE beneficiary = 'referee';
+
+DROP INDEX IF EXISTS rewards_credit_referral_beneficiary;
+CREATE UNIQUE INDEX IF NOT EXISTS rewards_credit_referral_member ON rewards (referral_id, member_id) WHERE entry_type = 'credit';
+
+ALTER TABLE rewards ALTER COLUMN member_id DROP DEFAULT;
+ALTER TABLE rewards DROP CONSTRAINT IF EXISTS fk_member;
+ALTER TABLE rewards ADD CONSTRAINT fk_member FOREIGN KEY (member_id) REFERENCES members(id);
+ALTER TABLE rewards DROP COLUMN IF EXISTS beneficiary;
+
+ALTER TABLE programs DROP COLUMN IF EXISTS reward_cap;
+ALTER TABLE programs DROP COLUMN IF EXISTS max_rewards_per_member;
+ALTER TABLE programs DROP COLUMN IF EXISTS referee_reward;
+ALTER TABLE programs DROP COLUMN IF EXISTS referrer_reward;
+ALTER TABLE programs DROP COLUMN IF EXISTS reward_currency;
+ALTER TABLE programs DROP COLUMN IF EXISTS reward_type;
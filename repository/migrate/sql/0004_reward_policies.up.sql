@@ -0,0 +1,18 @@
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS reward_type text NOT NULL DEFAULT '' CHECK (reward_type IN ('', 'fixed', 'percentage'));
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS reward_currency text NOT NULL DEFAULT '';
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS referrer_reward bigint NOT NULL DEFAULT 0;
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS referee_reward bigint NOT NULL DEFAULT 0;
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS max_rewards_per_member bigint NOT NULL DEFAULT 0;
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS reward_cap bigint NOT NULL DEFAULT 0;
+
+-- entries written before referee rewards existed all credit the referrer.
+ALTER TABLE rewards ADD COLUMN IF NOT EXISTS beneficiary text NOT NULL DEFAULT 'referrer' CHECK (beneficiary IN ('referrer', 'referee'));
+ALTER TABLE rewards ALTER COLUMN beneficiary DROP DEFAULT;
+
+-- empty for referee entries, the referred contact isn't a member.
+ALTER TABLE rewards ALTER COLUMN member_id SET DEFAULT '';
+ALTER TABLE rewards DROP CONSTRAINT IF EXISTS fk_member;
+
+-- a referral credits each beneficiary at most once.
+DROP INDEX IF EXISTS rewards_credit_referral_member;
+CREATE UNIQUE INDEX IF NOT EXISTS rewards_credit_referral_beneficiary ON rewards (referral_id, beneficiary) WHERE entry_type = 'credit';
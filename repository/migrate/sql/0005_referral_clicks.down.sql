@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS referral_clicks;
+
+ALTER TABLE programs DROP COLUMN IF EXISTS landing_url;
@@ -0,0 +1,18 @@
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS landing_url text NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS referral_clicks (
+    id text PRIMARY KEY,
+    referral_code text NOT NULL,
+    member_id text NOT NULL,
+    program_id text NOT NULL,
+    user_agent text NOT NULL DEFAULT '',
+    referer text NOT NULL DEFAULT '',
+    ip_hash text NOT NULL DEFAULT '',
+    created_at int,
+    CONSTRAINT fk_member FOREIGN KEY (member_id) REFERENCES members(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS referral_clicks_member_id ON referral_clicks (member_id);
+CREATE INDEX IF NOT EXISTS referral_clicks_program_id ON referral_clicks (program_id);
@@ -0,0 +1 @@
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS code_prefix text NOT NULL DEFAULT '';
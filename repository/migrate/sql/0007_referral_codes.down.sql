@@ -0,0 +1,12 @@
+-- referrals made with a retired code move to their member's current code.
+UPDATE referrals r SET referral_code = m.referral_code
+FROM referral_codes c JOIN members m ON c.member_id = m.id
+WHERE r.referral_code = c.code AND c.code <> m.referral_code;
+
+ALTER TABLE referrals DROP CONSTRAINT IF EXISTS fk_referral_code;
+ALTER TABLE referrals DROP CONSTRAINT IF EXISTS fk_member;
+ALTER TABLE referrals ADD CONSTRAINT fk_member FOREIGN KEY (referral_code) REFERENCES members(referral_code)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE;
+
+DROP TABLE IF EXISTS referral_codes;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS referral_codes (
+    code text PRIMARY KEY,
+    member_id text NOT NULL,
+    created_at int,
+    retired_at int NOT NULL DEFAULT 0,
+    CONSTRAINT fk_member FOREIGN KEY (member_id) REFERENCES members(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS referral_codes_member_id ON referral_codes (member_id);
+
+-- codes are case insensitive from now on; the update cascades to referrals.
+-- Codes that would collide once lowercased are left as they are.
+UPDATE members SET referral_code = lower(referral_code)
+WHERE referral_code <> lower(referral_code)
+    AND NOT EXISTS (SELECT 1 FROM members o WHERE o.referral_code = lower(members.referral_code));
+
+INSERT INTO referral_codes (code, member_id, created_at)
+SELECT referral_code, id, created_at FROM members
+ON CONFLICT (code) DO NOTHING;
+
+-- referrals keep the code they were made with, which may have been retired since.
+ALTER TABLE referrals DROP CONSTRAINT IF EXISTS fk_member;
+ALTER TABLE referrals DROP CONSTRAINT IF EXISTS fk_referral_code;
+ALTER TABLE referrals ADD CONSTRAINT fk_referral_code FOREIGN KEY (referral_code) REFERENCES referral_codes(code)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE;
@@ -0,0 +1,3 @@
+ALTER TABLE referral_codes DROP COLUMN IF EXISTS revoked_at;
+ALTER TABLE referral_codes DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE referral_codes DROP COLUMN IF EXISTS channel;
@@ -0,0 +1,3 @@
+ALTER TABLE referral_codes ADD COLUMN IF NOT EXISTS channel text NOT NULL DEFAULT '';
+ALTER TABLE referral_codes ADD COLUMN IF NOT EXISTS expires_at int NOT NULL DEFAULT 0;
+ALTER TABLE referral_codes ADD COLUMN IF NOT EXISTS revoked_at int NOT NULL DEFAULT 0;
@@ -0,0 +1,15 @@
+-- emails become unique across programs again, only each person's first enrolment is kept.
+DELETE FROM members m WHERE EXISTS (
+    SELECT 1 FROM members o
+    WHERE o.person_id = m.person_id AND (o.created_at, o.id) < (m.created_at, m.id)
+);
+
+DROP INDEX IF EXISTS members_program_id;
+ALTER TABLE members DROP CONSTRAINT IF EXISTS members_person_id_program_id_key;
+ALTER TABLE members DROP CONSTRAINT IF EXISTS members_email_program_id_key;
+ALTER TABLE members DROP CONSTRAINT IF EXISTS members_email_key;
+ALTER TABLE members ADD CONSTRAINT members_email_key UNIQUE (email);
+
+ALTER TABLE members DROP CONSTRAINT IF EXISTS fk_person;
+ALTER TABLE members DROP COLUMN IF EXISTS person_id;
+DROP TABLE IF EXISTS people;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS people (
+    id text PRIMARY KEY,
+    email text unique NOT NULL,
+    created_at int
+);
+
+-- emails were unique across members so far, every member becomes a person of their own.
+INSERT INTO people (id, email, created_at)
+SELECT id, email, created_at FROM members
+ON CONFLICT (email) DO NOTHING;
+
+ALTER TABLE members ADD COLUMN IF NOT EXISTS person_id text;
+UPDATE members m SET person_id = p.id FROM people p WHERE p.email = m.email AND m.person_id IS NULL;
+ALTER TABLE members ALTER COLUMN person_id SET NOT NULL;
+ALTER TABLE members DROP CONSTRAINT IF EXISTS fk_person;
+ALTER TABLE members ADD CONSTRAINT fk_person FOREIGN KEY (person_id) REFERENCES people(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE;
+
+-- a person enrols in a program at most once.
+ALTER TABLE members DROP CONSTRAINT IF EXISTS members_email_key;
+ALTER TABLE members DROP CONSTRAINT IF EXISTS members_email_program_id_key;
+ALTER TABLE members ADD CONSTRAINT members_email_program_id_key UNIQUE (email, program_id);
+ALTER TABLE members DROP CONSTRAINT IF EXISTS members_person_id_program_id_key;
+ALTER TABLE members ADD CONSTRAINT members_person_id_program_id_key UNIQUE (person_id, program_id);
+
+CREATE INDEX IF NOT EXISTS members_program_id ON members (program_id);
@@ -0,0 +1,7 @@
+ALTER TABLE referrals DROP COLUMN IF EXISTS flag_reason;
+ALTER TABLE referrals DROP COLUMN IF EXISTS flagged;
+
+DROP INDEX IF EXISTS referrals_contact_phone;
+DROP INDEX IF EXISTS referrals_contact_email;
+ALTER TABLE referrals DROP COLUMN IF EXISTS contact_phone;
+ALTER TABLE referrals DROP COLUMN IF EXISTS contact_email;
@@ -0,0 +1,14 @@
+-- contact_email and contact_phone are the referee's normalised email and phone,
+-- which duplicate referrals are found by.
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS contact_email text NOT NULL DEFAULT '';
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS contact_phone text NOT NULL DEFAULT '';
+UPDATE referrals SET
+    contact_email = lower(trim(coalesce(email, ''))),
+    contact_phone = regexp_replace(coalesce(phone, ''), '[^0-9]', '', 'g')
+WHERE contact_email = '' AND contact_phone = '';
+
+CREATE INDEX IF NOT EXISTS referrals_contact_email ON referrals (contact_email) WHERE contact_email <> '';
+CREATE INDEX IF NOT EXISTS referrals_contact_phone ON referrals (contact_phone) WHERE contact_phone <> '';
+
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS flagged boolean NOT NULL DEFAULT false;
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS flag_reason text NOT NULL DEFAULT '';
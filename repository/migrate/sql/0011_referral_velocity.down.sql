@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS referrals_created_at;
+DROP INDEX IF EXISTS referrals_ip_hash_created_at;
+DROP INDEX IF EXISTS referrals_referral_code_created_at;
+ALTER TABLE referrals DROP COLUMN IF EXISTS ip_hash;
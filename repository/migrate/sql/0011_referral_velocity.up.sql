@@ -0,0 +1,7 @@
+-- salted hash of the address the referral was submitted from, see referral_link.ip_hash_salt.
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS ip_hash text NOT NULL DEFAULT '';
+
+-- velocity limits count recent referrals per code, program and address.
+CREATE INDEX IF NOT EXISTS referrals_referral_code_created_at ON referrals (referral_code, created_at);
+CREATE INDEX IF NOT EXISTS referrals_ip_hash_created_at ON referrals (ip_hash, created_at) WHERE ip_hash <> '';
+CREATE INDEX IF NOT EXISTS referrals_created_at ON referrals (created_at);
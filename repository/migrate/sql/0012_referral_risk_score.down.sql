@@ -0,0 +1,3 @@
+-- contact emails keep their normalised form, they were only ever compared.
+DROP INDEX IF EXISTS referrals_risk_score;
+ALTER TABLE referrals DROP COLUMN IF EXISTS risk_score;
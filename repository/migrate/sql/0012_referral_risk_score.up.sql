@@ -0,0 +1,13 @@
+-- risk_score rates how likely a referral is abusive, from 0 to 100.
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS risk_score integer NOT NULL DEFAULT 0
+    CONSTRAINT referrals_risk_score_check CHECK (risk_score BETWEEN 0 AND 100);
+CREATE INDEX IF NOT EXISTS referrals_risk_score ON referrals (risk_score, id);
+
+-- contact emails drop plus suffixes, and gmail ones the dots too, so aliases of one inbox match.
+UPDATE referrals SET contact_email =
+    CASE WHEN split_part(contact_email, '@', 2) IN ('gmail.com', 'googlemail.com')
+        THEN replace(split_part(split_part(contact_email, '@', 1), '+', 1), '.', '') || '@gmail.com'
+        ELSE split_part(split_part(contact_email, '@', 1), '+', 1) || '@' || split_part(contact_email, '@', 2)
+    END
+WHERE contact_email LIKE '_%@%' AND contact_email NOT LIKE '%@%@%' AND contact_email NOT LIKE '+%'
+    AND (contact_email LIKE '%+%@%' OR split_part(contact_email, '@', 2) IN ('gmail.com', 'googlemail.com'));
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS referral_notes;
+
+DROP INDEX IF EXISTS referrals_status_risk_score;
+ALTER TABLE referrals DROP COLUMN IF EXISTS claimed_at;
+ALTER TABLE referrals DROP COLUMN IF EXISTS claimed_by;
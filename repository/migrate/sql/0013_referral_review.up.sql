@@ -0,0 +1,16 @@
+-- the reviewer working on a pending referral and since when, empty when nobody is.
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS claimed_by text NOT NULL DEFAULT '';
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS claimed_at int NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS referrals_status_risk_score ON referrals (status, risk_score, id);
+
+CREATE TABLE IF NOT EXISTS referral_notes (
+    id text PRIMARY KEY,
+    referral_id text NOT NULL,
+    author text NOT NULL,
+    note text NOT NULL,
+    created_at int,
+    CONSTRAINT fk_referral FOREIGN KEY (referral_id) REFERENCES referrals(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS referral_notes_referral_id ON referral_notes (referral_id, created_at);
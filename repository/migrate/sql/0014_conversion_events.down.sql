@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS conversion_events;
+
+ALTER TABLE referrals DROP COLUMN IF EXISTS order_amount;
+
+ALTER TABLE programs DROP COLUMN IF EXISTS qualify_within_days;
+ALTER TABLE programs DROP COLUMN IF EXISTS qualify_min_order;
+ALTER TABLE programs DROP COLUMN IF EXISTS qualify_event;
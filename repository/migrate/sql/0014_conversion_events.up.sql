@@ -0,0 +1,27 @@
+-- the conversion event that qualifies a program's referrals, an empty event turns it off.
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS qualify_event text NOT NULL DEFAULT '';
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS qualify_min_order bigint NOT NULL DEFAULT 0;
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS qualify_within_days int NOT NULL DEFAULT 0;
+
+-- the order that qualified the referral, the base for percentage rewards on approval.
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS order_amount bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS conversion_events (
+    id text PRIMARY KEY,
+    external_id text NOT NULL DEFAULT '',
+    event_type text NOT NULL,
+    contact_email text NOT NULL DEFAULT '',
+    contact_phone text NOT NULL DEFAULT '',
+    order_amount bigint NOT NULL DEFAULT 0,
+    -- null when the event matched no referral.
+    referral_id text,
+    qualified boolean NOT NULL DEFAULT false,
+    occurred_at int NOT NULL,
+    created_at int,
+    CONSTRAINT fk_referral FOREIGN KEY (referral_id) REFERENCES referrals(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+-- events sent again with the same id are ingested once.
+CREATE UNIQUE INDEX IF NOT EXISTS conversion_events_external_id_key ON conversion_events (external_id) WHERE external_id <> '';
+CREATE INDEX IF NOT EXISTS conversion_events_referral_id ON conversion_events (referral_id);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS referrals_pending_created_at;
+
+-- expired referrals go back to pending, the status changes recording their expiry are dropped.
+DELETE FROM referral_status_changes WHERE to_status = 'expired';
+UPDATE referrals SET status = 'pending', status_changed_by = '', status_reason = '' WHERE status = 'expired';
+
+ALTER TABLE referrals DROP CONSTRAINT IF EXISTS referrals_status_check;
+ALTER TABLE referrals ADD CONSTRAINT referrals_status_check
+    CHECK (status IN ('pending', 'qualified', 'approved', 'denied'));
+
+ALTER TABLE programs DROP COLUMN IF EXISTS attribution_window_days;
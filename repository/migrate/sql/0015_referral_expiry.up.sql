@@ -0,0 +1,10 @@
+-- how long a program's referrals stay pending before the expiry job expires them, 0 never does.
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS attribution_window_days int NOT NULL DEFAULT 0
+    CHECK (attribution_window_days >= 0);
+
+ALTER TABLE referrals DROP CONSTRAINT IF EXISTS referrals_status_check;
+ALTER TABLE referrals ADD CONSTRAINT referrals_status_check
+    CHECK (status IN ('pending', 'qualified', 'approved', 'denied', 'expired'));
+
+-- the expiry job scans pending referrals oldest first.
+CREATE INDEX IF NOT EXISTS referrals_pending_created_at ON referrals (created_at, id) WHERE status = 'pending';
@@ -0,0 +1,6 @@
+-- people merged on the way up stay merged.
+DROP INDEX IF EXISTS members_email_program_id_key;
+ALTER TABLE members ADD CONSTRAINT members_email_program_id_key UNIQUE (email, program_id);
+
+DROP INDEX IF EXISTS people_email_key;
+ALTER TABLE people ADD CONSTRAINT people_email_key UNIQUE (email);
@@ -0,0 +1,22 @@
+-- emails are unique regardless of case, as they are looked up. People whose emails differ
+-- only in case become the oldest of them; if such people are enrolled in the same program
+-- the update fails and those enrolments have to be merged by hand first.
+UPDATE members m SET person_id = k.id
+FROM people p, people k
+WHERE m.person_id = p.id AND lower(k.email) = lower(p.email)
+    AND NOT EXISTS (
+        SELECT 1 FROM people o
+        WHERE lower(o.email) = lower(k.email) AND (COALESCE(o.created_at, 0), o.id) < (COALESCE(k.created_at, 0), k.id)
+    )
+    AND k.id <> p.id;
+DELETE FROM people p WHERE NOT EXISTS (SELECT 1 FROM members m WHERE m.person_id = p.id)
+    AND EXISTS (
+        SELECT 1 FROM people o
+        WHERE lower(o.email) = lower(p.email) AND (COALESCE(o.created_at, 0), o.id) < (COALESCE(p.created_at, 0), p.id)
+    );
+
+ALTER TABLE people DROP CONSTRAINT IF EXISTS people_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS people_email_key ON people (lower(email));
+
+ALTER TABLE members DROP CONSTRAINT IF EXISTS members_email_program_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS members_email_program_id_key ON members (lower(email), program_id);
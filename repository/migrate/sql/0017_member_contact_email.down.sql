@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS members_program_id_contact_email;
+ALTER TABLE members DROP COLUMN IF EXISTS contact_email;
@@ -0,0 +1,12 @@
+-- contact_email is the member's normalised email, the form referees are compared with.
+ALTER TABLE members ADD COLUMN IF NOT EXISTS contact_email text NOT NULL DEFAULT '';
+UPDATE members SET contact_email = lower(trim(email)) WHERE contact_email = '';
+UPDATE members SET contact_email =
+    CASE WHEN split_part(contact_email, '@', 2) IN ('gmail.com', 'googlemail.com')
+        THEN replace(split_part(split_part(contact_email, '@', 1), '+', 1), '.', '') || '@gmail.com'
+        ELSE split_part(split_part(contact_email, '@', 1), '+', 1) || '@' || split_part(contact_email, '@', 2)
+    END
+WHERE contact_email LIKE '_%@%' AND contact_email NOT LIKE '%@%@%' AND contact_email NOT LIKE '+%'
+    AND (contact_email LIKE '%+%@%' OR split_part(contact_email, '@', 2) IN ('gmail.com', 'googlemail.com'));
+
+CREATE INDEX IF NOT EXISTS members_program_id_contact_email ON members (program_id, contact_email);
@@ -0,0 +1,6 @@
+DELETE FROM rewards WHERE account = 'program';
+DROP INDEX IF EXISTS rewards_balance_of;
+DROP INDEX IF EXISTS rewards_credit_referral_beneficiary;
+CREATE UNIQUE INDEX IF NOT EXISTS rewards_credit_referral_beneficiary ON rewards (referral_id, beneficiary) WHERE entry_type = 'credit';
+ALTER TABLE rewards DROP COLUMN IF EXISTS balance_of;
+ALTER TABLE rewards DROP COLUMN IF EXISTS account;
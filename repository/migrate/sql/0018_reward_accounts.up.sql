@@ -0,0 +1,19 @@
+-- rewards post to two accounts: the beneficiary's, what a referrer or referee is owed, and
+-- the program's, what it owes. Every beneficiary entry is balanced by the opposite entry on
+-- its program's account pointing back at it, so a program's entries net to zero.
+ALTER TABLE rewards ADD COLUMN IF NOT EXISTS account text NOT NULL DEFAULT 'beneficiary' CHECK (account IN ('beneficiary', 'program'));
+ALTER TABLE rewards ALTER COLUMN account DROP DEFAULT;
+ALTER TABLE rewards ADD COLUMN IF NOT EXISTS balance_of text NOT NULL DEFAULT '';
+
+-- the credit a reversal balances on the program's account isn't a second credit of the referral.
+DROP INDEX IF EXISTS rewards_credit_referral_beneficiary;
+CREATE UNIQUE INDEX IF NOT EXISTS rewards_credit_referral_beneficiary ON rewards (referral_id, beneficiary) WHERE entry_type = 'credit' AND account = 'beneficiary';
+CREATE UNIQUE INDEX IF NOT EXISTS rewards_balance_of ON rewards (balance_of) WHERE balance_of <> '';
+
+-- balance the entries written before programs had an account.
+INSERT INTO rewards (id, account, beneficiary, member_id, program_id, referral_id, entry_type, amount, currency, reversal_of, balance_of, reason, created_by, created_at)
+SELECT b.id || '-balance', 'program', b.beneficiary, '', b.program_id, b.referral_id,
+    CASE WHEN b.entry_type = 'credit' THEN 'debit' ELSE 'credit' END,
+    b.amount, b.currency, '', b.id, b.reason, b.created_by, b.created_at
+FROM rewards b
+WHERE b.account = 'beneficiary' AND NOT EXISTS (SELECT 1 FROM rewards p WHERE p.balance_of = b.id);
@@ -1,8 +1,79 @@
 package repository
 
-import "go.uber.org/fx"
+import (
+	"context"
+	"fmt"
+
+	"referral-service/repository/migrate"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
 
 var Module = fx.Module(
 	"repository",
 	fx.Provide(New),
 )
+
+type Params struct {
+	fx.In
+
+	Log *zap.Logger
+	Cfg config.Provider
+}
+
+// New provides the repository named by repository.driver, postgres unless configured otherwise.
+// With postgres.auto_migrate set pending schema migrations are applied first.
+func New(p Params) (Repository, error) {
+	switch driver := p.Cfg.Get("repository.driver").String(); driver {
+	case "memory":
+		p.Log.Info("using in-memory repository, data is lost on restart")
+		return NewMemory(p.Log), nil
+	case "", "postgres":
+		db, err := OpenPostgres(p.Log, p.Cfg)
+		if err != nil {
+			return nil, err
+		}
+		var autoMigrate bool
+		if err := p.Cfg.Get("postgres.auto_migrate").Populate(&autoMigrate); err != nil {
+			return nil, fmt.Errorf("postgres.auto_migrate config populate %w", err)
+		}
+		if autoMigrate {
+			migrator, err := migrate.New(p.Log, db)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := migrator.Up(context.Background()); err != nil {
+				return nil, fmt.Errorf("auto migrate %w", err)
+			}
+		}
+		retry := DefaultRetryPolicy
+		if err := p.Cfg.Get("postgres.tx_retry").Populate(&retry); err != nil {
+			return nil, fmt.Errorf("postgres.tx_retry config populate %w", err)
+		}
+		return NewPostgres(p.Log, db, retry), nil
+	default:
+		return nil, fmt.Errorf("unknown repository driver %q", driver)
+	}
+}
+
+// OpenPostgres opens the database configured under postgres.
+func OpenPostgres(log *zap.Logger, cfg config.Provider) (*sqlx.DB, error) {
+	user := cfg.Get("postgres.user").String()
+	dbName := cfg.Get("postgres.db_name").String()
+	host := cfg.Get("postgres.host").String()
+
+	log.Info(postgresConnStr(user, dbName, "[redacted]", host))
+	db, err := sqlx.Open("postgres", postgresConnStr(user, dbName, cfg.Get("postgres.password").String(), host))
+	if err != nil {
+		return nil, fmt.Errorf("sql Open %w", err)
+	}
+	return db, nil
+}
+
+func postgresConnStr(user string, dbName string, password string, host string) string {
+	return fmt.Sprintf("user=%s dbname=%s password=%s host=%s port=5432 sslmode=disable",
+		user, dbName, password, host)
+}
@@ -3,8 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"math/rand"
 	"strings"
 	"time"
 
@@ -13,8 +13,6 @@ import (
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
-	"go.uber.org/config"
-	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
 
@@ -22,89 +20,73 @@ type pgRepository struct {
 	log    *zap.Logger
 	db     *sqlx.DB
 	txOpts *sql.TxOptions
+	retry  RetryPolicy
+	stats  txStats
 }
 
-type Params struct {
-	fx.In
-
-	Log *zap.Logger
-	Cfg config.Provider
-}
-
-func New(p Params) (Repository, error) {
-
-	cfg := p.Cfg
-
-	connStr := fmt.Sprintf(
-		"user=%s dbname=%s password=%s host=%s port=5432 sslmode=disable",
-		cfg.Get("postgres.user").String(),
-		cfg.Get("postgres.db_name").String(),
-		cfg.Get("postgres.password").String(),
-		cfg.Get("postgres.host").String(),
-	)
-
-	p.Log.Info(connStr)
-	db, err := sqlx.Open("postgres", connStr)
-	if err != nil {
-		return nil, fmt.Errorf("sql Open %w", err)
-	}
-
+// NewPostgres returns a postgres backed repository using db, retrying aborted transactions per retry.
+func NewPostgres(log *zap.Logger, db *sqlx.DB, retry RetryPolicy) Repository {
 	return &pgRepository{
-		log:    p.Log,
+		log:    log,
 		db:     db,
 		txOpts: &sql.TxOptions{Isolation: sql.LevelSerializable},
-	}, nil
+		retry:  retry,
+	}
 }
 
 // program
 
-func (r *pgRepository) AddProgram(ctx context.Context, name string, title string, active bool) (string, error) {
-	// Open a new transaction to update table with data.
-	tx, err := r.db.BeginTxx(ctx, r.txOpts)
-	if err != nil {
-		return "", fmt.Errorf("schema transaction begin %w", err)
-	}
-	defer tx.Rollback()
-
-	programQuery, err := tx.PrepareNamedContext(
-		ctx,
-		"INSERT INTO programs (id, name, title, is_active, created_at, updated_at) VALUES (:id, :name, :title, :is_active, :created_at, :updated_at)",
-	)
-	if err != nil {
-		return "", fmt.Errorf("PrepareNamedContext %w", err)
-	}
-
+func (r *pgRepository) AddProgram(ctx context.Context,
+	name string,
+	title string,
+	active bool,
+	landingUrl string,
+	codePrefix string,
+	policy domain.RewardPolicy,
+	rule domain.QualificationRule,
+	attributionWindowDays int64) (string, error) {
 	programId := uuid.New().String()
 
-	_, err = programQuery.ExecContext(
-		ctx,
-		&domain.Program{
-			ID:        programId,
-			Name:      name,
-			Title:     title,
-			IsActive:  active,
-			CreatedAt: time.Now().UTC().Unix(),
-			UpdatedAt: time.Now().UTC().Unix(),
-		},
-	)
-	if err != nil {
-		return "", fmt.Errorf("program insert exec %w", err)
-	}
-	err = tx.Commit()
+	err := r.withTx(ctx, "AddProgram", func(tx *sqlx.Tx) error {
+		_, err := tx.NamedExecContext(
+			ctx,
+			"INSERT INTO programs (id, name, title, is_active, landing_url, code_prefix, attribution_window_days, created_at, updated_at, reward_type, reward_currency, referrer_reward, referee_reward, max_rewards_per_member, reward_cap, qualify_event, qualify_min_order, qualify_within_days) VALUES (:id, :name, :title, :is_active, :landing_url, :code_prefix, :attribution_window_days, :created_at, :updated_at, :reward_type, :reward_currency, :referrer_reward, :referee_reward, :max_rewards_per_member, :reward_cap, :qualify_event, :qualify_min_order, :qualify_within_days)",
+			&domain.Program{
+				ID:                    programId,
+				Name:                  name,
+				Title:                 title,
+				IsActive:              active,
+				LandingUrl:            landingUrl,
+				CodePrefix:            codePrefix,
+				AttributionWindowDays: attributionWindowDays,
+				CreatedAt:             time.Now().UTC().Unix(),
+				UpdatedAt:             time.Now().UTC().Unix(),
+				RewardPolicy:          policy,
+				QualificationRule:     rule,
+			},
+		)
+		if err != nil {
+			return writeError(err, "program insert exec")
+		}
+		return nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("commit transaction %w", err)
+		return "", err
 	}
 
 	return programId, nil
 }
 
-func (r *pgRepository) UpdateProgram(ctx context.Context, id string, name *string, title *string, active *bool) error {
-	tx, err := r.db.BeginTxx(ctx, r.txOpts)
-	if err != nil {
-		return fmt.Errorf("schema transaction begin %w", err)
-	}
-	defer tx.Rollback()
-
+func (r *pgRepository) UpdateProgram(ctx context.Context,
+	id string,
+	name *string,
+	title *string,
+	active *bool,
+	landingUrl *string,
+	codePrefix *string,
+	policy *domain.RewardPolicy,
+	rule *domain.QualificationRule,
+	attributionWindowDays *int64) error {
 	query := "UPDATE programs SET "
 	params := map[string]interface{}{"id": id}
 	var sets []string
@@ -121,6 +103,46 @@ func (r *pgRepository) UpdateProgram(ctx context.Context, id string, name *strin
 		sets = append(sets, "is_active=:is_active")
 		params["is_active"] = *active
 	}
+	if landingUrl != nil {
+		sets = append(sets, "landing_url=:landing_url")
+		params["landing_url"] = *landingUrl
+	}
+	if codePrefix != nil {
+		sets = append(sets, "code_prefix=:code_prefix")
+		params["code_prefix"] = *codePrefix
+	}
+	if attributionWindowDays != nil {
+		sets = append(sets, "attribution_window_days=:attribution_window_days")
+		params["attribution_window_days"] = *attributionWindowDays
+	}
+	if policy != nil {
+		// the policy is replaced as a whole.
+		sets = append(sets,
+			"reward_type=:reward_type",
+			"reward_currency=:reward_currency",
+			"referrer_reward=:referrer_reward",
+			"referee_reward=:referee_reward",
+			"max_rewards_per_member=:max_rewards_per_member",
+			"reward_cap=:reward_cap",
+		)
+		params["reward_type"] = policy.RewardType
+		params["reward_currency"] = policy.RewardCurrency
+		params["referrer_reward"] = policy.ReferrerReward
+		params["referee_reward"] = policy.RefereeReward
+		params["max_rewards_per_member"] = policy.MaxRewardsPerMember
+		params["reward_cap"] = policy.RewardCap
+	}
+	if rule != nil {
+		// like the policy, the rule is replaced as a whole.
+		sets = append(sets,
+			"qualify_event=:qualify_event",
+			"qualify_min_order=:qualify_min_order",
+			"qualify_within_days=:qualify_within_days",
+		)
+		params["qualify_event"] = rule.QualifyEvent
+		params["qualify_min_order"] = rule.QualifyMinOrder
+		params["qualify_within_days"] = rule.QualifyWithinDays
+	}
 
 	sets = append(sets, "updated_at=:updated_at")
 	params["updated_at"] = time.Now().UTC().Unix()
@@ -128,31 +150,27 @@ func (r *pgRepository) UpdateProgram(ctx context.Context, id string, name *strin
 	query += strings.Join(sets, ", ")
 	query += " WHERE id=:id"
 
-	_, err = tx.NamedExec(query, params)
-
-	if err != nil {
-		return fmt.Errorf("program update exec %w", err)
-	}
-	err = tx.Commit()
-	if err != nil {
-		return fmt.Errorf("commit transaction %w", err)
-	}
-	return nil
+	return r.withTx(ctx, "UpdateProgram", func(tx *sqlx.Tx) error {
+		_, err := tx.NamedExecContext(ctx, query, params)
+		if err != nil {
+			return writeError(err, "program update exec")
+		}
+		return nil
+	})
 }
 
-func (r *pgRepository) GetPrograms(ctx context.Context, page int, size int) ([]domain.Program, error) {
+func (r *pgRepository) GetPrograms(ctx context.Context, page domain.Page) ([]domain.Program, error) {
 	programs := []domain.Program{}
-	offset := (page - 1) * size
-	query := "SELECT * FROM programs order by created_at LIMIT $1 OFFSET $2"
+	query, args := pagedQuery("SELECT * FROM programs", nil, nil, page, "created_at", "id", false)
 
-	err := r.db.Select(&programs, query, size, offset)
+	err := r.db.Select(&programs, query, args...)
 	return programs, err
 }
 
 func (r *pgRepository) GetProgram(ctx context.Context, programId string) (domain.Program, error) {
 	program := domain.Program{}
 	err := r.db.Get(&program, "SELECT * FROM programs WHERE id=$1", programId)
-	return program, err
+	return program, notFound(err, "program", programId)
 }
 
 // member
@@ -162,131 +180,830 @@ func (r *pgRepository) AddMember(ctx context.Context,
 	last_name *string,
 	email string,
 	program_id string,
-	referral_code *string,
+	referral_code string,
 	is_active *bool) (string, error) {
-	tx, err := r.db.BeginTxx(ctx, r.txOpts)
-	if err != nil {
-		return "", fmt.Errorf("schema transaction begin %w", err)
-	}
-	defer tx.Rollback()
+	memberId := uuid.New().String()
 
-	programQuery, err := tx.PrepareNamedContext(
-		ctx,
-		"INSERT INTO members (id, first_name, last_name, email, program_id, referral_code, is_active, created_at, updated_at) VALUES (:id, :first_name, :last_name, :email, :program_id, :referral_code, :is_active, :created_at, :updated_at)",
-	)
+	err := r.withTx(ctx, "AddMember", func(tx *sqlx.Tx) error {
+		personId, err := r.personId(ctx, tx, email)
+		if err != nil {
+			return err
+		}
+		_, err = tx.NamedExecContext(
+			ctx,
+			"INSERT INTO members (id, person_id, first_name, last_name, email, contact_email, program_id, referral_code, is_active, created_at, updated_at) VALUES (:id, :person_id, :first_name, :last_name, :email, :contact_email, :program_id, :referral_code, :is_active, :created_at, :updated_at)",
+			&domain.Member{
+				ID:           memberId,
+				PersonId:     personId,
+				FirstName:    first_name,
+				LastName:     valueOrEmpty(last_name),
+				Email:        email,
+				ContactEmail: domain.NormalizeEmail(email),
+				ProgramId:    program_id,
+				ReferralCode: referral_code,
+				IsActive:     is_active == nil || *is_active,
+				CreatedAt:    time.Now().UTC().Unix(),
+				UpdatedAt:    time.Now().UTC().Unix(),
+			},
+		)
+		if err != nil {
+			return writeError(err, "member insert exec")
+		}
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO referral_codes (code, member_id, created_at) VALUES ($1, $2, $3)",
+			referral_code, memberId, time.Now().UTC().Unix())
+		if err != nil {
+			return writeError(err, "referral code insert exec")
+		}
+		return nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("PrepareNamedContext %w", err)
+		return "", err
 	}
 
-	memberId := uuid.New().String()
-	var code = randomLowercaseString(5)
-	if referral_code != nil {
-		code = *referral_code
+	return memberId, nil
+}
+
+func (r *pgRepository) UpdateMember(ctx context.Context,
+	id string,
+	first_name *string,
+	last_name *string,
+	email *string,
+	program_id *string,
+	is_active *bool) error {
+	query := "UPDATE members SET "
+	params := map[string]interface{}{"id": id}
+	var sets []string
+
+	if first_name != nil {
+		sets = append(sets, "first_name=:first_name")
+		params["first_name"] = *first_name
 	}
-	_, err = programQuery.ExecContext(
-		ctx,
-		&domain.Member{
-			ID:           memberId,
-			FirstName:    first_name,
-			LastName:     *last_name,
-			Email:        email,
-			ProgramId:    program_id,
-			ReferralCode: code,
-			IsActive:     *is_active,
-			CreatedAt:    time.Now().UTC().Unix(),
-			UpdatedAt:    time.Now().UTC().Unix(),
-		},
-	)
-	if err != nil {
-		return "", fmt.Errorf("member insert exec %w", err)
+	if last_name != nil {
+		sets = append(sets, "last_name=:last_name")
+		params["last_name"] = *last_name
 	}
-	err = tx.Commit()
-	if err != nil {
-		return "", fmt.Errorf("commit transaction %w", err)
+	if email != nil {
+		// the enrolment moves to the person with the new email.
+		sets = append(sets, "email=:email", "contact_email=:contact_email", "person_id=:person_id")
+		params["email"] = *email
+		params["contact_email"] = domain.NormalizeEmail(*email)
+	}
+	if is_active != nil {
+		sets = append(sets, "is_active=:is_active")
+		params["is_active"] = *is_active
 	}
 
-	return memberId, nil
+	sets = append(sets, "updated_at=:updated_at")
+	params["updated_at"] = time.Now().UTC().Unix()
+
+	query += strings.Join(sets, ", ")
+	query += " WHERE id=:id"
+
+	return r.withTx(ctx, "UpdateMember", func(tx *sqlx.Tx) error {
+		if program_id != nil {
+			var current string
+			err := tx.GetContext(ctx, &current, "SELECT program_id FROM members WHERE id=$1", id)
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("member program select %w", err)
+			}
+			if current != *program_id {
+				return domain.ErrMemberProgramChange
+			}
+		}
+		if email != nil {
+			personId, err := r.personId(ctx, tx, *email)
+			if err != nil {
+				return err
+			}
+			params["person_id"] = personId
+		}
+		_, err := tx.NamedExecContext(ctx, query, params)
+		if err != nil {
+			return writeError(err, "member update exec")
+		}
+		return nil
+	})
 }
 
-func (r *pgRepository) GetMembers(ctx context.Context, page int, size int) ([]domain.Member, error) {
+// personId returns the id of the person with email, adding the person if there is none.
+func (r *pgRepository) personId(ctx context.Context, tx *sqlx.Tx, email string) (string, error) {
+	_, err := tx.ExecContext(ctx,
+		"INSERT INTO people (id, email, created_at) VALUES ($1, $2, $3) ON CONFLICT (lower(email)) DO NOTHING",
+		uuid.New().String(), email, time.Now().UTC().Unix())
+	if err != nil {
+		return "", writeError(err, "person insert exec")
+	}
+	var id string
+	if err := tx.GetContext(ctx, &id, "SELECT id FROM people WHERE lower(email)=lower($1)", email); err != nil {
+		return "", fmt.Errorf("person select %w", err)
+	}
+	return id, nil
+}
+
+func (r *pgRepository) GetMembers(ctx context.Context, filter domain.MemberFilter, page domain.Page) ([]domain.Member, error) {
 	members := []domain.Member{}
-	offset := (page - 1) * size
-	query := "SELECT * FROM members order by created_at LIMIT $1 OFFSET $2"
-	err := r.db.Select(&members, query, size, offset)
+	var conds []string
+	var args []interface{}
+	where := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if filter.ProgramId != nil {
+		where("program_id=$%d", *filter.ProgramId)
+	}
+	if filter.PersonId != nil {
+		where("person_id=$%d", *filter.PersonId)
+	}
+	if filter.Email != nil {
+		where("lower(email)=lower($%d)", *filter.Email)
+	}
+	if filter.ContactEmail != nil {
+		where("contact_email=$%d", *filter.ContactEmail)
+	}
+	query, args := pagedQuery("SELECT * FROM members", conds, args, page, "created_at", "id", false)
+	err := r.db.Select(&members, query, args...)
 	return members, err
 }
 
 func (r *pgRepository) GetMember(ctx context.Context, memberId string) (domain.Member, error) {
 	member := domain.Member{}
-	err := r.db.Get(&member, "SELECT * FROM programs WHERE id=$1", memberId)
-	return member, err
+	err := r.db.Get(&member, "SELECT * FROM members WHERE id=$1", memberId)
+	return member, notFound(err, "member", memberId)
+}
+
+// GetMemberByEmail finds the enrolment of email in programId, or its only
+// enrolment when programId is nil.
+func (r *pgRepository) GetMemberByEmail(ctx context.Context, email string, programId *string) (domain.Member, error) {
+	members, err := r.GetMembers(ctx, domain.MemberFilter{Email: &email, ProgramId: programId}, domain.Page{Number: 1, Size: 2})
+	if err != nil {
+		return domain.Member{}, err
+	}
+	return onlyMember(members, email)
+}
+
+func (r *pgRepository) GetMemberByReferralCode(ctx context.Context, referralCode string) (domain.Member, error) {
+	member := domain.Member{}
+	err := r.db.Get(&member, "SELECT m.* FROM members m join referral_codes c on c.member_id = m.id WHERE c.code=$1", referralCode)
+	return member, notFound(err, "referral code", referralCode)
+}
+
+// ChangeReferralCode makes code the member's current referral code. The previous
+// code is retired but keeps resolving to the member; a member may take back one
+// of their own codes, which then neither expires nor stays revoked.
+func (r *pgRepository) ChangeReferralCode(ctx context.Context, memberId string, code string) error {
+	now := time.Now().UTC().Unix()
+	return r.withTx(ctx, "ChangeReferralCode", func(tx *sqlx.Tx) error {
+		member := domain.Member{}
+		err := tx.GetContext(ctx, &member, "SELECT * FROM members WHERE id=$1 FOR UPDATE", memberId)
+		if err != nil {
+			return notFound(err, "member", memberId)
+		}
+		if member.ReferralCode == code {
+			return nil
+		}
+
+		var owner string
+		err = tx.GetContext(ctx, &owner, "SELECT member_id FROM referral_codes WHERE code=$1", code)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			_, err = tx.ExecContext(ctx,
+				"INSERT INTO referral_codes (code, member_id, created_at) VALUES ($1, $2, $3)",
+				code, memberId, now)
+			if err != nil {
+				return writeError(err, "referral code insert exec")
+			}
+		case err != nil:
+			return fmt.Errorf("referral code select %w", err)
+		case owner != memberId:
+			return domain.ErrReferralCodeTaken
+		default:
+			_, err = tx.ExecContext(ctx, "UPDATE referral_codes SET retired_at=0, revoked_at=0, expires_at=0 WHERE code=$1", code)
+			if err != nil {
+				return writeError(err, "referral code update exec")
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE referral_codes SET retired_at=$1 WHERE code=$2", now, member.ReferralCode)
+		if err != nil {
+			return writeError(err, "referral code retire exec")
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE members SET referral_code=$1, updated_at=$2 WHERE id=$3", code, now, memberId)
+		if err != nil {
+			return writeError(err, "member referral code update exec")
+		}
+		return nil
+	})
+}
+
+// referral code
+
+func (r *pgRepository) AddReferralCode(ctx context.Context, code domain.ReferralCode) error {
+	code.CreatedAt = time.Now().UTC().Unix()
+	code.RetiredAt = 0
+	code.RevokedAt = 0
+
+	return r.withTx(ctx, "AddReferralCode", func(tx *sqlx.Tx) error {
+		_, err := tx.NamedExecContext(
+			ctx,
+			"INSERT INTO referral_codes (code, member_id, channel, created_at, expires_at, retired_at, revoked_at) VALUES (:code, :member_id, :channel, :created_at, :expires_at, :retired_at, :revoked_at)",
+			&code,
+		)
+		if err != nil {
+			return writeError(err, "referral code insert exec")
+		}
+		return nil
+	})
+}
+
+func (r *pgRepository) GetReferralCode(ctx context.Context, code string) (domain.ReferralCode, error) {
+	referralCode := domain.ReferralCode{}
+	err := r.db.Get(&referralCode, "SELECT * FROM referral_codes WHERE code=$1", code)
+	return referralCode, notFound(err, "referral code", code)
+}
+
+// GetReferralCodes lists every code the member has held, oldest first.
+func (r *pgRepository) GetReferralCodes(ctx context.Context, memberId string) ([]domain.ReferralCode, error) {
+	codes := []domain.ReferralCode{}
+	err := r.db.Select(&codes, "SELECT * FROM referral_codes WHERE member_id=$1 ORDER BY created_at, code", memberId)
+	return codes, err
+}
+
+// RevokeReferralCode stops code from resolving. Revoking a revoked code keeps the first revocation time.
+func (r *pgRepository) RevokeReferralCode(ctx context.Context, code string) error {
+	now := time.Now().UTC().Unix()
+	return r.withTx(ctx, "RevokeReferralCode", func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, "UPDATE referral_codes SET revoked_at=$1 WHERE code=$2 AND revoked_at=0", now, code)
+		if err != nil {
+			return writeError(err, "referral code revoke exec")
+		}
+		return nil
+	})
 }
 
 // referral
 
-func (r *pgRepository) AddReferral(ctx context.Context,
-	first_name *string,
-	last_name *string,
-	email *string,
-	phone *string,
-	referral_code string) (string, error) {
-	tx, err := r.db.BeginTxx(ctx, r.txOpts)
+// AddReferral stores a new pending referral made with referral.ReferralCode. Concurrent
+// referrals under the same limits conflict in the serializable transaction, the retry
+// then counts the referral stored first.
+func (r *pgRepository) AddReferral(ctx context.Context, referral domain.Referral, limits ...domain.ReferralLimit) (string, error) {
+	referral.ID = uuid.New().String()
+	referral.Status = domain.ReferralStatusPending
+	referral.CreatedAt = time.Now().UTC().Unix()
+	referral.UpdatedAt = referral.CreatedAt
+
+	err := r.withTx(ctx, "AddReferral", func(tx *sqlx.Tx) error {
+		limited := referral
+		err := applyLimits(&limited, limits, func(filter domain.ReferralFilter) (int64, error) {
+			return countReferrals(ctx, tx, filter)
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tx.NamedExecContext(
+			ctx,
+			"INSERT INTO referrals (id, first_name, last_name, email, phone, contact_email, contact_phone, ip_hash, referral_code, status, flagged, flag_reason, risk_score, created_at, updated_at) VALUES (:id, :first_name, :last_name, :email, :phone, :contact_email, :contact_phone, :ip_hash, :referral_code, :status, :flagged, :flag_reason, :risk_score, :created_at, :updated_at)",
+			&limited,
+		)
+		if err != nil {
+			return writeError(err, "referral insert exec")
+		}
+		return nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("schema transaction begin %w", err)
+		return "", err
 	}
-	defer tx.Rollback()
 
-	referralQuery, err := tx.PrepareNamedContext(
-		ctx,
-		"INSERT INTO referrals (id, first_name, last_name, email, phone, referral_code, status, created_at, updated_at) VALUES (:id, :first_name, :last_name, :email, :phone, :referral_code, :status, :created_at, :updated_at)",
+	return referral.ID, nil
+}
+
+// CountReferralsByContact counts the referrals in the program whose contact email
+// or contact phone matches; empty values match nothing.
+func (r *pgRepository) CountReferralsByContact(ctx context.Context, programId string, contactEmail string, contactPhone string) (int64, error) {
+	var count int64
+	err := r.db.GetContext(ctx, &count,
+		"SELECT COUNT(*)"+referralJoins+" WHERE m.program_id=$1 AND (($2 <> '' AND r.contact_email=$2) OR ($3 <> '' AND r.contact_phone=$3))",
+		programId, contactEmail, contactPhone)
+	return count, err
+}
+
+func (r *pgRepository) GetReferrals(ctx context.Context,
+	filter domain.ReferralFilter,
+	page domain.Page) ([]domain.Referral, error) {
+	referrals := []domain.Referral{}
+	conds, args := referralConds(filter)
+
+	sortCol := "r.created_at"
+	switch filter.SortBy {
+	case domain.ReferralSortUpdatedAt:
+		sortCol = "r.updated_at"
+	case domain.ReferralSortRiskScore:
+		sortCol = "r.risk_score"
+	}
+	query, args := pagedQuery(
+		"SELECT r.*,m.program_id as program_id, m.id as member_id, c.channel as channel"+referralJoins,
+		conds, args, page, sortCol, "r.id", filter.Descending,
 	)
-	if err != nil {
-		return "", fmt.Errorf("PrepareNamedContext %w", err)
+	err := r.db.Select(&referrals, query, args...)
+	return referrals, err
+}
+
+// CountReferrals counts the referrals matching filter, its sort order is ignored.
+func (r *pgRepository) CountReferrals(ctx context.Context, filter domain.ReferralFilter) (int64, error) {
+	return countReferrals(ctx, r.db, filter)
+}
+
+func countReferrals(ctx context.Context, q sqlx.QueryerContext, filter domain.ReferralFilter) (int64, error) {
+	conds, args := referralConds(filter)
+	query := "SELECT COUNT(*)" + referralJoins
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	var count int64
+	err := sqlx.GetContext(ctx, q, &count, query, args...)
+	return count, err
+}
+
+// referralJoins selects referrals with the code they were made with and its member.
+const referralJoins = " FROM referrals r join referral_codes c on r.referral_code = c.code join members m on c.member_id = m.id"
+
+// referralConds returns the conditions of filter on referralJoins and their args.
+func referralConds(filter domain.ReferralFilter) ([]string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	where := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if filter.ProgramId != nil {
+		where("m.program_id=$%d", *filter.ProgramId)
+	}
+	if filter.MemberId != nil {
+		where("m.id=$%d", *filter.MemberId)
+	}
+	if filter.ReferralCode != nil {
+		where("r.referral_code=$%d", *filter.ReferralCode)
+	}
+	if filter.Channel != nil {
+		where("c.channel=$%d", *filter.Channel)
 	}
+	if filter.Status != nil {
+		where("r.status=$%d", *filter.Status)
+	}
+	if filter.Flagged != nil {
+		where("r.flagged=$%d", *filter.Flagged)
+	}
+	if filter.Email != nil {
+		where("lower(r.email)=lower($%d)", *filter.Email)
+	}
+	if filter.ContactEmail != nil {
+		where("r.contact_email=$%d", *filter.ContactEmail)
+	}
+	if filter.ContactPhone != nil {
+		where("r.contact_phone=$%d", *filter.ContactPhone)
+	}
+	if filter.IpHash != nil {
+		where("r.ip_hash=$%d", *filter.IpHash)
+	}
+	if filter.ClaimedBy != nil {
+		where("r.claimed_by=$%d", *filter.ClaimedBy)
+	}
+	if filter.CreatedAfter != nil {
+		where("r.created_at>=$%d", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		where("r.created_at<$%d", *filter.CreatedBefore)
+	}
+	return conds, args
+}
+
+func (r *pgRepository) GetReferral(ctx context.Context, referralId string) (domain.Referral, error) {
+	referral := domain.Referral{}
+	query := "SELECT r.*,m.program_id as program_id, m.id as member_id, c.channel as channel" + referralJoins + " WHERE r.id=$1"
+	err := r.db.Get(&referral, query, referralId)
+	return referral, notFound(err, "referral", referralId)
+}
+
+// UpdateReferralStatus moves a referral from change.FromStatus to change.ToStatus and records the change.
+// The update only applies if the referral is still in FromStatus, so concurrent changes can't skip a state.
+// Reward ledger entries produced by the change are written in the same transaction.
+func (r *pgRepository) UpdateReferralStatus(ctx context.Context,
+	change domain.ReferralStatusChange,
+	rewards []domain.Reward,
+	limits ...domain.RewardLimit) error {
+	return r.UpdateReferralStatuses(ctx, []domain.ReferralStatusChange{change}, rewards, limits...)
+}
 
-	referralId := uuid.New().String()
+// UpdateReferralStatuses counts the rewards under limits in the serializable transaction
+// that adds the new ones, concurrent approvals in a program conflict and the retry counts
+// the rewards added first.
+func (r *pgRepository) UpdateReferralStatuses(ctx context.Context,
+	changes []domain.ReferralStatusChange,
+	rewards []domain.Reward,
+	limits ...domain.RewardLimit) error {
+	var skipped []domain.Reward
+	err := r.withTx(ctx, "UpdateReferralStatuses", func(tx *sqlx.Tx) error {
+		now := time.Now().UTC().Unix()
+		for _, change := range changes {
+			if err := updateReferralStatus(ctx, tx, change, now); err != nil {
+				return err
+			}
+		}
+
+		var kept []domain.Reward
+		var err error
+		kept, skipped, err = limitRewards(rewards, limits, func(memberId string, programId string) (int64, error) {
+			return countMemberRewards(ctx, tx, memberId, programId)
+		}, func(programId string, currency string) (int64, error) {
+			return sumProgramRewards(ctx, tx, programId, currency)
+		})
+		if err != nil {
+			return err
+		}
+		for _, reward := range kept {
+			if _, err := insertReward(ctx, tx, reward, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	logSkippedRewards(r.log, skipped)
+	return nil
+}
 
-	_, err = referralQuery.ExecContext(
+// updateReferralStatus moves a referral out of change.FromStatus and records the change,
+// releasing the referral's claim. Changes with StaleClaimsBefore set only apply while no
+// other reviewer holds a fresh claim.
+func updateReferralStatus(ctx context.Context, tx *sqlx.Tx, change domain.ReferralStatusChange, now int64) error {
+	result, err := tx.NamedExecContext(
 		ctx,
-		&domain.Referral{
-			ID:           referralId,
-			FirstName:    *first_name,
-			LastName:     *last_name,
-			Email:        *email,
-			Phone:        *phone,
-			ReferralCode: referral_code,
-			Status:       "pending",
-			CreatedAt:    time.Now().UTC().Unix(),
-			UpdatedAt:    time.Now().UTC().Unix(),
+		"UPDATE referrals SET status=:to_status, status_changed_by=:changed_by, status_reason=:reason, claimed_by='', claimed_at=0, updated_at=:updated_at WHERE id=:id AND status=:from_status AND (:stale_before = 0 OR claimed_by = '' OR claimed_by = :changed_by OR claimed_at < :stale_before)",
+		map[string]interface{}{
+			"id":           change.ReferralId,
+			"from_status":  change.FromStatus,
+			"to_status":    change.ToStatus,
+			"changed_by":   change.ChangedBy,
+			"reason":       change.Reason,
+			"updated_at":   now,
+			"stale_before": change.StaleClaimsBefore,
 		},
 	)
 	if err != nil {
-		return "", fmt.Errorf("referral insert exec %w", err)
+		return writeError(err, "referral status update exec")
 	}
-	err = tx.Commit()
+	rows, err := result.RowsAffected()
 	if err != nil {
-		return "", fmt.Errorf("commit transaction %w", err)
+		return fmt.Errorf("referral status rows affected %w", err)
+	}
+	if rows == 0 {
+		// the referral is still in FromStatus when another reviewer's claim held the change back.
+		var status string
+		err := tx.GetContext(ctx, &status, "SELECT status FROM referrals WHERE id=$1", change.ReferralId)
+		if err == nil && status == change.FromStatus {
+			return domain.ErrReferralClaimed
+		}
+		return fmt.Errorf("referral %s is no longer %s %w", change.ReferralId, change.FromStatus, domain.ErrInvalidStatusTransition)
 	}
 
-	return referralId, nil
+	change.ID = uuid.New().String()
+	change.CreatedAt = now
+	_, err = tx.NamedExecContext(
+		ctx,
+		"INSERT INTO referral_status_changes (id, referral_id, from_status, to_status, changed_by, reason, created_at) VALUES (:id, :referral_id, :from_status, :to_status, :changed_by, :reason, :created_at)",
+		&change,
+	)
+	if err != nil {
+		return writeError(err, "referral status change insert exec")
+	}
+	return nil
 }
 
-func (r *pgRepository) GetReferrals(ctx context.Context, page int, size int) ([]domain.Referral, error) {
-	referrals := []domain.Referral{}
-	offset := (page - 1) * size
-	query := "SELECT r.*,m.program_id as program_id, m.id as member_id FROM referrals r join members m on r.referral_code = m.referral_code order by r.created_at LIMIT $1 OFFSET $2"
-	err := r.db.Select(&referrals, query, size, offset)
-	return referrals, err
+func (r *pgRepository) ClaimReferral(ctx context.Context, referralId string, reviewer string, staleBefore int64) error {
+	return r.withTx(ctx, "ClaimReferral", func(tx *sqlx.Tx) error {
+		referral, err := lockReferral(ctx, tx, referralId)
+		if err != nil {
+			return err
+		}
+		if err := claimable(referral, reviewer, staleBefore); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE referrals SET claimed_by=$1, claimed_at=$2 WHERE id=$3",
+			reviewer, time.Now().UTC().Unix(), referralId)
+		if err != nil {
+			return writeError(err, "referral claim exec")
+		}
+		return nil
+	})
+}
+
+// ReleaseReferral gives up reviewer's claim on a referral, releasing an unclaimed one does nothing.
+func (r *pgRepository) ReleaseReferral(ctx context.Context, referralId string, reviewer string) error {
+	return r.withTx(ctx, "ReleaseReferral", func(tx *sqlx.Tx) error {
+		referral, err := lockReferral(ctx, tx, referralId)
+		if err != nil {
+			return err
+		}
+		if referral.ClaimedBy != "" && referral.ClaimedBy != reviewer {
+			return domain.ErrReferralClaimed
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE referrals SET claimed_by='', claimed_at=0 WHERE id=$1", referralId)
+		if err != nil {
+			return writeError(err, "referral release exec")
+		}
+		return nil
+	})
+}
+
+// lockReferral reads a referral's row, locking it for the rest of tx.
+func lockReferral(ctx context.Context, tx *sqlx.Tx, referralId string) (domain.Referral, error) {
+	referral := domain.Referral{}
+	err := tx.GetContext(ctx, &referral, "SELECT * FROM referrals WHERE id=$1 FOR UPDATE", referralId)
+	return referral, notFound(err, "referral", referralId)
+}
+
+func (r *pgRepository) SetReferralFlag(ctx context.Context, referralId string, flagged bool, reason string) error {
+	return r.withTx(ctx, "SetReferralFlag", func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, "UPDATE referrals SET flagged=$1, flag_reason=$2, updated_at=$3 WHERE id=$4",
+			flagged, reason, time.Now().UTC().Unix(), referralId)
+		if err != nil {
+			return writeError(err, "referral flag exec")
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("referral flag rows affected %w", err)
+		}
+		if rows == 0 {
+			return notFound(sql.ErrNoRows, "referral", referralId)
+		}
+		return nil
+	})
+}
+
+func (r *pgRepository) AddReferralNote(ctx context.Context, note domain.ReferralNote) (string, error) {
+	note.ID = uuid.New().String()
+	note.CreatedAt = time.Now().UTC().Unix()
+
+	err := r.withTx(ctx, "AddReferralNote", func(tx *sqlx.Tx) error {
+		_, err := tx.NamedExecContext(
+			ctx,
+			"INSERT INTO referral_notes (id, referral_id, author, note, created_at) VALUES (:id, :referral_id, :author, :note, :created_at)",
+			&note,
+		)
+		if err != nil {
+			return writeError(err, "referral note insert exec")
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return note.ID, nil
+}
+
+func (r *pgRepository) GetReferralNotes(ctx context.Context, referralId string) ([]domain.ReferralNote, error) {
+	notes := []domain.ReferralNote{}
+	err := r.db.SelectContext(ctx, &notes, "SELECT * FROM referral_notes WHERE referral_id=$1 ORDER BY created_at, id", referralId)
+	return notes, err
+}
+
+// conversion event
+
+func (r *pgRepository) AddConversionEvent(ctx context.Context, event domain.ConversionEvent, change *domain.ReferralStatusChange) (string, error) {
+	event.ID = uuid.New().String()
+	event.CreatedAt = time.Now().UTC().Unix()
+
+	err := r.withTx(ctx, "AddConversionEvent", func(tx *sqlx.Tx) error {
+		if change != nil {
+			if err := updateReferralStatus(ctx, tx, *change, event.CreatedAt); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, "UPDATE referrals SET order_amount=$1 WHERE id=$2", event.OrderAmount, change.ReferralId)
+			if err != nil {
+				return writeError(err, "referral order amount exec")
+			}
+		}
+		_, err := tx.NamedExecContext(
+			ctx,
+			"INSERT INTO conversion_events (id, external_id, event_type, contact_email, contact_phone, order_amount, referral_id, qualified, occurred_at, created_at) VALUES (:id, :external_id, :event_type, :contact_email, :contact_phone, :order_amount, NULLIF(:referral_id, ''), :qualified, :occurred_at, :created_at)",
+			&event,
+		)
+		if err != nil {
+			return writeError(err, "conversion event insert exec")
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return event.ID, nil
+}
+
+// GetConversionEvent returns the event the sender sent with externalId.
+func (r *pgRepository) GetConversionEvent(ctx context.Context, externalId string) (domain.ConversionEvent, error) {
+	event := domain.ConversionEvent{}
+	err := r.db.GetContext(ctx, &event,
+		"SELECT id, external_id, event_type, contact_email, contact_phone, order_amount, COALESCE(referral_id, '') AS referral_id, qualified, occurred_at, created_at FROM conversion_events WHERE external_id=$1 AND external_id <> ''",
+		externalId)
+	return event, notFound(err, "conversion event", externalId)
+}
+
+// ExpireReferrals locks the referrals it expires, skipping ones locked by others
+// so status changes in flight finish first.
+func (r *pgRepository) ExpireReferrals(ctx context.Context, now int64, limit int64, changedBy string, reason string) ([]string, error) {
+	var expired []string
+	err := r.withTx(ctx, "ExpireReferrals", func(tx *sqlx.Tx) error {
+		expired = expired[:0]
+		var ids []string
+		err := tx.SelectContext(ctx, &ids,
+			"SELECT r.id"+referralJoins+" join programs p on m.program_id = p.id WHERE r.status=$1 AND p.attribution_window_days > 0 AND r.created_at <= $2 - p.attribution_window_days*86400 ORDER BY r.created_at, r.id LIMIT $3 FOR UPDATE OF r SKIP LOCKED",
+			domain.ReferralStatusPending, now, limit)
+		if err != nil {
+			return fmt.Errorf("expired referrals select %w", err)
+		}
+		for _, id := range ids {
+			err := updateReferralStatus(ctx, tx, domain.ReferralStatusChange{
+				ReferralId: id,
+				FromStatus: domain.ReferralStatusPending,
+				ToStatus:   domain.ReferralStatusExpired,
+				ChangedBy:  changedBy,
+				Reason:     reason,
+			}, now)
+			if err != nil {
+				return err
+			}
+			expired = append(expired, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+// reward
+
+const insertRewardQuery = "INSERT INTO rewards (id, account, beneficiary, member_id, program_id, referral_id, entry_type, amount, currency, reversal_of, balance_of, reason, created_by, created_at) VALUES (:id, :account, :beneficiary, :member_id, :program_id, :referral_id, :entry_type, :amount, :currency, :reversal_of, :balance_of, :reason, :created_by, :created_at)"
+
+// insertReward posts reward to its beneficiary's account and balances it on its program's,
+// returning the id of the beneficiary's entry.
+func insertReward(ctx context.Context, tx *sqlx.Tx, reward domain.Reward, now int64) (string, error) {
+	entries := postings(reward, now)
+	for _, entry := range entries {
+		_, err := tx.NamedExecContext(ctx, insertRewardQuery, &entry)
+		if err != nil {
+			return "", writeError(err, "reward insert exec")
+		}
+	}
+	return entries[0].ID, nil
+}
+
+func (r *pgRepository) AddReward(ctx context.Context, reward domain.Reward) (string, error) {
+	var id string
+	err := r.withTx(ctx, "AddReward", func(tx *sqlx.Tx) error {
+		var err error
+		id, err = insertReward(ctx, tx, reward, time.Now().UTC().Unix())
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (r *pgRepository) GetReward(ctx context.Context, rewardId string) (domain.Reward, error) {
+	reward := domain.Reward{}
+	err := r.db.Get(&reward, "SELECT * FROM rewards WHERE id=$1", rewardId)
+	return reward, notFound(err, "reward", rewardId)
+}
+
+func (r *pgRepository) GetRewards(ctx context.Context, memberId string, page domain.Page) ([]domain.Reward, error) {
+	rewards := []domain.Reward{}
+	query, args := pagedQuery(
+		"SELECT * FROM rewards",
+		[]string{"member_id=$1"}, []interface{}{memberId},
+		page, "created_at", "id", false,
+	)
+	err := r.db.Select(&rewards, query, args...)
+	return rewards, err
+}
+
+// GetRewardBalances nets credits against debits per currency for a member.
+func (r *pgRepository) GetRewardBalances(ctx context.Context, memberId string) ([]domain.RewardBalance, error) {
+	balances := []domain.RewardBalance{}
+	query := "SELECT member_id, currency, SUM(CASE WHEN entry_type = 'credit' THEN amount ELSE -amount END) as amount FROM rewards WHERE member_id=$1 GROUP BY member_id, currency order by currency"
+	err := r.db.Select(&balances, query, memberId)
+	return balances, err
+}
+
+// CountMemberRewards counts the referrer credits a member has earned in a program, ignoring reversed ones.
+func (r *pgRepository) CountMemberRewards(ctx context.Context, memberId string, programId string) (int64, error) {
+	return countMemberRewards(ctx, r.db, memberId, programId)
+}
+
+func countMemberRewards(ctx context.Context, q sqlx.QueryerContext, memberId string, programId string) (int64, error) {
+	var count int64
+	query := "SELECT COUNT(*) FROM rewards c WHERE c.entry_type = 'credit' AND c.beneficiary = 'referrer' AND c.member_id=$1 AND c.program_id=$2 AND NOT EXISTS (SELECT 1 FROM rewards d WHERE d.reversal_of = c.id)"
+	err := sqlx.GetContext(ctx, q, &count, query, memberId, programId)
+	return count, err
+}
+
+// SumProgramRewards nets every credit and debit a program has issued in currency.
+func (r *pgRepository) SumProgramRewards(ctx context.Context, programId string, currency string) (int64, error) {
+	return sumProgramRewards(ctx, r.db, programId, currency)
+}
+
+func sumProgramRewards(ctx context.Context, q sqlx.QueryerContext, programId string, currency string) (int64, error) {
+	var total int64
+	query := "SELECT COALESCE(SUM(CASE WHEN entry_type = 'credit' THEN amount ELSE -amount END), 0) FROM rewards WHERE account = 'beneficiary' AND program_id=$1 AND currency=$2"
+	err := sqlx.GetContext(ctx, q, &total, query, programId, currency)
+	return total, err
+}
+
+// click
+
+func (r *pgRepository) AddClick(ctx context.Context, click domain.Click) (string, error) {
+	click.ID = uuid.New().String()
+	click.CreatedAt = time.Now().UTC().Unix()
+
+	err := r.withTx(ctx, "AddClick", func(tx *sqlx.Tx) error {
+		_, err := tx.NamedExecContext(
+			ctx,
+			"INSERT INTO referral_clicks (id, referral_code, member_id, program_id, user_agent, referer, ip_hash, created_at) VALUES (:id, :referral_code, :member_id, :program_id, :user_agent, :referer, :ip_hash, :created_at)",
+			&click,
+		)
+		if err != nil {
+			return writeError(err, "click insert exec")
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return click.ID, nil
+}
+
+// GetClickStats counts clicks, optionally narrowed to a member and/or a program.
+func (r *pgRepository) GetClickStats(ctx context.Context, memberId *string, programId *string) (domain.ClickStats, error) {
+	stats := domain.ClickStats{}
+	query := "SELECT COUNT(*) as clicks, COUNT(DISTINCT ip_hash) as unique_visitors FROM referral_clicks"
+	var conds []string
+	var args []interface{}
+	if memberId != nil {
+		args = append(args, *memberId)
+		conds = append(conds, fmt.Sprintf("member_id=$%d", len(args)))
+	}
+	if programId != nil {
+		args = append(args, *programId)
+		conds = append(conds, fmt.Sprintf("program_id=$%d", len(args)))
+	}
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	err := r.db.Get(&stats, query, args...)
+	return stats, err
+}
+
+// pagedQuery appends the conditions, ordering and limits for page to a select statement.
+// Rows are ordered by (keyCol, idCol) so pages stay stable while rows are inserted;
+// conds use $n placeholders numbered from 1 matching args.
+func pagedQuery(base string, conds []string, args []interface{}, page domain.Page, keyCol string, idCol string, desc bool) (string, []interface{}) {
+	cmp, order := ">", ""
+	if desc {
+		cmp, order = "<", " desc"
+	}
+	if page.After != nil {
+		args = append(args, page.After.Key, page.After.ID)
+		conds = append(conds, fmt.Sprintf("(%s, %s) %s ($%d, $%d)", keyCol, idCol, cmp, len(args)-1, len(args)))
+	}
+
+	query := base
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += fmt.Sprintf(" order by %s%s, %s%s", keyCol, order, idCol, order)
+
+	args = append(args, page.Size)
+	query += fmt.Sprintf(" LIMIT $%d", len(args))
+	if page.After == nil {
+		args = append(args, (page.Number-1)*page.Size)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+	return query, args
 }
 
-// generates random string of specified length
-// helps to generate referral code
-func randomLowercaseString(length int) string {
-	const charset = "abcdefghijklmnopqrstuvwxyz"
-	seededRand := rand.New(rand.NewSource(time.Now().UnixNano()))
-	b := make([]byte, length)
-	for i := range b {
-		b[i] = charset[seededRand.Intn(len(charset))]
+// valueOrEmpty dereferences an optional string field.
+func valueOrEmpty(s *string) string {
+	if s == nil {
+		return ""
 	}
-	return string(b)
+	return *s
 }
@@ -0,0 +1,57 @@
+package repository_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"referral-service/repository"
+	"referral-service/repository/migrate"
+	"referral-service/repository/repotest"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// TestPostgresConformance runs the suite against the database in
+// REPOSITORY_TEST_POSTGRES_DSN, each test in a freshly migrated schema of its own, e.g.
+//
+//	docker run -d -p 5432:5432 -e POSTGRES_PASSWORD=postgres postgres:12
+//	REPOSITORY_TEST_POSTGRES_DSN="host=localhost user=postgres password=postgres sslmode=disable" go test ./repository/...
+func TestPostgresConformance(t *testing.T) {
+	dsn := os.Getenv("REPOSITORY_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("REPOSITORY_TEST_POSTGRES_DSN not set")
+	}
+
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		db, err := sqlx.Open("postgres", dsn)
+		if err != nil {
+			t.Fatalf("sql Open %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		schema := "repotest_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+		if _, err := db.Exec("CREATE SCHEMA " + schema); err != nil {
+			t.Fatalf("create schema %v", err)
+		}
+		t.Cleanup(func() { db.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+		schemaDB, err := sqlx.Open("postgres", dsn+" search_path="+schema)
+		if err != nil {
+			t.Fatalf("sql Open %v", err)
+		}
+		t.Cleanup(func() { schemaDB.Close() })
+		migrator, err := migrate.New(zap.NewNop(), schemaDB)
+		if err != nil {
+			t.Fatalf("migrate New %v", err)
+		}
+		if _, err := migrator.Up(context.Background()); err != nil {
+			t.Fatalf("migrate up %v", err)
+		}
+
+		return repository.NewPostgres(zap.NewNop(), schemaDB, repository.DefaultRetryPolicy)
+	})
+}
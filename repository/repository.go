@@ -10,13 +10,23 @@ type Repository interface {
 	AddProgram(ctx context.Context,
 		name string,
 		title string,
-		active bool) (string, error)
+		active bool,
+		landingUrl string,
+		codePrefix string,
+		policy domain.RewardPolicy,
+		rule domain.QualificationRule,
+		attributionWindowDays int64) (string, error)
 	UpdateProgram(ctx context.Context,
 		id string,
 		name *string,
 		title *string,
-		active *bool) error
-	GetPrograms(ctx context.Context, page int, size int) ([]domain.Program, error)
+		active *bool,
+		landingUrl *string,
+		codePrefix *string,
+		policy *domain.RewardPolicy,
+		rule *domain.QualificationRule,
+		attributionWindowDays *int64) error
+	GetPrograms(ctx context.Context, page domain.Page) ([]domain.Program, error)
 	GetProgram(ctx context.Context, programId string) (domain.Program, error)
 	// Member
 	AddMember(ctx context.Context,
@@ -24,15 +34,70 @@ type Repository interface {
 		last_name *string,
 		email string,
 		program_id string,
-		referral_code *string,
+		referral_code string,
 		is_active *bool) (string, error)
-	GetMembers(ctx context.Context, page int, size int) ([]domain.Member, error)
-	// Referral
-	AddReferral(ctx context.Context,
+	UpdateMember(ctx context.Context,
+		id string,
 		first_name *string,
 		last_name *string,
 		email *string,
-		phone *string,
-		referral_code string) (string, error)
-	GetReferrals(ctx context.Context, page int, size int) ([]domain.Referral, error)
+		program_id *string,
+		is_active *bool) error
+	GetMembers(ctx context.Context, filter domain.MemberFilter, page domain.Page) ([]domain.Member, error)
+	GetMember(ctx context.Context, memberId string) (domain.Member, error)
+	GetMemberByEmail(ctx context.Context, email string, programId *string) (domain.Member, error)
+	GetMemberByReferralCode(ctx context.Context, referralCode string) (domain.Member, error)
+	ChangeReferralCode(ctx context.Context, memberId string, code string) error
+	// Referral code
+	AddReferralCode(ctx context.Context, code domain.ReferralCode) error
+	GetReferralCode(ctx context.Context, code string) (domain.ReferralCode, error)
+	GetReferralCodes(ctx context.Context, memberId string) ([]domain.ReferralCode, error)
+	RevokeReferralCode(ctx context.Context, code string) error
+	// Referral
+	// AddReferral stores a new pending referral, flagged or rejected by the limits it exceeds.
+	// Counting the referrals under the limits and storing the new one happen atomically.
+	AddReferral(ctx context.Context, referral domain.Referral, limits ...domain.ReferralLimit) (string, error)
+	CountReferralsByContact(ctx context.Context, programId string, contactEmail string, contactPhone string) (int64, error)
+	GetReferrals(ctx context.Context,
+		filter domain.ReferralFilter,
+		page domain.Page) ([]domain.Referral, error)
+	CountReferrals(ctx context.Context, filter domain.ReferralFilter) (int64, error)
+	GetReferral(ctx context.Context, referralId string) (domain.Referral, error)
+	UpdateReferralStatus(ctx context.Context,
+		change domain.ReferralStatusChange,
+		rewards []domain.Reward,
+		limits ...domain.RewardLimit) error
+	// UpdateReferralStatuses applies every change and adds every reward, or none of them.
+	// Credits over the limits of their program are left out, counting the stored rewards
+	// under the limits and adding the new ones happen atomically.
+	UpdateReferralStatuses(ctx context.Context,
+		changes []domain.ReferralStatusChange,
+		rewards []domain.Reward,
+		limits ...domain.RewardLimit) error
+	// ClaimReferral assigns a pending referral to reviewer, unless another reviewer
+	// claimed it at staleBefore or later.
+	ClaimReferral(ctx context.Context, referralId string, reviewer string, staleBefore int64) error
+	ReleaseReferral(ctx context.Context, referralId string, reviewer string) error
+	SetReferralFlag(ctx context.Context, referralId string, flagged bool, reason string) error
+	AddReferralNote(ctx context.Context, note domain.ReferralNote) (string, error)
+	GetReferralNotes(ctx context.Context, referralId string) ([]domain.ReferralNote, error)
+	// Conversion event
+	// AddConversionEvent stores an event and, when change is set, qualifies the event's
+	// referral with it and records the event's order amount on the referral.
+	AddConversionEvent(ctx context.Context, event domain.ConversionEvent, change *domain.ReferralStatusChange) (string, error)
+	GetConversionEvent(ctx context.Context, externalId string) (domain.ConversionEvent, error)
+	// ExpireReferrals expires up to limit pending referrals, oldest first, whose program's
+	// attribution window closed by now, recording changedBy and reason. It returns their ids.
+	ExpireReferrals(ctx context.Context, now int64, limit int64, changedBy string, reason string) ([]string, error)
+	// Reward
+	AddReward(ctx context.Context, reward domain.Reward) (string, error)
+	GetReward(ctx context.Context, rewardId string) (domain.Reward, error)
+	GetRewards(ctx context.Context, memberId string, page domain.Page) ([]domain.Reward, error)
+	GetRewardBalances(ctx context.Context, memberId string) ([]domain.RewardBalance, error)
+	CountMemberRewards(ctx context.Context, memberId string, programId string) (int64, error)
+	// SumProgramRewards nets the credits and debits a program issued in currency.
+	SumProgramRewards(ctx context.Context, programId string, currency string) (int64, error)
+	// Click
+	AddClick(ctx context.Context, click domain.Click) (string, error)
+	GetClickStats(ctx context.Context, memberId *string, programId *string) (domain.ClickStats, error)
 }
@@ -0,0 +1,1577 @@
+// Package repotest is a conformance suite for repository.Repository implementations.
+//
+// Every implementation must behave like the postgres one: the same constraint
+// errors, the same pagination ordering and the same transactional guarantees.
+// Run it from a test in the implementation's package:
+//
+//	func TestConformance(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) repository.Repository {
+//			return repository.NewMemory(zap.NewNop())
+//		})
+//	}
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"referral-service/domain"
+	"referral-service/repository"
+
+	"github.com/google/uuid"
+)
+
+// NewRepository returns an empty repository for a single test.
+type NewRepository func(t *testing.T) repository.Repository
+
+// Run runs the conformance suite, calling newRepo once per test.
+func Run(t *testing.T, newRepo NewRepository) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, r repository.Repository)
+	}{
+		{"Programs", testPrograms},
+		{"ProgramPagination", testProgramPagination},
+		{"Members", testMembers},
+		{"MemberUniqueness", testMemberUniqueness},
+		{"MemberForeignKeys", testMemberForeignKeys},
+		{"Enrolments", testEnrolments},
+		{"MemberPagination", testMemberPagination},
+		{"Referrals", testReferrals},
+		{"ReferralFilters", testReferralFilters},
+		{"ReferralPagination", testReferralPagination},
+		{"MemberStaysInProgram", testMemberStaysInProgram},
+		{"ReferralContacts", testReferralContacts},
+		{"CountReferrals", testCountReferrals},
+		{"ReferralRiskScore", testReferralRiskScore},
+		{"ChangeReferralCode", testChangeReferralCode},
+		{"ReferralCodes", testReferralCodes},
+		{"ReferralStatus", testReferralStatus},
+		{"ReferralStatusRollback", testReferralStatusRollback},
+		{"ReferralStatuses", testReferralStatuses},
+		{"ReferralReview", testReferralReview},
+		{"ConversionEvents", testConversionEvents},
+		{"ExpireReferrals", testExpireReferrals},
+		{"Rewards", testRewards},
+		{"RewardConstraints", testRewardConstraints},
+		{"Clicks", testClicks},
+		{"ConcurrentInserts", testConcurrentInserts},
+		{"ConcurrentDuplicateInserts", testConcurrentDuplicateInserts},
+		{"ConcurrentLimitedReferrals", testConcurrentLimitedReferrals},
+		{"RewardLimits", testRewardLimits},
+		{"ConcurrentRewardLimits", testConcurrentRewardLimits},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+func testPrograms(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	policy := domain.RewardPolicy{
+		RewardType:          domain.RewardTypeFixed,
+		RewardCurrency:      "EUR",
+		ReferrerReward:      500,
+		RefereeReward:       250,
+		MaxRewardsPerMember: 3,
+		RewardCap:           10000,
+	}
+	rule := domain.QualificationRule{QualifyEvent: "first_purchase", QualifyMinOrder: 2000, QualifyWithinDays: 30}
+	id, err := r.AddProgram(ctx, "spring", "Spring", true, "https://example.com/spring", "spr", policy, rule, 90)
+	mustNot(t, err)
+
+	program, err := r.GetProgram(ctx, id)
+	mustNot(t, err)
+	if program.ID != id || program.Name != "spring" || program.Title != "Spring" || !program.IsActive ||
+		program.LandingUrl != "https://example.com/spring" || program.CodePrefix != "spr" || program.RewardPolicy != policy ||
+		program.QualificationRule != rule || program.AttributionWindowDays != 90 {
+		t.Fatalf("GetProgram = %+v", program)
+	}
+	if program.CreatedAt == 0 || program.UpdatedAt == 0 {
+		t.Fatalf("GetProgram timestamps not set: %+v", program)
+	}
+
+	// only the given fields change.
+	title, active := "Spring sale", false
+	mustNot(t, r.UpdateProgram(ctx, id, nil, &title, &active, nil, nil, nil, nil, nil))
+	program, err = r.GetProgram(ctx, id)
+	mustNot(t, err)
+	if program.Name != "spring" || program.Title != title || program.IsActive || program.CodePrefix != "spr" || program.RewardPolicy != policy || program.AttributionWindowDays != 90 {
+		t.Fatalf("GetProgram after partial update = %+v", program)
+	}
+
+	// the policy is replaced as a whole.
+	policy = domain.RewardPolicy{RewardType: domain.RewardTypePercentage, RewardCurrency: "USD", ReferrerReward: 100}
+	mustNot(t, r.UpdateProgram(ctx, id, nil, nil, nil, nil, nil, &policy, nil, nil))
+	program, err = r.GetProgram(ctx, id)
+	mustNot(t, err)
+	if program.RewardPolicy != policy || program.QualificationRule != rule {
+		t.Fatalf("RewardPolicy after update = %+v, want %+v", program.RewardPolicy, policy)
+	}
+
+	rule = domain.QualificationRule{QualifyEvent: "signed_up"}
+	mustNot(t, r.UpdateProgram(ctx, id, nil, nil, nil, nil, nil, nil, &rule, nil))
+	program, err = r.GetProgram(ctx, id)
+	mustNot(t, err)
+	if program.QualificationRule != rule || program.RewardPolicy != policy {
+		t.Fatalf("QualificationRule after update = %+v, want %+v", program.QualificationRule, rule)
+	}
+
+	_, err = r.GetProgram(ctx, "missing")
+	mustKind(t, err, domain.ErrNotFound)
+
+	_, err = r.AddProgram(ctx, "bad", "Bad", true, "", "", domain.RewardPolicy{RewardType: "bogus"}, domain.QualificationRule{}, 0)
+	mustKind(t, err, domain.ErrInvalidArgument)
+	bad := domain.RewardPolicy{RewardType: "bogus"}
+	mustKind(t, r.UpdateProgram(ctx, id, nil, nil, nil, nil, nil, &bad, nil, nil), domain.ErrInvalidArgument)
+
+	window := int64(0)
+	mustNot(t, r.UpdateProgram(ctx, id, nil, nil, nil, nil, nil, nil, nil, &window))
+	program, err = r.GetProgram(ctx, id)
+	mustNot(t, err)
+	if program.AttributionWindowDays != 0 || program.QualificationRule != rule {
+		t.Fatalf("GetProgram after window update = %+v", program)
+	}
+}
+
+func testProgramPagination(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		addProgram(t, r)
+	}
+	checkPagination(t, 5, func(page domain.Page) ([]string, []domain.Cursor) {
+		programs, err := r.GetPrograms(ctx, page)
+		mustNot(t, err)
+		var ids []string
+		var cursors []domain.Cursor
+		for _, p := range programs {
+			ids = append(ids, p.ID)
+			cursors = append(cursors, domain.Cursor{Key: p.CreatedAt, ID: p.ID})
+		}
+		return ids, cursors
+	})
+}
+
+func testMembers(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+
+	last, code := "Lovelace", "ada01"
+	id, err := r.AddMember(ctx, "Ada", &last, "ada@example.com", programId, code, nil)
+	mustNot(t, err)
+
+	member, err := r.GetMember(ctx, id)
+	mustNot(t, err)
+	if member.ID != id || member.FirstName != "Ada" || member.LastName != last || member.Email != "ada@example.com" ||
+		member.ProgramId != programId || member.ReferralCode != code || !member.IsActive {
+		t.Fatalf("GetMember = %+v", member)
+	}
+
+	byEmail, err := r.GetMemberByEmail(ctx, "ada@example.com", nil)
+	mustNot(t, err)
+	byCode, err := r.GetMemberByReferralCode(ctx, code)
+	mustNot(t, err)
+	if byEmail != member || byCode != member {
+		t.Fatalf("lookups disagree: by email %+v, by code %+v, by id %+v", byEmail, byCode, member)
+	}
+
+	// members can start out inactive.
+	inactive := false
+	otherId, err := r.AddMember(ctx, "Bob", nil, "bob@example.com", programId, newCode(), &inactive)
+	mustNot(t, err)
+	other, err := r.GetMember(ctx, otherId)
+	mustNot(t, err)
+	if other.IsActive || other.LastName != "" {
+		t.Fatalf("GetMember with defaults = %+v", other)
+	}
+
+	first, active := "Adele", false
+	mustNot(t, r.UpdateMember(ctx, id, &first, nil, nil, nil, &active))
+	member, err = r.GetMember(ctx, id)
+	mustNot(t, err)
+	if member.FirstName != first || member.LastName != last || member.IsActive {
+		t.Fatalf("GetMember after update = %+v", member)
+	}
+
+	_, err = r.GetMember(ctx, "missing")
+	mustKind(t, err, domain.ErrNotFound)
+	_, err = r.GetMemberByEmail(ctx, "missing@example.com", nil)
+	mustKind(t, err, domain.ErrNotFound)
+	_, err = r.GetMemberByReferralCode(ctx, "missing")
+	mustKind(t, err, domain.ErrNotFound)
+}
+
+func testMemberUniqueness(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	code := "dup01"
+	_, err := r.AddMember(ctx, "Ada", nil, "ada@example.com", programId, code, nil)
+	mustNot(t, err)
+
+	_, err = r.AddMember(ctx, "Ada", nil, "ada@example.com", programId, newCode(), nil)
+	mustKind(t, err, domain.ErrAlreadyExists)
+	// emails are unique in any case.
+	_, err = r.AddMember(ctx, "Ada", nil, "ADA@Example.com", programId, newCode(), nil)
+	mustKind(t, err, domain.ErrAlreadyExists)
+	_, err = r.AddMember(ctx, "Bob", nil, "bob@example.com", programId, code, nil)
+	if !errors.Is(err, domain.ErrReferralCodeTaken) {
+		t.Fatalf("AddMember with a taken code error = %v, want %v", err, domain.ErrReferralCodeTaken)
+	}
+
+	bobId, err := r.AddMember(ctx, "Bob", nil, "bob@example.com", programId, newCode(), nil)
+	mustNot(t, err)
+	email := "ada@example.com"
+	mustKind(t, r.UpdateMember(ctx, bobId, nil, nil, &email, nil, nil), domain.ErrAlreadyExists)
+	email = "Ada@example.COM"
+	mustKind(t, r.UpdateMember(ctx, bobId, nil, nil, &email, nil, nil), domain.ErrAlreadyExists)
+
+	// the failed update left bob untouched.
+	bob, err := r.GetMember(ctx, bobId)
+	mustNot(t, err)
+	if bob.Email != "bob@example.com" {
+		t.Fatalf("email after rejected update = %q", bob.Email)
+	}
+}
+
+func testMemberForeignKeys(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	_, err := r.AddMember(ctx, "Ada", nil, "ada@example.com", "missing", newCode(), nil)
+	mustKind(t, err, domain.ErrInvalidArgument)
+
+	programId := addProgram(t, r)
+	id, err := r.AddMember(ctx, "Ada", nil, "ada@example.com", programId, newCode(), nil)
+	mustNot(t, err)
+	missing := "missing"
+	mustKind(t, r.UpdateMember(ctx, id, nil, nil, nil, &missing, nil), domain.ErrInvalidArgument)
+}
+
+func testEnrolments(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	friends, partners := addProgram(t, r), addProgram(t, r)
+	inFriends, _ := addMember(t, r, friends, "ada@example.com")
+	inPartners, _ := addMember(t, r, partners, "Ada@Example.com")
+	bobId, _ := addMember(t, r, friends, "bob@example.com")
+
+	ada, err := r.GetMember(ctx, inFriends)
+	mustNot(t, err)
+	adaPartner, err := r.GetMember(ctx, inPartners)
+	mustNot(t, err)
+	if ada.PersonId == "" || ada.PersonId != adaPartner.PersonId || ada.ReferralCode == adaPartner.ReferralCode {
+		t.Fatalf("enrolments of one person = %+v and %+v", ada, adaPartner)
+	}
+	bob, err := r.GetMember(ctx, bobId)
+	mustNot(t, err)
+	if bob.PersonId == ada.PersonId {
+		t.Fatalf("bob and ada share person %s", bob.PersonId)
+	}
+
+	_, err = r.AddMember(ctx, "Ada", nil, "ada@example.com", partners, newCode(), nil)
+	mustKind(t, err, domain.ErrAlreadyExists)
+
+	list := func(filter domain.MemberFilter) []string {
+		t.Helper()
+		members, err := r.GetMembers(ctx, filter, firstPage(100))
+		mustNot(t, err)
+		var ids []string
+		for _, member := range members {
+			ids = append(ids, member.ID)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	want := []string{inFriends, bobId}
+	sort.Strings(want)
+	if got := list(domain.MemberFilter{ProgramId: &friends}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("members of friends = %v, want %v", got, want)
+	}
+	contact := "ada@example.com"
+	if got := list(domain.MemberFilter{ProgramId: &partners, ContactEmail: &contact}); !reflect.DeepEqual(got, []string{inPartners}) {
+		t.Fatalf("members of partners with contact email %s = %v", contact, got)
+	}
+	want = []string{inFriends, inPartners}
+	sort.Strings(want)
+	if got := list(domain.MemberFilter{PersonId: &ada.PersonId}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("enrolments of ada = %v, want %v", got, want)
+	}
+	email := "ada@example.com"
+	if got := list(domain.MemberFilter{Email: &email, ProgramId: &partners}); !reflect.DeepEqual(got, []string{inPartners}) {
+		t.Fatalf("ada in partners = %v", got)
+	}
+
+	// an email enrolled twice needs a program to find the member by.
+	_, err = r.GetMemberByEmail(ctx, email, nil)
+	if !errors.Is(err, domain.ErrAmbiguousEmail) {
+		t.Fatalf("GetMemberByEmail without program error = %v, want %v", err, domain.ErrAmbiguousEmail)
+	}
+	member, err := r.GetMemberByEmail(ctx, email, &partners)
+	mustNot(t, err)
+	if member.ID != inPartners {
+		t.Fatalf("GetMemberByEmail in partners = %s, want %s", member.ID, inPartners)
+	}
+	_, err = r.GetMemberByEmail(ctx, "bob@example.com", &partners)
+	mustKind(t, err, domain.ErrNotFound)
+
+	// changing the email moves the enrolment to the person with that email.
+	mustKind(t, r.UpdateMember(ctx, bobId, nil, nil, &email, nil, nil), domain.ErrAlreadyExists)
+	mustNot(t, r.UpdateMember(ctx, inPartners, nil, nil, &bob.Email, nil, nil))
+	moved, err := r.GetMember(ctx, inPartners)
+	mustNot(t, err)
+	if moved.PersonId != bob.PersonId || moved.Email != bob.Email {
+		t.Fatalf("enrolment after email change = %+v, want person %s", moved, bob.PersonId)
+	}
+}
+
+func testMemberPagination(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	for i := 0; i < 7; i++ {
+		_, err := r.AddMember(ctx, "M", nil, fmt.Sprintf("m%d@example.com", i), programId, newCode(), nil)
+		mustNot(t, err)
+	}
+	checkPagination(t, 7, func(page domain.Page) ([]string, []domain.Cursor) {
+		members, err := r.GetMembers(ctx, domain.MemberFilter{}, page)
+		mustNot(t, err)
+		var ids []string
+		var cursors []domain.Cursor
+		for _, m := range members {
+			ids = append(ids, m.ID)
+			cursors = append(cursors, domain.Cursor{Key: m.CreatedAt, ID: m.ID})
+		}
+		return ids, cursors
+	})
+}
+
+func testReferrals(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	memberId, code := addMember(t, r, programId, "ada@example.com")
+
+	added := domain.Referral{
+		FirstName:    "Cy",
+		Email:        "Cy@example.com",
+		Phone:        "+1 555-0100",
+		ContactEmail: "cy@example.com",
+		ContactPhone: "15550100",
+		ReferralCode: code,
+		Flagged:      true,
+		FlagReason:   "referee was already referred",
+	}
+	id, err := r.AddReferral(ctx, added)
+	mustNot(t, err)
+
+	referral, err := r.GetReferral(ctx, id)
+	mustNot(t, err)
+	if referral.ID != id || referral.FirstName != added.FirstName || referral.Email != added.Email || referral.Phone != added.Phone ||
+		referral.ContactEmail != added.ContactEmail || referral.ContactPhone != added.ContactPhone ||
+		referral.ReferralCode != code || referral.Status != domain.ReferralStatusPending ||
+		!referral.Flagged || referral.FlagReason != added.FlagReason || referral.CreatedAt == 0 ||
+		referral.ProgramId != programId || referral.MemberId != memberId {
+		t.Fatalf("GetReferral = %+v", referral)
+	}
+
+	added.ReferralCode = "missing"
+	_, err = r.AddReferral(ctx, added)
+	mustKind(t, err, domain.ErrInvalidArgument)
+	_, err = r.GetReferral(ctx, "missing")
+	mustKind(t, err, domain.ErrNotFound)
+}
+
+func testReferralFilters(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programA, programB := addProgram(t, r), addProgram(t, r)
+	adaId, adaCode := addMember(t, r, programA, "ada@example.com")
+	_, bobCode := addMember(t, r, programB, "bob@example.com")
+
+	cyId := addReferral(t, r, adaCode, "Cy@Example.com")
+	addReferral(t, r, adaCode, "dee@example.com")
+	addReferral(t, r, bobCode, "eve@example.com")
+	mustNot(t, r.UpdateReferralStatus(ctx, domain.ReferralStatusChange{
+		ReferralId: cyId,
+		FromStatus: domain.ReferralStatusPending,
+		ToStatus:   domain.ReferralStatusDenied,
+		ChangedBy:  "test",
+	}, nil))
+
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name   string
+		filter domain.ReferralFilter
+		want   int
+	}{
+		{"none", domain.ReferralFilter{}, 3},
+		{"program", domain.ReferralFilter{ProgramId: &programA}, 2},
+		{"member", domain.ReferralFilter{MemberId: &adaId}, 2},
+		{"code", domain.ReferralFilter{ReferralCode: &bobCode}, 1},
+		{"status", domain.ReferralFilter{Status: str(domain.ReferralStatusPending)}, 2},
+		{"email ignores case", domain.ReferralFilter{Email: str("cy@EXAMPLE.com")}, 1},
+		{"combined", domain.ReferralFilter{ProgramId: &programA, Status: str(domain.ReferralStatusDenied)}, 1},
+		{"no match", domain.ReferralFilter{ProgramId: &programB, Status: str(domain.ReferralStatusDenied)}, 0},
+	}
+	for _, tt := range tests {
+		referrals, err := r.GetReferrals(ctx, tt.filter, firstPage(100))
+		mustNot(t, err)
+		if len(referrals) != tt.want {
+			t.Errorf("GetReferrals(%s) returned %d referrals, want %d", tt.name, len(referrals), tt.want)
+		}
+	}
+
+	// all three were created no earlier than the first one.
+	all, err := r.GetReferrals(ctx, domain.ReferralFilter{}, firstPage(100))
+	mustNot(t, err)
+	earliest := all[0].CreatedAt
+	after, err := r.GetReferrals(ctx, domain.ReferralFilter{CreatedAfter: &earliest}, firstPage(100))
+	mustNot(t, err)
+	before, err := r.GetReferrals(ctx, domain.ReferralFilter{CreatedBefore: &earliest}, firstPage(100))
+	mustNot(t, err)
+	if len(after) != 3 || len(before) != 0 {
+		t.Errorf("created_after is inclusive and created_before exclusive: got %d after and %d before, want 3 and 0", len(after), len(before))
+	}
+}
+
+func testReferralPagination(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	_, code := addMember(t, r, programId, "ada@example.com")
+	for i := 0; i < 6; i++ {
+		addReferral(t, r, code, fmt.Sprintf("r%d@example.com", i))
+	}
+	for _, filter := range []domain.ReferralFilter{
+		{},
+		{Descending: true},
+		{SortBy: domain.ReferralSortUpdatedAt},
+		{SortBy: domain.ReferralSortUpdatedAt, Descending: true},
+		{SortBy: domain.ReferralSortRiskScore},
+	} {
+		checkPagination(t, 6, func(page domain.Page) ([]string, []domain.Cursor) {
+			referrals, err := r.GetReferrals(ctx, filter, page)
+			mustNot(t, err)
+			var ids []string
+			var cursors []domain.Cursor
+			for _, ref := range referrals {
+				ids = append(ids, ref.ID)
+				cursors = append(cursors, domain.Cursor{Key: filter.SortKey(ref), ID: ref.ID})
+			}
+			return ids, cursors
+		})
+	}
+
+	asc, err := r.GetReferrals(ctx, domain.ReferralFilter{}, firstPage(100))
+	mustNot(t, err)
+	desc, err := r.GetReferrals(ctx, domain.ReferralFilter{Descending: true}, firstPage(100))
+	mustNot(t, err)
+	for i := range asc {
+		if asc[i].ID != desc[len(desc)-1-i].ID {
+			t.Fatalf("descending order is not the reverse of ascending order")
+		}
+	}
+}
+
+// testMemberStaysInProgram checks a member can't move their referrals to another program.
+func testMemberStaysInProgram(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programA, programB := addProgram(t, r), addProgram(t, r)
+	memberId, code := addMember(t, r, programA, "ada@example.com")
+	id := addReferral(t, r, code, "cy@example.com")
+
+	mustKind(t, r.UpdateMember(ctx, memberId, nil, nil, nil, &programB, nil), domain.ErrInvalidArgument)
+	// sending the member's own program changes nothing.
+	mustNot(t, r.UpdateMember(ctx, memberId, nil, nil, nil, &programA, nil))
+
+	member, err := r.GetMember(ctx, memberId)
+	mustNot(t, err)
+	if member.ProgramId != programA {
+		t.Fatalf("member program = %s, want %s", member.ProgramId, programA)
+	}
+	referrals, err := r.GetReferrals(ctx, domain.ReferralFilter{ProgramId: &programA}, firstPage(100))
+	mustNot(t, err)
+	if len(referrals) != 1 || referrals[0].ID != id {
+		t.Fatalf("program lists %d referrals, want the member's one", len(referrals))
+	}
+}
+
+func testReferralContacts(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programA, programB := addProgram(t, r), addProgram(t, r)
+	_, adaCode := addMember(t, r, programA, "ada@example.com")
+	_, bobCode := addMember(t, r, programA, "bob@example.com")
+	_, cyCode := addMember(t, r, programB, "cy@example.com")
+
+	flaggedId, err := r.AddReferral(ctx, domain.Referral{ContactPhone: "15550100", ReferralCode: adaCode, Flagged: true, FlagReason: "suspicious"})
+	mustNot(t, err)
+	addReferral(t, r, bobCode, "Dee@example.com")
+	addReferral(t, r, cyCode, "eve@example.com")
+
+	for _, tt := range []struct {
+		programId, email, phone string
+		want                    int64
+	}{
+		{programA, "dee@example.com", "", 1},
+		{programA, "", "15550100", 1},
+		{programA, "dee@example.com", "15550100", 2},
+		{programA, "eve@example.com", "", 0},
+		{programB, "eve@example.com", "", 1},
+		{programA, "", "", 0},
+	} {
+		count, err := r.CountReferralsByContact(ctx, tt.programId, tt.email, tt.phone)
+		mustNot(t, err)
+		if count != tt.want {
+			t.Fatalf("CountReferralsByContact(%s, %q, %q) = %d, want %d", tt.programId, tt.email, tt.phone, count, tt.want)
+		}
+	}
+
+	flagged := true
+	referrals, err := r.GetReferrals(ctx, domain.ReferralFilter{Flagged: &flagged}, firstPage(100))
+	mustNot(t, err)
+	if len(referrals) != 1 || referrals[0].ID != flaggedId {
+		t.Fatalf("flagged referrals = %+v", referrals)
+	}
+
+	phone := "15550100"
+	count, err := r.CountReferrals(ctx, domain.ReferralFilter{ContactPhone: &phone})
+	mustNot(t, err)
+	if count != 1 {
+		t.Fatalf("CountReferrals by contact phone = %d, want 1", count)
+	}
+}
+
+func testReferralRiskScore(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	_, code := addMember(t, r, programId, "ada@example.com")
+	var want []string
+	for _, score := range []int64{90, 10, 40} {
+		id, err := r.AddReferral(ctx, domain.Referral{ReferralCode: code, RiskScore: score})
+		mustNot(t, err)
+		want = append(want, id)
+	}
+	want = []string{want[0], want[2], want[1]}
+
+	referrals, err := r.GetReferrals(ctx,
+		domain.ReferralFilter{ProgramId: &programId, SortBy: domain.ReferralSortRiskScore, Descending: true},
+		firstPage(100))
+	mustNot(t, err)
+	var got []string
+	for _, ref := range referrals {
+		got = append(got, ref.ID)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("referrals by risk score = %v, want %v", got, want)
+	}
+	if referrals[0].RiskScore != 90 {
+		t.Fatalf("risk score = %d, want 90", referrals[0].RiskScore)
+	}
+}
+
+func testCountReferrals(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programA, programB := addProgram(t, r), addProgram(t, r)
+	_, adaCode := addMember(t, r, programA, "ada@example.com")
+	_, bobCode := addMember(t, r, programB, "bob@example.com")
+
+	for _, referral := range []domain.Referral{
+		{ReferralCode: adaCode, IpHash: "a"},
+		{ReferralCode: adaCode, IpHash: "b"},
+		{ReferralCode: bobCode, IpHash: "a"},
+	} {
+		_, err := r.AddReferral(ctx, referral)
+		mustNot(t, err)
+	}
+
+	ip := "a"
+	later := time.Now().Add(time.Hour).Unix()
+	for _, tt := range []struct {
+		name   string
+		filter domain.ReferralFilter
+		want   int64
+	}{
+		{"code", domain.ReferralFilter{ReferralCode: &adaCode}, 2},
+		{"program", domain.ReferralFilter{ProgramId: &programB}, 1},
+		{"ip", domain.ReferralFilter{IpHash: &ip}, 2},
+		{"ip in program", domain.ReferralFilter{IpHash: &ip, ProgramId: &programA}, 1},
+		{"created after", domain.ReferralFilter{ReferralCode: &adaCode, CreatedAfter: &later}, 0},
+	} {
+		count, err := r.CountReferrals(ctx, tt.filter)
+		mustNot(t, err)
+		if count != tt.want {
+			t.Fatalf("CountReferrals by %s = %d, want %d", tt.name, count, tt.want)
+		}
+	}
+}
+
+func testChangeReferralCode(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	adaId, oldCode := addMember(t, r, programId, "ada@example.com")
+	bobId, bobCode := addMember(t, r, programId, "bob@example.com")
+	oldReferral := addReferral(t, r, oldCode, "cy@example.com")
+
+	claimed := newCode()
+	mustNot(t, r.ChangeReferralCode(ctx, adaId, claimed))
+	ada, err := r.GetMember(ctx, adaId)
+	mustNot(t, err)
+	if ada.ReferralCode != claimed {
+		t.Fatalf("referral code = %s, want %s", ada.ReferralCode, claimed)
+	}
+
+	// the retired code still resolves to ada and accepts referrals.
+	for _, code := range []string{oldCode, claimed} {
+		member, err := r.GetMemberByReferralCode(ctx, code)
+		mustNot(t, err)
+		if member.ID != adaId {
+			t.Fatalf("code %s resolves to %s, want %s", code, member.ID, adaId)
+		}
+	}
+	addReferral(t, r, oldCode, "dee@example.com")
+	addReferral(t, r, claimed, "eve@example.com")
+	referrals, err := r.GetReferrals(ctx, domain.ReferralFilter{MemberId: &adaId}, firstPage(100))
+	mustNot(t, err)
+	if len(referrals) != 3 {
+		t.Fatalf("ada has %d referrals, want 3", len(referrals))
+	}
+	referral, err := r.GetReferral(ctx, oldReferral)
+	mustNot(t, err)
+	if referral.ReferralCode != oldCode || referral.MemberId != adaId {
+		t.Fatalf("referral made before the change = %+v", referral)
+	}
+
+	// codes, current or retired, belong to one member only.
+	if err := r.ChangeReferralCode(ctx, bobId, oldCode); !errors.Is(err, domain.ErrReferralCodeTaken) {
+		t.Fatalf("claiming a retired code error = %v, want %v", err, domain.ErrReferralCodeTaken)
+	}
+	if err := r.ChangeReferralCode(ctx, adaId, bobCode); !errors.Is(err, domain.ErrReferralCodeTaken) {
+		t.Fatalf("claiming a current code error = %v, want %v", err, domain.ErrReferralCodeTaken)
+	}
+	_, err = r.AddMember(ctx, "Fay", nil, "fay@example.com", programId, oldCode, nil)
+	if !errors.Is(err, domain.ErrReferralCodeTaken) {
+		t.Fatalf("AddMember with a retired code error = %v, want %v", err, domain.ErrReferralCodeTaken)
+	}
+
+	// ada may take back her own retired code.
+	mustNot(t, r.ChangeReferralCode(ctx, adaId, oldCode))
+	ada, err = r.GetMember(ctx, adaId)
+	mustNot(t, err)
+	if ada.ReferralCode != oldCode {
+		t.Fatalf("referral code = %s, want %s", ada.ReferralCode, oldCode)
+	}
+	mustKind(t, r.ChangeReferralCode(ctx, "missing", claimed), domain.ErrNotFound)
+}
+
+func testReferralCodes(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	adaId, current := addMember(t, r, programId, "ada@example.com")
+	social := newCode()
+	mustNot(t, r.AddReferralCode(ctx, domain.ReferralCode{Code: social, MemberId: adaId, Channel: "social", ExpiresAt: 4102444800}))
+
+	code, err := r.GetReferralCode(ctx, social)
+	mustNot(t, err)
+	if code.MemberId != adaId || code.Channel != "social" || code.ExpiresAt != 4102444800 || code.CreatedAt == 0 || code.RevokedAt != 0 {
+		t.Fatalf("GetReferralCode = %+v", code)
+	}
+	codes, err := r.GetReferralCodes(ctx, adaId)
+	mustNot(t, err)
+	if len(codes) != 2 {
+		t.Fatalf("GetReferralCodes = %+v, want the current and the social code", codes)
+	}
+	_, err = r.GetReferralCode(ctx, "missing")
+	mustKind(t, err, domain.ErrNotFound)
+
+	// the extra code resolves to ada and tags her referrals with its channel.
+	member, err := r.GetMemberByReferralCode(ctx, social)
+	mustNot(t, err)
+	if member.ID != adaId || member.ReferralCode != current {
+		t.Fatalf("GetMemberByReferralCode(%s) = %+v", social, member)
+	}
+	id := addReferral(t, r, social, "cy@example.com")
+	addReferral(t, r, current, "dee@example.com")
+	referral, err := r.GetReferral(ctx, id)
+	mustNot(t, err)
+	if referral.MemberId != adaId || referral.ProgramId != programId || referral.Channel != "social" {
+		t.Fatalf("referral made with the social code = %+v", referral)
+	}
+	channel := "social"
+	referrals, err := r.GetReferrals(ctx, domain.ReferralFilter{Channel: &channel}, firstPage(100))
+	mustNot(t, err)
+	if len(referrals) != 1 || referrals[0].ID != id {
+		t.Fatalf("referrals in channel social = %+v", referrals)
+	}
+
+	err = r.AddReferralCode(ctx, domain.ReferralCode{Code: current, MemberId: adaId})
+	if !errors.Is(err, domain.ErrReferralCodeTaken) {
+		t.Fatalf("AddReferralCode with a taken code error = %v, want %v", err, domain.ErrReferralCodeTaken)
+	}
+	mustKind(t, r.AddReferralCode(ctx, domain.ReferralCode{Code: newCode(), MemberId: "missing"}), domain.ErrInvalidArgument)
+
+	mustNot(t, r.RevokeReferralCode(ctx, social))
+	code, err = r.GetReferralCode(ctx, social)
+	mustNot(t, err)
+	if code.RevokedAt == 0 {
+		t.Fatalf("revoked code = %+v", code)
+	}
+	revokedAt := code.RevokedAt
+	mustNot(t, r.RevokeReferralCode(ctx, social))
+	code, err = r.GetReferralCode(ctx, social)
+	mustNot(t, err)
+	if code.RevokedAt != revokedAt {
+		t.Fatalf("revoking again moved revoked_at from %d to %d", revokedAt, code.RevokedAt)
+	}
+
+	// claiming a revoked code of her own makes it her working current code again.
+	mustNot(t, r.ChangeReferralCode(ctx, adaId, social))
+	code, err = r.GetReferralCode(ctx, social)
+	mustNot(t, err)
+	if code.RevokedAt != 0 || code.ExpiresAt != 0 || code.RetiredAt != 0 {
+		t.Fatalf("reclaimed code = %+v", code)
+	}
+}
+
+func testReferralStatus(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	memberId, code := addMember(t, r, programId, "ada@example.com")
+	id := addReferral(t, r, code, "cy@example.com")
+
+	change := domain.ReferralStatusChange{
+		ReferralId: id,
+		FromStatus: domain.ReferralStatusPending,
+		ToStatus:   domain.ReferralStatusApproved,
+		ChangedBy:  "ops",
+		Reason:     "looks good",
+	}
+	reward := domain.Reward{
+		Beneficiary: domain.RewardBeneficiaryReferrer,
+		MemberId:    memberId,
+		ProgramId:   programId,
+		ReferralId:  id,
+		EntryType:   domain.RewardEntryCredit,
+		Amount:      1000,
+		Currency:    "USD",
+	}
+	mustNot(t, r.UpdateReferralStatus(ctx, change, []domain.Reward{reward}))
+
+	referral, err := r.GetReferral(ctx, id)
+	mustNot(t, err)
+	if referral.Status != domain.ReferralStatusApproved || referral.StatusChangedBy != "ops" || referral.StatusReason != "looks good" {
+		t.Fatalf("GetReferral after status change = %+v", referral)
+	}
+	rewards, err := r.GetRewards(ctx, memberId, firstPage(100))
+	mustNot(t, err)
+	if len(rewards) != 1 || rewards[0].Amount != 1000 || rewards[0].ReferralId != id {
+		t.Fatalf("GetRewards after approval = %+v", rewards)
+	}
+
+	// the change only applies while the referral is still in FromStatus.
+	change.ToStatus = domain.ReferralStatusDenied
+	err = r.UpdateReferralStatus(ctx, change, nil)
+	if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+		t.Fatalf("stale UpdateReferralStatus error = %v, want %v", err, domain.ErrInvalidStatusTransition)
+	}
+	change.ReferralId = "missing"
+	err = r.UpdateReferralStatus(ctx, change, nil)
+	if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+		t.Fatalf("UpdateReferralStatus of missing referral error = %v, want %v", err, domain.ErrInvalidStatusTransition)
+	}
+
+	other := addReferral(t, r, code, "dee@example.com")
+	err = r.UpdateReferralStatus(ctx, domain.ReferralStatusChange{
+		ReferralId: other,
+		FromStatus: domain.ReferralStatusPending,
+		ToStatus:   "bogus",
+		ChangedBy:  "ops",
+	}, nil)
+	mustKind(t, err, domain.ErrInvalidArgument)
+}
+
+// testReferralStatusRollback checks a failing reward undoes the whole status change.
+func testReferralStatusRollback(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	memberId, code := addMember(t, r, programId, "ada@example.com")
+	id := addReferral(t, r, code, "cy@example.com")
+
+	credit := domain.Reward{
+		Beneficiary: domain.RewardBeneficiaryReferrer,
+		MemberId:    memberId,
+		ProgramId:   programId,
+		ReferralId:  id,
+		EntryType:   domain.RewardEntryCredit,
+		Amount:      1000,
+		Currency:    "USD",
+	}
+	err := r.UpdateReferralStatus(ctx, domain.ReferralStatusChange{
+		ReferralId: id,
+		FromStatus: domain.ReferralStatusPending,
+		ToStatus:   domain.ReferralStatusApproved,
+		ChangedBy:  "ops",
+	}, []domain.Reward{credit, credit})
+	mustKind(t, err, domain.ErrAlreadyExists)
+
+	referral, err := r.GetReferral(ctx, id)
+	mustNot(t, err)
+	if referral.Status != domain.ReferralStatusPending {
+		t.Fatalf("status after failed change = %s, want %s", referral.Status, domain.ReferralStatusPending)
+	}
+	rewards, err := r.GetRewards(ctx, memberId, firstPage(100))
+	mustNot(t, err)
+	if len(rewards) != 0 {
+		t.Fatalf("failed change left %d rewards", len(rewards))
+	}
+}
+
+func testReferralStatuses(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	memberId, code := addMember(t, r, programId, "ada@example.com")
+	first := addReferral(t, r, code, "cy@example.com")
+	second := addReferral(t, r, code, "dee@example.com")
+	approve := func(id string) domain.ReferralStatusChange {
+		return domain.ReferralStatusChange{
+			ReferralId: id,
+			FromStatus: domain.ReferralStatusPending,
+			ToStatus:   domain.ReferralStatusApproved,
+			ChangedBy:  "ops",
+		}
+	}
+	credit := domain.Reward{
+		Beneficiary: domain.RewardBeneficiaryReferrer,
+		MemberId:    memberId,
+		ProgramId:   programId,
+		ReferralId:  first,
+		EntryType:   domain.RewardEntryCredit,
+		Amount:      1000,
+		Currency:    "USD",
+	}
+
+	// the second change fails, so neither applies.
+	err := r.UpdateReferralStatuses(ctx,
+		[]domain.ReferralStatusChange{approve(first), approve(second), approve(second)},
+		[]domain.Reward{credit})
+	mustKind(t, err, domain.ErrConflict)
+	for _, id := range []string{first, second} {
+		referral, err := r.GetReferral(ctx, id)
+		mustNot(t, err)
+		if referral.Status != domain.ReferralStatusPending {
+			t.Fatalf("status after failed batch = %s, want %s", referral.Status, domain.ReferralStatusPending)
+		}
+	}
+	rewards, err := r.GetRewards(ctx, memberId, firstPage(100))
+	mustNot(t, err)
+	if len(rewards) != 0 {
+		t.Fatalf("failed batch left %d rewards", len(rewards))
+	}
+
+	mustNot(t, r.ClaimReferral(ctx, first, "ops", 0))
+	err = r.UpdateReferralStatuses(ctx,
+		[]domain.ReferralStatusChange{approve(first), approve(second)},
+		[]domain.Reward{credit})
+	mustNot(t, err)
+	for _, id := range []string{first, second} {
+		referral, err := r.GetReferral(ctx, id)
+		mustNot(t, err)
+		if referral.Status != domain.ReferralStatusApproved || referral.ClaimedBy != "" {
+			t.Fatalf("referral after batch = %+v, want approved and unclaimed", referral)
+		}
+	}
+	rewards, err = r.GetRewards(ctx, memberId, firstPage(100))
+	mustNot(t, err)
+	if len(rewards) != 1 {
+		t.Fatalf("batch added %d rewards, want 1", len(rewards))
+	}
+}
+
+func testReferralReview(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	_, code := addMember(t, r, programId, "ada@example.com")
+	id := addReferral(t, r, code, "cy@example.com")
+	now := time.Now().Unix()
+
+	mustKind(t, r.ClaimReferral(ctx, uuid.New().String(), "ann", now), domain.ErrNotFound)
+	mustNot(t, r.ClaimReferral(ctx, id, "ann", now))
+	mustKind(t, r.ClaimReferral(ctx, id, "bob", now), domain.ErrConflict)
+	mustNot(t, r.ClaimReferral(ctx, id, "ann", now))
+	mustKind(t, r.ReleaseReferral(ctx, id, "bob"), domain.ErrConflict)
+
+	ann := "ann"
+	claimed, err := r.GetReferrals(ctx, domain.ReferralFilter{ClaimedBy: &ann}, firstPage(100))
+	mustNot(t, err)
+	if len(claimed) != 1 || claimed[0].ID != id || claimed[0].ClaimedAt == 0 {
+		t.Fatalf("referrals claimed by ann = %+v", claimed)
+	}
+
+	// claims made before staleBefore can be taken over.
+	mustNot(t, r.ClaimReferral(ctx, id, "bob", now+3600))
+	mustNot(t, r.ReleaseReferral(ctx, id, "bob"))
+	mustNot(t, r.ClaimReferral(ctx, id, "ann", now))
+
+	mustNot(t, r.SetReferralFlag(ctx, id, true, "odd address"))
+	mustKind(t, r.SetReferralFlag(ctx, uuid.New().String(), true, "odd address"), domain.ErrNotFound)
+	referral, err := r.GetReferral(ctx, id)
+	mustNot(t, err)
+	if !referral.Flagged || referral.FlagReason != "odd address" || referral.ClaimedBy != "ann" {
+		t.Fatalf("referral after flag = %+v", referral)
+	}
+
+	first, err := r.AddReferralNote(ctx, domain.ReferralNote{ReferralId: id, Author: "ann", Note: "called the referee"})
+	mustNot(t, err)
+	second, err := r.AddReferralNote(ctx, domain.ReferralNote{ReferralId: id, Author: "bob", Note: "looks fine"})
+	mustNot(t, err)
+	_, err = r.AddReferralNote(ctx, domain.ReferralNote{ReferralId: uuid.New().String(), Author: "ann", Note: "lost"})
+	mustKind(t, err, domain.ErrInvalidArgument)
+	notes, err := r.GetReferralNotes(ctx, id)
+	mustNot(t, err)
+	// notes added in the same second have no order.
+	got := map[string]string{}
+	for _, note := range notes {
+		got[note.ID] = note.Author
+	}
+	if want := map[string]string{first: "ann", second: "bob"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("notes = %+v, want %v", notes, want)
+	}
+
+	// only the claimant can change a referral while the claim is fresh.
+	deny := domain.ReferralStatusChange{
+		ReferralId:        id,
+		FromStatus:        domain.ReferralStatusPending,
+		ToStatus:          domain.ReferralStatusDenied,
+		ChangedBy:         "bob",
+		StaleClaimsBefore: now,
+	}
+	if err := r.UpdateReferralStatus(ctx, deny, nil); !errors.Is(err, domain.ErrReferralClaimed) {
+		t.Fatalf("denying a referral claimed by another reviewer error = %v, want %v", err, domain.ErrReferralClaimed)
+	}
+	deny.ChangedBy = "ann"
+	mustNot(t, r.UpdateReferralStatus(ctx, deny, nil))
+	mustKind(t, r.ClaimReferral(ctx, id, "ann", now), domain.ErrConflict)
+}
+
+func testConversionEvents(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	_, code := addMember(t, r, programId, "ada@example.com")
+	id := addReferral(t, r, code, "cy@example.com")
+	now := time.Now().Unix()
+
+	eventId, err := r.AddConversionEvent(ctx, domain.ConversionEvent{
+		ExternalId:  "order-1",
+		EventType:   "purchase",
+		OrderAmount: 4200,
+		ReferralId:  id,
+		Qualified:   true,
+		OccurredAt:  now,
+	}, &domain.ReferralStatusChange{
+		ReferralId: id,
+		FromStatus: domain.ReferralStatusPending,
+		ToStatus:   domain.ReferralStatusQualified,
+		ChangedBy:  "conversion-events",
+		Reason:     "purchase",
+	})
+	mustNot(t, err)
+	referral, err := r.GetReferral(ctx, id)
+	mustNot(t, err)
+	if referral.Status != domain.ReferralStatusQualified || referral.OrderAmount != 4200 {
+		t.Fatalf("referral after conversion = %+v", referral)
+	}
+	event, err := r.GetConversionEvent(ctx, "order-1")
+	mustNot(t, err)
+	if event.ID != eventId || event.ReferralId != id || !event.Qualified || event.OrderAmount != 4200 || event.OccurredAt != now {
+		t.Fatalf("conversion event = %+v", event)
+	}
+
+	// the same event again is rejected and leaves the referral alone.
+	_, err = r.AddConversionEvent(ctx, domain.ConversionEvent{ExternalId: "order-1", EventType: "purchase"}, nil)
+	mustKind(t, err, domain.ErrAlreadyExists)
+	// a status change that no longer applies rolls the event back.
+	_, err = r.AddConversionEvent(ctx, domain.ConversionEvent{ExternalId: "order-2", EventType: "purchase", ReferralId: id}, &domain.ReferralStatusChange{
+		ReferralId: id,
+		FromStatus: domain.ReferralStatusPending,
+		ToStatus:   domain.ReferralStatusQualified,
+		ChangedBy:  "conversion-events",
+	})
+	mustKind(t, err, domain.ErrConflict)
+	_, err = r.GetConversionEvent(ctx, "order-2")
+	mustKind(t, err, domain.ErrNotFound)
+
+	// events matching no referral and without an external id are kept too.
+	for i := 0; i < 2; i++ {
+		_, err = r.AddConversionEvent(ctx, domain.ConversionEvent{EventType: "signup", ContactEmail: "nobody@example.com", OccurredAt: now}, nil)
+		mustNot(t, err)
+	}
+	_, err = r.AddConversionEvent(ctx, domain.ConversionEvent{EventType: "signup", ReferralId: uuid.New().String()}, nil)
+	mustKind(t, err, domain.ErrInvalidArgument)
+}
+
+func testExpireReferrals(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	windowed, err := r.AddProgram(ctx, "windowed", "Windowed", true, "", "", domain.RewardPolicy{}, domain.QualificationRule{}, 1)
+	mustNot(t, err)
+	_, code := addMember(t, r, windowed, "ada@example.com")
+	_, forever := addMember(t, r, addProgram(t, r), "bob@example.com")
+	first := addReferral(t, r, code, "cy@example.com")
+	second := addReferral(t, r, code, "di@example.com")
+	qualified := addReferral(t, r, code, "ed@example.com")
+	kept := addReferral(t, r, forever, "fay@example.com")
+	mustNot(t, r.UpdateReferralStatus(ctx, domain.ReferralStatusChange{
+		ReferralId: qualified,
+		FromStatus: domain.ReferralStatusPending,
+		ToStatus:   domain.ReferralStatusQualified,
+		ChangedBy:  "ann",
+	}, nil))
+
+	now := time.Now().Unix()
+	expired, err := r.ExpireReferrals(ctx, now, 10, "referral-expiry", "attribution window closed")
+	mustNot(t, err)
+	if len(expired) != 0 {
+		t.Fatalf("expired within the window = %v", expired)
+	}
+
+	later := now + 2*24*60*60
+	expired, err = r.ExpireReferrals(ctx, later, 1, "referral-expiry", "attribution window closed")
+	mustNot(t, err)
+	if len(expired) != 1 {
+		t.Fatalf("expired with limit 1 = %v", expired)
+	}
+	rest, err := r.ExpireReferrals(ctx, later, 10, "referral-expiry", "attribution window closed")
+	mustNot(t, err)
+	got := append(expired, rest...)
+	sort.Strings(got)
+	want := []string{first, second}
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expired = %v, want %v", got, want)
+	}
+
+	referral, err := r.GetReferral(ctx, first)
+	mustNot(t, err)
+	if referral.Status != domain.ReferralStatusExpired || referral.StatusChangedBy != "referral-expiry" || referral.StatusReason != "attribution window closed" {
+		t.Fatalf("expired referral = %+v", referral)
+	}
+	for id, status := range map[string]string{qualified: domain.ReferralStatusQualified, kept: domain.ReferralStatusPending} {
+		referral, err := r.GetReferral(ctx, id)
+		mustNot(t, err)
+		if referral.Status != status {
+			t.Fatalf("referral %s status = %s, want %s", id, referral.Status, status)
+		}
+	}
+
+	// expired is terminal.
+	err = r.UpdateReferralStatus(ctx, domain.ReferralStatusChange{
+		ReferralId: first,
+		FromStatus: domain.ReferralStatusPending,
+		ToStatus:   domain.ReferralStatusApproved,
+		ChangedBy:  "ann",
+	}, nil)
+	mustKind(t, err, domain.ErrConflict)
+}
+
+func testRewards(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	memberId, code := addMember(t, r, programId, "ada@example.com")
+	first, second := addReferral(t, r, code, "cy@example.com"), addReferral(t, r, code, "dee@example.com")
+
+	credit := func(referralId string, amount int64, currency string) string {
+		id, err := r.AddReward(ctx, domain.Reward{
+			Beneficiary: domain.RewardBeneficiaryReferrer,
+			MemberId:    memberId,
+			ProgramId:   programId,
+			ReferralId:  referralId,
+			EntryType:   domain.RewardEntryCredit,
+			Amount:      amount,
+			Currency:    currency,
+		})
+		mustNot(t, err)
+		return id
+	}
+	firstCredit := credit(first, 1000, "USD")
+	credit(second, 700, "EUR")
+
+	// a referee credit has no member and doesn't count towards the referrer.
+	_, err := r.AddReward(ctx, domain.Reward{
+		Beneficiary: domain.RewardBeneficiaryReferee,
+		ProgramId:   programId,
+		ReferralId:  first,
+		EntryType:   domain.RewardEntryCredit,
+		Amount:      300,
+		Currency:    "USD",
+	})
+	mustNot(t, err)
+
+	reward, err := r.GetReward(ctx, firstCredit)
+	mustNot(t, err)
+	if reward.ID != firstCredit || reward.Amount != 1000 || reward.MemberId != memberId || reward.CreatedAt == 0 {
+		t.Fatalf("GetReward = %+v", reward)
+	}
+	_, err = r.GetReward(ctx, "missing")
+	mustKind(t, err, domain.ErrNotFound)
+
+	count, err := r.CountMemberRewards(ctx, memberId, programId)
+	mustNot(t, err)
+	if count != 2 {
+		t.Fatalf("CountMemberRewards = %d, want 2", count)
+	}
+
+	_, err = r.AddReward(ctx, domain.Reward{
+		Beneficiary: domain.RewardBeneficiaryReferrer,
+		MemberId:    memberId,
+		ProgramId:   programId,
+		ReferralId:  first,
+		EntryType:   domain.RewardEntryDebit,
+		Amount:      1000,
+		Currency:    "USD",
+		ReversalOf:  firstCredit,
+	})
+	mustNot(t, err)
+
+	count, err = r.CountMemberRewards(ctx, memberId, programId)
+	mustNot(t, err)
+	if count != 1 {
+		t.Fatalf("CountMemberRewards after reversal = %d, want 1", count)
+	}
+	balances, err := r.GetRewardBalances(ctx, memberId)
+	mustNot(t, err)
+	want := []domain.RewardBalance{
+		{MemberId: memberId, Currency: "EUR", Amount: 700},
+		{MemberId: memberId, Currency: "USD", Amount: 0},
+	}
+	if fmt.Sprint(balances) != fmt.Sprint(want) {
+		t.Fatalf("GetRewardBalances = %+v, want %+v", balances, want)
+	}
+	// the program's totals are kept apart per currency.
+	for currency, want := range map[string]int64{"USD": 300, "EUR": 700, "GBP": 0} {
+		total, err := r.SumProgramRewards(ctx, programId, currency)
+		mustNot(t, err)
+		if total != want {
+			t.Fatalf("SumProgramRewards in %s = %d, want %d", currency, total, want)
+		}
+	}
+
+	rewards, err := r.GetRewards(ctx, memberId, firstPage(100))
+	mustNot(t, err)
+	if len(rewards) != 3 {
+		t.Fatalf("GetRewards returned %d entries, want 3", len(rewards))
+	}
+	checkPagination(t, 3, func(page domain.Page) ([]string, []domain.Cursor) {
+		rewards, err := r.GetRewards(ctx, memberId, page)
+		mustNot(t, err)
+		var ids []string
+		var cursors []domain.Cursor
+		for _, rw := range rewards {
+			ids = append(ids, rw.ID)
+			cursors = append(cursors, domain.Cursor{Key: rw.CreatedAt, ID: rw.ID})
+		}
+		return ids, cursors
+	})
+}
+
+func testRewardConstraints(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	memberId, code := addMember(t, r, programId, "ada@example.com")
+	referralId := addReferral(t, r, code, "cy@example.com")
+
+	valid := domain.Reward{
+		Beneficiary: domain.RewardBeneficiaryReferrer,
+		MemberId:    memberId,
+		ProgramId:   programId,
+		ReferralId:  referralId,
+		EntryType:   domain.RewardEntryCredit,
+		Amount:      1000,
+		Currency:    "USD",
+	}
+	creditId, err := r.AddReward(ctx, valid)
+	mustNot(t, err)
+
+	_, err = r.AddReward(ctx, valid)
+	mustKind(t, err, domain.ErrAlreadyExists)
+
+	invalid := []func(rw *domain.Reward){
+		func(rw *domain.Reward) { rw.Amount = 0 },
+		func(rw *domain.Reward) { rw.Beneficiary = "bogus" },
+		func(rw *domain.Reward) { rw.EntryType = "bogus" },
+		func(rw *domain.Reward) { rw.ReferralId = "missing"; rw.EntryType = domain.RewardEntryDebit },
+	}
+	for i, mutate := range invalid {
+		rw := valid
+		mutate(&rw)
+		_, err = r.AddReward(ctx, rw)
+		if !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Errorf("AddReward invalid case %d error = %v, want %v", i, err, domain.ErrInvalidArgument)
+		}
+	}
+
+	debit := valid
+	debit.EntryType = domain.RewardEntryDebit
+	debit.ReversalOf = creditId
+	_, err = r.AddReward(ctx, debit)
+	mustNot(t, err)
+	_, err = r.AddReward(ctx, debit)
+	mustKind(t, err, domain.ErrAlreadyExists)
+}
+
+func testClicks(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programA, programB := addProgram(t, r), addProgram(t, r)
+	adaId, adaCode := addMember(t, r, programA, "ada@example.com")
+	bobId, bobCode := addMember(t, r, programB, "bob@example.com")
+
+	click := func(memberId, code, programId, ipHash string) {
+		_, err := r.AddClick(ctx, domain.Click{ReferralCode: code, MemberId: memberId, ProgramId: programId, IpHash: ipHash})
+		mustNot(t, err)
+	}
+	click(adaId, adaCode, programA, "h1")
+	click(adaId, adaCode, programA, "h1")
+	click(adaId, adaCode, programA, "h2")
+	click(bobId, bobCode, programB, "h1")
+
+	tests := []struct {
+		name      string
+		memberId  *string
+		programId *string
+		want      domain.ClickStats
+	}{
+		{"all", nil, nil, domain.ClickStats{Clicks: 4, UniqueVisitors: 2}},
+		{"member", &adaId, nil, domain.ClickStats{Clicks: 3, UniqueVisitors: 2}},
+		{"program", nil, &programB, domain.ClickStats{Clicks: 1, UniqueVisitors: 1}},
+		{"member and program", &adaId, &programB, domain.ClickStats{}},
+	}
+	for _, tt := range tests {
+		stats, err := r.GetClickStats(ctx, tt.memberId, tt.programId)
+		mustNot(t, err)
+		if stats != tt.want {
+			t.Errorf("GetClickStats(%s) = %+v, want %+v", tt.name, stats, tt.want)
+		}
+	}
+
+	_, err := r.AddClick(ctx, domain.Click{ReferralCode: adaCode, MemberId: "missing", ProgramId: programA})
+	mustKind(t, err, domain.ErrInvalidArgument)
+}
+
+func testConcurrentInserts(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	_, code := addMember(t, r, programId, "ada@example.com")
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*n)
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			code := fmt.Sprintf("code%d", i)
+			_, err := r.AddMember(ctx, "M", nil, fmt.Sprintf("m%d@example.com", i), programId, code, nil)
+			errs <- err
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			email := fmt.Sprintf("r%d@example.com", i)
+			_, err := r.AddReferral(ctx, domain.Referral{Email: email, ContactEmail: email, ReferralCode: code})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		mustNot(t, err)
+	}
+
+	members, err := r.GetMembers(ctx, domain.MemberFilter{}, firstPage(100))
+	mustNot(t, err)
+	if len(members) != n+1 {
+		t.Fatalf("GetMembers returned %d members, want %d", len(members), n+1)
+	}
+	referrals, err := r.GetReferrals(ctx, domain.ReferralFilter{}, firstPage(100))
+	mustNot(t, err)
+	if len(referrals) != n {
+		t.Fatalf("GetReferrals returned %d referrals, want %d", len(referrals), n)
+	}
+}
+
+// testConcurrentDuplicateInserts races inserts of the same email; exactly one may win.
+func testConcurrentDuplicateInserts(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := r.AddMember(ctx, "Ada", nil, "ada@example.com", programId, newCode(), nil)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	won := 0
+	for err := range errs {
+		if err == nil {
+			won++
+		}
+	}
+	if won != 1 {
+		t.Fatalf("%d concurrent inserts of the same email succeeded, want 1", won)
+	}
+	members, err := r.GetMembers(ctx, domain.MemberFilter{}, firstPage(100))
+	mustNot(t, err)
+	if len(members) != 1 {
+		t.Fatalf("GetMembers returned %d members, want 1", len(members))
+	}
+}
+
+// checkPagination walks total rows with every page size using both offset and
+// cursor paging, and checks both agree with a single page holding every row.
+func checkPagination(t *testing.T, total int, list func(page domain.Page) ([]string, []domain.Cursor)) {
+	t.Helper()
+	all, _ := list(firstPage(total + 10))
+	if len(all) != total {
+		t.Fatalf("single page returned %d rows, want %d", len(all), total)
+	}
+
+	for size := 1; size <= total+1; size++ {
+		var byOffset []string
+		for number := 1; ; number++ {
+			ids, _ := list(domain.Page{Number: number, Size: size})
+			if len(ids) > size {
+				t.Fatalf("page %d of size %d returned %d rows", number, size, len(ids))
+			}
+			byOffset = append(byOffset, ids...)
+			if len(ids) < size {
+				break
+			}
+		}
+		if fmt.Sprint(byOffset) != fmt.Sprint(all) {
+			t.Fatalf("offset pages of size %d = %v, want %v", size, byOffset, all)
+		}
+
+		var byCursor []string
+		page := firstPage(size)
+		for {
+			ids, cursors := list(page)
+			byCursor = append(byCursor, ids...)
+			if len(ids) < size {
+				break
+			}
+			last := cursors[len(cursors)-1]
+			page = domain.Page{Number: 1, Size: size, After: &last}
+		}
+		if fmt.Sprint(byCursor) != fmt.Sprint(all) {
+			t.Fatalf("cursor pages of size %d = %v, want %v", size, byCursor, all)
+		}
+	}
+
+	if ids, _ := list(domain.Page{Number: total + 1, Size: 1}); len(ids) != 0 {
+		t.Fatalf("page past the end returned %d rows", len(ids))
+	}
+}
+
+func addProgram(t *testing.T, r repository.Repository) string {
+	t.Helper()
+	id, err := r.AddProgram(context.Background(), "program", "Program", true, "", "", domain.RewardPolicy{}, domain.QualificationRule{}, 0)
+	mustNot(t, err)
+	return id
+}
+
+// addMember adds a member with a new referral code and returns its id and code.
+func addMember(t *testing.T, r repository.Repository, programId string, email string) (string, string) {
+	t.Helper()
+	id, err := r.AddMember(context.Background(), "Member", nil, email, programId, newCode(), nil)
+	mustNot(t, err)
+	member, err := r.GetMember(context.Background(), id)
+	mustNot(t, err)
+	return id, member.ReferralCode
+}
+
+func addReferral(t *testing.T, r repository.Repository, code string, email string) string {
+	t.Helper()
+	id, err := r.AddReferral(context.Background(), domain.Referral{
+		Email:        email,
+		ContactEmail: strings.ToLower(email),
+		ReferralCode: code,
+	})
+	mustNot(t, err)
+	return id
+}
+
+// newCode returns a referral code no other test uses.
+func newCode() string {
+	return "c" + strings.ReplaceAll(uuid.New().String(), "-", "")[:10]
+}
+
+func firstPage(size int) domain.Page {
+	return domain.Page{Number: 1, Size: size}
+}
+
+func mustNot(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func mustKind(t *testing.T, err error, kind error) {
+	t.Helper()
+	if !errors.Is(err, kind) {
+		t.Fatalf("error = %v, want kind %v", err, kind)
+	}
+}
+
+// testConcurrentLimitedReferrals races referrals under one limit; only Max may be stored,
+// the rest are rejected, or stored flagged when the limit doesn't reject.
+func testConcurrentLimitedReferrals(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	_, code := addMember(t, r, programId, "ada@example.com")
+
+	const n, max = 20, 5
+	limit := domain.ReferralLimit{
+		Filter:        domain.ReferralFilter{ReferralCode: &code},
+		Max:           max,
+		WindowMinutes: 60,
+		Reject:        true,
+		Reason:        "referral code exceeded 5 referrals in 60 minutes",
+	}
+	race := func(limit domain.ReferralLimit) (added int) {
+		var wg sync.WaitGroup
+		errs := make(chan error, n)
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				email := fmt.Sprintf("r%d-%t@example.com", i, limit.Reject)
+				_, err := r.AddReferral(ctx, domain.Referral{Email: email, ContactEmail: email, ReferralCode: code}, limit)
+				errs <- err
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err == nil {
+				added++
+				continue
+			}
+			mustKind(t, err, domain.ErrConflict)
+		}
+		return added
+	}
+
+	if added := race(limit); added != max {
+		t.Fatalf("%d referrals were added under a limit of %d", added, max)
+	}
+
+	limit.Reject = false
+	if added := race(limit); added != n {
+		t.Fatalf("%d referrals were added over a flagging limit, want %d", added, n)
+	}
+	flagged := true
+	referrals, err := r.GetReferrals(ctx, domain.ReferralFilter{Flagged: &flagged}, firstPage(100))
+	mustNot(t, err)
+	if len(referrals) != n || referrals[0].FlagReason != limit.Reason {
+		t.Fatalf("%d referrals over the limit were flagged, want %d", len(referrals), n)
+	}
+}
+
+// approveWithCredit approves a pending referral crediting its referrer amount in USD.
+func approveWithCredit(memberId string, programId string, referralId string, amount int64) (domain.ReferralStatusChange, domain.Reward) {
+	return domain.ReferralStatusChange{
+		ReferralId: referralId,
+		FromStatus: domain.ReferralStatusPending,
+		ToStatus:   domain.ReferralStatusApproved,
+		ChangedBy:  "ann",
+	}, domain.Reward{
+		Beneficiary: domain.RewardBeneficiaryReferrer,
+		MemberId:    memberId,
+		ProgramId:   programId,
+		ReferralId:  referralId,
+		EntryType:   domain.RewardEntryCredit,
+		Amount:      amount,
+		Currency:    "USD",
+	}
+}
+
+// testRewardLimits approves referrals together under limits that fit one of their credits.
+func testRewardLimits(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		limit domain.RewardLimit
+	}{
+		{"program cap", domain.RewardLimit{Currency: "USD", RewardCap: 1500}},
+		{"member limit", domain.RewardLimit{Currency: "USD", MaxRewardsPerMember: 1}},
+	}
+	for i, tt := range tests {
+		programId := addProgram(t, r)
+		memberId, code := addMember(t, r, programId, fmt.Sprintf("limit%d@example.com", i))
+		tt.limit.ProgramId = programId
+		var changes []domain.ReferralStatusChange
+		var rewards []domain.Reward
+		for j := 0; j < 2; j++ {
+			id := addReferral(t, r, code, fmt.Sprintf("limit%d-%d@example.com", i, j))
+			change, reward := approveWithCredit(memberId, programId, id, 1000)
+			changes = append(changes, change)
+			rewards = append(rewards, reward)
+		}
+		mustNot(t, r.UpdateReferralStatuses(ctx, changes, rewards, tt.limit))
+		count, err := r.CountMemberRewards(ctx, memberId, programId)
+		mustNot(t, err)
+		if count != 1 {
+			t.Fatalf("%s: approving two referrals added %d credits, want 1", tt.name, count)
+		}
+		for _, change := range changes {
+			referral, err := r.GetReferral(ctx, change.ReferralId)
+			mustNot(t, err)
+			if referral.Status != domain.ReferralStatusApproved {
+				t.Fatalf("%s: referral over the limit is %s, want approved", tt.name, referral.Status)
+			}
+		}
+	}
+}
+
+// testConcurrentRewardLimits races approvals whose credits only fit the program's cap once.
+func testConcurrentRewardLimits(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	programId := addProgram(t, r)
+	memberId, code := addMember(t, r, programId, "ada@example.com")
+
+	const n = 10
+	limit := domain.RewardLimit{ProgramId: programId, Currency: "USD", RewardCap: 1500}
+	var ids []string
+	for i := 0; i < n; i++ {
+		ids = append(ids, addReferral(t, r, code, fmt.Sprintf("cap%d@example.com", i)))
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			change, reward := approveWithCredit(memberId, programId, id, 1000)
+			errs <- r.UpdateReferralStatus(ctx, change, []domain.Reward{reward}, limit)
+		}(id)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		mustNot(t, err)
+	}
+
+	total, err := r.SumProgramRewards(ctx, programId, "USD")
+	mustNot(t, err)
+	if total != 1000 {
+		t.Fatalf("concurrent approvals issued %d under a cap of %d", total, limit.RewardCap)
+	}
+}
@@ -0,0 +1,21 @@
+package repository
+
+import "referral-service/domain"
+
+// claimable reports why reviewer can't claim referral, nil if they can. Claims
+// made before staleBefore are abandoned and can be taken over.
+func claimable(referral domain.Referral, reviewer string, staleBefore int64) error {
+	if referral.Status != domain.ReferralStatusPending {
+		return domain.ErrReferralNotPending
+	}
+	if claimedByOther(referral, reviewer, staleBefore) {
+		return domain.ErrReferralClaimed
+	}
+	return nil
+}
+
+// claimedByOther reports whether a reviewer other than reviewer holds a claim on
+// referral made at staleBefore or later.
+func claimedByOther(referral domain.Referral, reviewer string, staleBefore int64) bool {
+	return referral.ClaimedBy != "" && referral.ClaimedBy != reviewer && referral.ClaimedAt >= staleBefore
+}
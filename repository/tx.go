@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// RetryPolicy bounds how often and how fast transactions aborted by postgres are retried.
+type RetryPolicy struct {
+	MaxRetries  int `yaml:"max_retries"`
+	BaseDelayMs int `yaml:"base_delay_ms"`
+	MaxDelayMs  int `yaml:"max_delay_ms"`
+}
+
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 5, BaseDelayMs: 10, MaxDelayMs: 500}
+
+// txStats counts retried transactions over the lifetime of the repository.
+type txStats struct {
+	retries   atomic.Int64
+	exhausted atomic.Int64
+}
+
+// RetryStats counts the transactions rerun after postgres aborted them, Retries
+// per rerun and Exhausted per transaction that failed after its last retry.
+type RetryStats struct {
+	Retries   int64
+	Exhausted int64
+}
+
+// RetryReporter is implemented by repositories that retry aborted transactions.
+type RetryReporter interface {
+	RetryStats() RetryStats
+}
+
+// RetryStats returns the retries counted since the repository was created.
+func (r *pgRepository) RetryStats() RetryStats {
+	return RetryStats{Retries: r.stats.retries.Load(), Exhausted: r.stats.exhausted.Load()}
+}
+
+// withTx runs fn in a serializable transaction and commits it. Serializable
+// transactions that race are aborted by postgres with a serialization failure or
+// a deadlock; the whole transaction is then rerun after a jittered backoff, so
+// fn must not have side effects outside tx.
+func (r *pgRepository) withTx(ctx context.Context, op string, fn func(tx *sqlx.Tx) error) error {
+	for attempt := 0; ; attempt++ {
+		err := r.runTx(ctx, fn)
+		if err == nil || !retryable(err) {
+			return err
+		}
+		if attempt == r.retry.MaxRetries {
+			r.stats.exhausted.Add(1)
+			r.log.Warn("transaction retries exhausted",
+				zap.String("op", op),
+				zap.Int("attempts", attempt+1),
+				zap.Int64("exhausted_total", r.stats.exhausted.Load()),
+				zap.Error(err))
+			return err
+		}
+
+		delay := r.retry.backoff(attempt)
+		r.log.Info("retrying transaction",
+			zap.String("op", op),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("delay", delay),
+			zap.Int64("retries_total", r.stats.retries.Add(1)),
+			zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (r *pgRepository) runTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, r.txOpts)
+	if err != nil {
+		return fmt.Errorf("schema transaction begin %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction %w", err)
+	}
+	return nil
+}
+
+// retryable reports whether err aborted a transaction that may succeed when rerun.
+func retryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code.Name() {
+	case "serialization_failure", "deadlock_detected":
+		return true
+	}
+	return false
+}
+
+// backoff is the delay before retry attempt+1: a random duration up to
+// BaseDelayMs doubled per attempt and capped at MaxDelayMs.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := time.Duration(p.MaxDelayMs) * time.Millisecond
+	if attempt < 30 {
+		if exp := time.Duration(p.BaseDelayMs) * time.Millisecond << attempt; exp < ceiling {
+			ceiling = exp
+		}
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&pq.Error{Code: "40001"}, true},
+		{&pq.Error{Code: "40P01"}, true},
+		{fmt.Errorf("commit transaction %w", &pq.Error{Code: "40001"}), true},
+		{&pq.Error{Code: "23505"}, false},
+		{errors.New("connection refused"), false},
+	}
+	for _, tt := range tests {
+		if got := retryable(tt.err); got != tt.want {
+			t.Errorf("retryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, BaseDelayMs: 10, MaxDelayMs: 50}
+	for attempt := 0; attempt < 40; attempt++ {
+		ceiling := 10 * time.Millisecond << attempt
+		if attempt >= 3 {
+			ceiling = 50 * time.Millisecond
+		}
+		for i := 0; i < 100; i++ {
+			if delay := policy.backoff(attempt); delay < 0 || delay > ceiling {
+				t.Fatalf("backoff(%d) = %v, want within [0, %v]", attempt, delay, ceiling)
+			}
+		}
+	}
+	if delay := (RetryPolicy{}).backoff(3); delay != 0 {
+		t.Fatalf("backoff without delays = %v, want 0", delay)
+	}
+}
+
+// abortingDriver opens connections whose first aborts commits fail with a
+// serialization failure, the way postgres aborts racing serializable transactions.
+type abortingDriver struct {
+	aborts atomic.Int64
+}
+
+func (d *abortingDriver) Open(name string) (driver.Conn, error) {
+	return abortingConn{d}, nil
+}
+
+type abortingConn struct {
+	d *abortingDriver
+}
+
+func (c abortingConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c abortingConn) Close() error {
+	return nil
+}
+
+func (c abortingConn) Begin() (driver.Tx, error) {
+	return abortingTx(c), nil
+}
+
+func (c abortingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return abortingTx(c), nil
+}
+
+type abortingTx struct {
+	d *abortingDriver
+}
+
+func (tx abortingTx) Commit() error {
+	if tx.d.aborts.Add(-1) >= 0 {
+		return &pq.Error{Code: "40001"}
+	}
+	return nil
+}
+
+func (tx abortingTx) Rollback() error {
+	return nil
+}
+
+func TestWithTxRetryStats(t *testing.T) {
+	d := &abortingDriver{}
+	db := sqlx.NewDb(sql.OpenDB(driverConnector{d}), "postgres")
+	defer db.Close()
+	r := NewPostgres(zap.NewNop(), db, RetryPolicy{MaxRetries: 2}).(*pgRepository)
+	noop := func(tx *sqlx.Tx) error { return nil }
+
+	d.aborts.Store(2)
+	if err := r.withTx(context.Background(), "test", noop); err != nil {
+		t.Fatal(err)
+	}
+	if stats := r.RetryStats(); stats != (RetryStats{Retries: 2}) {
+		t.Fatalf("RetryStats after two aborts = %+v, want 2 retries", stats)
+	}
+
+	d.aborts.Store(3)
+	if err := r.withTx(context.Background(), "test", noop); !retryable(err) {
+		t.Fatalf("withTx aborted past its retries = %v, want the serialization failure", err)
+	}
+	if stats := r.RetryStats(); stats != (RetryStats{Retries: 4, Exhausted: 1}) {
+		t.Fatalf("RetryStats after exhausting the retries = %+v, want 4 retries and 1 exhausted", stats)
+	}
+
+	var reporter Repository = r
+	if _, ok := reporter.(RetryReporter); !ok {
+		t.Fatal("postgres repository doesn't report its retries")
+	}
+}
+
+// driverConnector connects to a driver without registering it.
+type driverConnector struct {
+	d driver.Driver
+}
+
+func (c driverConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.d.Open("")
+}
+
+func (c driverConnector) Driver() driver.Driver {
+	return c.d
+}
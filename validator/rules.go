@@ -0,0 +1,199 @@
+package validator
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	pb "referral-service/proto/referral"
+)
+
+const (
+	maxNameLength  = 100
+	maxTitleLength = 200
+	maxEmailLength = 254
+	maxTextLength  = 1000
+	maxPageSize    = 1000
+	minPhoneDigits = 7
+	maxPhoneDigits = 15
+	maxCodePrefix  = 8
+	maxLabelLength = 32
+	maxReviewBatch = 100
+	maxConversions = 100
+)
+
+// violations collects field level problems of a single request.
+type violations []fieldViolation
+
+type fieldViolation struct {
+	field       string
+	description string
+}
+
+func (v *violations) add(field string, format string, args ...interface{}) {
+	*v = append(*v, fieldViolation{field: field, description: fmt.Sprintf(format, args...)})
+}
+
+func (v *violations) required(field string, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+	}
+}
+
+func (v *violations) name(field string, value string, required bool) {
+	if required {
+		v.required(field, value)
+	}
+	if utf8.RuneCountInString(value) > maxNameLength {
+		v.add(field, "must be at most %d characters", maxNameLength)
+	}
+}
+
+func (v *violations) optionalName(field string, value *string) {
+	if value != nil {
+		v.name(field, *value, true)
+	}
+}
+
+func (v *violations) text(field string, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		v.add(field, "must be at most %d characters", max)
+	}
+}
+
+// email accepts a bare address (no display name) such as john@gmail.com.
+func (v *violations) email(field string, value string) {
+	if len(value) > maxEmailLength {
+		v.add(field, "must be at most %d characters", maxEmailLength)
+		return
+	}
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Address != value {
+		v.add(field, "must be a valid email address")
+		return
+	}
+	if host := value[strings.LastIndex(value, "@")+1:]; !strings.Contains(host, ".") {
+		v.add(field, "must be a valid email address")
+	}
+}
+
+func (v *violations) optionalEmail(field string, value *string) {
+	if value != nil {
+		v.email(field, *value)
+	}
+}
+
+// phone accepts digits with an optional leading + and common separators, ex. +1 (111) 111-1111.
+func (v *violations) phone(field string, value string) {
+	digits := 0
+	for i, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			v.add(field, "must be a phone number")
+			return
+		}
+	}
+	if digits < minPhoneDigits || digits > maxPhoneDigits {
+		v.add(field, "must have between %d and %d digits", minPhoneDigits, maxPhoneDigits)
+	}
+}
+
+func (v *violations) optionalPhone(field string, value *string) {
+	if value != nil {
+		v.phone(field, *value)
+	}
+}
+
+func (v *violations) page(page *int64, size *int64) {
+	if page != nil && *page < 1 {
+		v.add("page", "must be at least 1")
+	}
+	if size != nil && (*size < 1 || *size > maxPageSize) {
+		v.add("size", "must be between 1 and %d", maxPageSize)
+	}
+}
+
+func (v *violations) oneOf(field string, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(field, "must be one of %s", strings.Join(allowed, ", "))
+}
+
+// codePrefix accepts an empty prefix or up to maxCodePrefix lowercase letters and digits.
+func (v *violations) codePrefix(field string, value string) {
+	if len(value) > maxCodePrefix {
+		v.add(field, "must be at most %d characters", maxCodePrefix)
+		return
+	}
+	for _, c := range value {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+			v.add(field, "must only contain lowercase letters and digits")
+			return
+		}
+	}
+}
+
+// label accepts up to maxLabelLength lowercase letters, digits, dashes and underscores, ex. in-store.
+func (v *violations) label(field string, value string) {
+	if value == "" || len(value) > maxLabelLength {
+		v.add(field, "must be between 1 and %d characters", maxLabelLength)
+		return
+	}
+	for _, c := range value {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			v.add(field, "must only contain lowercase letters, digits, dashes and underscores")
+			return
+		}
+	}
+}
+
+// qualification accepts a rule with no event, or an event label and non-negative limits.
+func (v *violations) qualification(field string, rule *pb.QualificationRule) {
+	if rule == nil {
+		return
+	}
+	if rule.Event != "" {
+		v.label(field+".event", rule.Event)
+	}
+	if rule.MinOrderAmount < 0 {
+		v.add(field+".min_order_amount", "can't be negative")
+	}
+	if rule.WithinDays < 0 {
+		v.add(field+".within_days", "can't be negative")
+	}
+}
+
+// conversionEvent requires an event type label and something to match the referral by.
+func (v *violations) conversionEvent(field string, event *pb.ConversionEvent) {
+	if event == nil {
+		v.add(field, "is required")
+		return
+	}
+	if event.EventId != nil {
+		v.required(field+".event_id", *event.EventId)
+		v.text(field+".event_id", *event.EventId, maxNameLength)
+	}
+	v.label(field+".event_type", event.EventType)
+	v.optionalEmail(field+".email", event.Email)
+	v.optionalPhone(field+".phone", event.Phone)
+	if event.GetAttributionToken() == "" && event.GetEmail() == "" && event.GetPhone() == "" {
+		v.add(field+".attribution_token", "attribution token, email or phone is required")
+	}
+	if event.ProgramId != nil {
+		v.required(field+".program_id", *event.ProgramId)
+	}
+	if event.OrderAmount != nil && *event.OrderAmount < 0 {
+		v.add(field+".order_amount", "can't be negative")
+	}
+	if event.OccurredAt != nil && *event.OccurredAt < 0 {
+		v.add(field+".occurred_at", "can't be negative")
+	}
+}
@@ -0,0 +1,234 @@
+// Package validator checks rpc requests before they reach the handlers.
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "referral-service/proto/referral"
+)
+
+// UnaryServerInterceptor rejects invalid requests with InvalidArgument and
+// field level BadRequest details.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := Validate(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Validate returns an InvalidArgument status error describing every invalid field of req,
+// or nil when req is valid or has no rules.
+func Validate(req interface{}) error {
+	v := violations{}
+	switch r := req.(type) {
+	// program
+	case *pb.GetProgramsRequest:
+		v.page(r.Page, r.Size)
+	case *pb.GetProgramRequest:
+		v.required("id", r.Id)
+	case *pb.AddProgramRequest:
+		v.name("name", r.Name, true)
+		v.text("title", r.Title, maxTitleLength)
+		v.text("landing_url", r.LandingUrl, maxTextLength)
+		v.codePrefix("code_prefix", r.CodePrefix)
+		v.qualification("qualification", r.Qualification)
+		if r.AttributionWindowDays < 0 {
+			v.add("attribution_window_days", "can't be negative")
+		}
+	case *pb.UpdateProgramRequest:
+		v.required("id", r.Id)
+		v.optionalName("name", r.Name)
+		if r.Title != nil {
+			v.text("title", *r.Title, maxTitleLength)
+		}
+		if r.LandingUrl != nil {
+			v.text("landing_url", *r.LandingUrl, maxTextLength)
+		}
+		if r.CodePrefix != nil {
+			v.codePrefix("code_prefix", *r.CodePrefix)
+		}
+		v.qualification("qualification", r.Qualification)
+		if r.AttributionWindowDays != nil && *r.AttributionWindowDays < 0 {
+			v.add("attribution_window_days", "can't be negative")
+		}
+
+	// member
+	case *pb.GetMembersRequest:
+		v.page(r.Page, r.Size)
+		v.optionalEmail("email", r.Email)
+	case *pb.GetMemberRequest:
+		v.required("id", r.Id)
+	case *pb.GetMemberByEmailRequest:
+		v.email("email", r.Email)
+	case *pb.GetMemberByReferralCodeRequest:
+		v.required("referral_code", r.ReferralCode)
+	case *pb.AddMemberRequest:
+		v.name("first_name", r.FirstName, true)
+		v.optionalName("last_name", r.LastName)
+		v.email("email", r.Email)
+		v.required("program_id", r.ProgramId)
+	case *pb.UpdateMemberRequest:
+		v.required("id", r.Id)
+		v.optionalName("first_name", r.FirstName)
+		v.optionalName("last_name", r.LastName)
+		v.optionalEmail("email", r.Email)
+		if r.ProgramId != nil {
+			v.required("program_id", *r.ProgramId)
+		}
+	case *pb.DeactivateMemberRequest:
+		v.required("id", r.Id)
+	case *pb.ClaimVanityCodeRequest:
+		v.required("id", r.Id)
+		v.required("referral_code", r.ReferralCode)
+	case *pb.AddReferralCodeRequest:
+		v.required("member_id", r.MemberId)
+		if r.Code != nil {
+			v.required("code", *r.Code)
+		}
+		if r.Channel != nil {
+			v.label("channel", *r.Channel)
+		}
+	case *pb.GetReferralCodesRequest:
+		v.required("member_id", r.MemberId)
+	case *pb.RevokeReferralCodeRequest:
+		v.required("member_id", r.MemberId)
+		v.required("code", r.Code)
+	case *pb.ReferralLinkWrapper:
+		v.email("email", r.GetReferrallink().GetEmail())
+
+	// referral
+	case *pb.GetReferralsRequest:
+		v.page(r.Page, r.Size)
+		v.optionalEmail("email", r.Email)
+		if r.CreatedAfter != nil && r.CreatedBefore != nil && *r.CreatedAfter >= *r.CreatedBefore {
+			v.add("created_before", "must be after created_after")
+		}
+		if r.SortBy != nil {
+			v.oneOf("sort_by", *r.SortBy, "created_at", "updated_at", "risk_score")
+		}
+		if r.SortOrder != nil {
+			v.oneOf("sort_order", *r.SortOrder, "asc", "desc")
+		}
+	case *pb.AddReferralRequest:
+		v.required("referral_code", r.ReferralCode)
+		v.optionalName("first_name", r.FirstName)
+		v.optionalName("last_name", r.LastName)
+		v.optionalEmail("email", r.Email)
+		v.optionalPhone("phone", r.Phone)
+		if r.GetEmail() == "" && r.GetPhone() == "" {
+			v.add("email", "email or phone is required")
+		}
+	case *pb.QualifyReferralRequest:
+		v.statusChange(r.Id, r.ChangedBy, r.Reason)
+	case *pb.ApproveReferralRequest:
+		v.statusChange(r.Id, r.ChangedBy, r.Reason)
+		if r.OrderAmount != nil && *r.OrderAmount < 0 {
+			v.add("order_amount", "can't be negative")
+		}
+	case *pb.DenyReferralRequest:
+		v.statusChange(r.Id, r.ChangedBy, r.Reason)
+	case *pb.ReviewReferralsRequest:
+		if len(r.Ids) == 0 || len(r.Ids) > maxReviewBatch {
+			v.add("ids", "must list 1 to %d referrals", maxReviewBatch)
+		}
+		for _, id := range r.Ids {
+			v.required("ids", id)
+		}
+		v.oneOf("status", r.Status, "approved", "denied")
+		v.name("changed_by", r.ChangedBy, true)
+		v.text("reason", r.Reason, maxTextLength)
+
+	// review
+	case *pb.GetReviewQueueRequest:
+		v.page(r.Page, r.Size)
+		if r.SortBy != nil {
+			v.oneOf("sort_by", *r.SortBy, "created_at", "updated_at", "risk_score")
+		}
+		if r.SortOrder != nil {
+			v.oneOf("sort_order", *r.SortOrder, "asc", "desc")
+		}
+	case *pb.ClaimReferralRequest:
+		v.required("id", r.Id)
+		v.name("reviewer", r.Reviewer, true)
+	case *pb.ReleaseReferralRequest:
+		v.required("id", r.Id)
+		v.name("reviewer", r.Reviewer, true)
+	case *pb.FlagReferralRequest:
+		v.required("id", r.Id)
+		if r.Flagged {
+			v.required("reason", r.Reason)
+		}
+		v.text("reason", r.Reason, maxTextLength)
+	case *pb.AddReferralNoteRequest:
+		v.required("id", r.Id)
+		v.name("author", r.Author, true)
+		v.required("note", r.Note)
+		v.text("note", r.Note, maxTextLength)
+	case *pb.GetReferralNotesRequest:
+		v.required("id", r.Id)
+
+	// conversion
+	case *pb.IngestConversionEventRequest:
+		v.conversionEvent("event", r.Event)
+	case *pb.IngestConversionEventsRequest:
+		if len(r.Events) == 0 || len(r.Events) > maxConversions {
+			v.add("events", "must list 1 to %d events", maxConversions)
+		}
+		for i, event := range r.Events {
+			v.conversionEvent(fmt.Sprintf("events[%d]", i), event)
+		}
+
+	// reward
+	case *pb.GetRewardBalanceRequest:
+		v.required("member_id", r.MemberId)
+	case *pb.GetRewardsRequest:
+		v.required("member_id", r.MemberId)
+		v.page(r.Page, r.Size)
+	case *pb.ReverseRewardRequest:
+		v.required("id", r.Id)
+		v.name("reversed_by", r.ReversedBy, true)
+		v.text("reason", r.Reason, maxTextLength)
+	}
+
+	if len(v) == 0 {
+		return nil
+	}
+	return v.err()
+}
+
+func (v *violations) statusChange(id string, changedBy string, reason string) {
+	v.required("id", id)
+	v.name("changed_by", changedBy, true)
+	v.text("reason", reason, maxTextLength)
+}
+
+// err builds the InvalidArgument status carrying a BadRequest detail per violation.
+func (v violations) err() error {
+	badRequest := &errdetails.BadRequest{}
+	for _, fv := range v {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fv.field,
+			Description: fv.description,
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, "invalid "+v[0].field+": "+v[0].description)
+	detailed, err := st.WithDetails(badRequest)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "referral-service/proto/referral"
+)
+
+// badRequest returns the field violations Validate reported for req.
+func badRequest(t *testing.T, req interface{}) []fieldViolation {
+	t.Helper()
+	err := Validate(req)
+	if err == nil {
+		return nil
+	}
+	st, _ := status.FromError(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("code = %s, want InvalidArgument", st.Code())
+	}
+	var got []fieldViolation
+	for _, detail := range st.Details() {
+		br, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, fv := range br.FieldViolations {
+			got = append(got, fieldViolation{field: fv.Field, description: fv.Description})
+		}
+	}
+	if len(got) == 0 {
+		t.Fatalf("%v carries no field violations", err)
+	}
+	return got
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  interface{}
+		want []fieldViolation
+	}{
+		// required
+		{"valid", &pb.GetMemberRequest{Id: "m1"}, nil},
+		{"required", &pb.GetMemberRequest{Id: " "}, []fieldViolation{{"id", "is required"}}},
+		{"required fields", &pb.AddMemberRequest{Email: "ada@example.com"}, []fieldViolation{
+			{"first_name", "is required"},
+			{"program_id", "is required"},
+		}},
+		{"email or phone", &pb.AddReferralRequest{ReferralCode: "abc"}, []fieldViolation{
+			{"email", "email or phone is required"},
+		}},
+		{"request without rules", &pb.GetClickStatsRequest{}, nil},
+
+		// email
+		{"email", &pb.GetMemberByEmailRequest{Email: "ada@example.com"}, nil},
+		{"email without domain", &pb.GetMemberByEmailRequest{Email: "ada@example"}, []fieldViolation{
+			{"email", "must be a valid email address"},
+		}},
+		{"email with display name", &pb.GetMemberByEmailRequest{Email: "Ada <ada@example.com>"}, []fieldViolation{
+			{"email", "must be a valid email address"},
+		}},
+		{"optional email", &pb.GetMembersRequest{Email: proto.String("ada")}, []fieldViolation{
+			{"email", "must be a valid email address"},
+		}},
+
+		// phone
+		{"phone", &pb.AddReferralRequest{ReferralCode: "abc", Phone: proto.String("+1 (555) 010-0000")}, nil},
+		{"phone with letters", &pb.AddReferralRequest{ReferralCode: "abc", Phone: proto.String("555-CALL-NOW")}, []fieldViolation{
+			{"phone", "must be a phone number"},
+		}},
+		{"short phone", &pb.AddReferralRequest{ReferralCode: "abc", Phone: proto.String("555")}, []fieldViolation{
+			{"phone", "must have between 7 and 15 digits"},
+		}},
+
+		// enums
+		{"sort", &pb.GetReferralsRequest{SortBy: proto.String("risk_score"), SortOrder: proto.String("desc")}, nil},
+		{"unknown sort", &pb.GetReferralsRequest{SortBy: proto.String("name"), SortOrder: proto.String("up")}, []fieldViolation{
+			{"sort_by", "must be one of created_at, updated_at, risk_score"},
+			{"sort_order", "must be one of asc, desc"},
+		}},
+		{"review status", &pb.ReviewReferralsRequest{Ids: []string{"r1"}, Status: "qualified", ChangedBy: "ann"}, []fieldViolation{
+			{"status", "must be one of approved, denied"},
+		}},
+
+		// page
+		{"page", &pb.GetProgramsRequest{Page: proto.Int64(1), Size: proto.Int64(1000)}, nil},
+		{"page below 1", &pb.GetProgramsRequest{Page: proto.Int64(0)}, []fieldViolation{
+			{"page", "must be at least 1"},
+		}},
+		{"page size too large", &pb.GetProgramsRequest{Size: proto.Int64(1001)}, []fieldViolation{
+			{"size", "must be between 1 and 1000"},
+		}},
+		{"empty page", &pb.GetRewardsRequest{MemberId: "m1", Size: proto.Int64(0)}, []fieldViolation{
+			{"size", "must be between 1 and 1000"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := badRequest(t, tt.req); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}